@@ -0,0 +1,47 @@
+package html
+
+// abbreviations.go collects <abbr title> expansions for ExtractAbbreviations
+// and writes them into the text for ExpandAbbreviations.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// extractAbbreviations returns the expansion of each abbreviation under
+// contentNode, keyed by its whitespace-collapsed text; the first title given
+// for an abbreviation wins. With ExpandAbbreviations, the first occurrence of
+// each is followed by its expansion in parentheses. <abbr> elements without a
+// title, or whose title repeats their text, are skipped.
+func (p *Processor) extractAbbreviations(contentNode *stdxhtml.Node) map[string]string {
+	abbreviations := make(map[string]string)
+	var firsts []*stdxhtml.Node // first occurrence of each abbreviation
+	internal.WalkNodes(contentNode, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode || n.Data != "abbr" {
+			return true
+		}
+		text := strings.Join(strings.Fields(internal.GetTextContent(n)), " ")
+		title := strings.Join(strings.Fields(internal.GetAttr(n, "title")), " ")
+		if text == "" || title == "" || strings.EqualFold(text, title) {
+			return false
+		}
+		if _, ok := abbreviations[text]; !ok {
+			abbreviations[text] = title
+			firsts = append(firsts, n)
+		}
+		return false
+	})
+
+	if p.config.ExpandAbbreviations {
+		for _, n := range firsts {
+			title := strings.Join(strings.Fields(internal.GetAttr(n, "title")), " ")
+			n.Parent.InsertBefore(&stdxhtml.Node{Type: stdxhtml.TextNode, Data: " (" + title + ")"}, n.NextSibling)
+		}
+	}
+	if len(abbreviations) == 0 {
+		return nil
+	}
+	return abbreviations
+}
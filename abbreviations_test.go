@@ -0,0 +1,66 @@
+package html_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p><abbr title="National Aeronautics and Space Administration">NASA</abbr> launched the probe.
+		Later, <abbr title="Not the first">NASA</abbr> confirmed it.</p>
+		<p>Written in <em><abbr title="HyperText  Markup Language">HTML</abbr></em> and <abbr>CSS</abbr>.</p>
+		<p><abbr title="ok">OK</abbr></p>
+	</article></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.Abbreviations != nil || strings.Contains(result.Text, "(National") {
+		t.Errorf("Abbreviations = %v, Text = %q; want neither collected nor expanded by default", result.Abbreviations, result.Text)
+	}
+
+	cfg.ExtractAbbreviations = true
+	cfg.ExpandAbbreviations = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := map[string]string{
+		"NASA": "National Aeronautics and Space Administration",
+		"HTML": "HyperText Markup Language",
+	}
+	if len(result.Abbreviations) != len(want) {
+		t.Errorf("Abbreviations = %v, want %v", result.Abbreviations, want)
+	}
+	for abbr, title := range want {
+		if result.Abbreviations[abbr] != title {
+			t.Errorf("Abbreviations[%q] = %q, want %q", abbr, result.Abbreviations[abbr], title)
+		}
+	}
+	for _, s := range []string{
+		"NASA (National Aeronautics and Space Administration) launched",
+		"Later, NASA confirmed",
+		"HTML (HyperText Markup Language) and CSS",
+	} {
+		if !strings.Contains(result.Text, s) {
+			t.Errorf("Text = %q, want it to contain %q", result.Text, s)
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"abbreviations":{"HTML":`) {
+		t.Errorf("JSON = %s, want abbreviations", data)
+	}
+}
@@ -0,0 +1,130 @@
+package html
+
+// author.go extracts the document author from JSON-LD, meta tags, rel="author"
+// links, and byline elements.
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxAuthorLength bounds byline text, so that a large element whose class
+// merely mentions "author" (such as an author bio box) is not taken as a name.
+const maxAuthorLength = 100
+
+// collectAuthor returns the first non-empty author found, checking in order
+// JSON-LD author.name, <meta name="author">, article:author, rel="author"
+// links, and elements whose class contains "author" or "byline". article:author
+// values that are profile URLs rather than names are skipped.
+func collectAuthor(doc *stdxhtml.Node) string {
+	if doc == nil {
+		return ""
+	}
+	var jsonLD, metaName, articleAuthor, relAuthor, byline string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		switch n.Data {
+		case "script":
+			if jsonLD == "" && isJSONLDScript(n) {
+				if data, ok := decodeJSONLD(n); ok {
+					jsonLD = jsonLDAuthor(data, 0)
+				}
+			}
+			return false
+		case "meta":
+			key, content := metaKeyContent(n)
+			switch key {
+			case "author":
+				if metaName == "" {
+					metaName = content
+				}
+			case "article:author":
+				if articleAuthor == "" && !internal.IsExternalURL(content) {
+					articleAuthor = content
+				}
+			}
+			return true
+		}
+		if relAuthor == "" && n.Data == "a" && relHasToken(strings.ToLower(internal.GetAttr(n, "rel")), "author") {
+			relAuthor = bylineText(n)
+		}
+		if byline == "" {
+			class := strings.ToLower(internal.GetAttr(n, "class"))
+			if strings.Contains(class, "author") || strings.Contains(class, "byline") {
+				byline = bylineText(n)
+			}
+		}
+		return true
+	})
+	for _, candidate := range []string{jsonLD, metaName, articleAuthor, relAuthor, byline} {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// jsonLDAuthor returns the first author name in a decoded JSON-LD value. The
+// author property may be a plain string, a Person object, or an array of either.
+func jsonLDAuthor(v any, depth int) string {
+	if depth > 16 {
+		return ""
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		if author, ok := v["author"]; ok {
+			if name := authorName(author); name != "" {
+				return name
+			}
+		}
+		for _, child := range v {
+			if name := jsonLDAuthor(child, depth+1); name != "" {
+				return name
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if name := jsonLDAuthor(child, depth+1); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// authorName extracts a name from a JSON-LD author property value.
+func authorName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		if name, ok := v["name"].(string); ok {
+			return strings.TrimSpace(name)
+		}
+	case []any:
+		for _, item := range v {
+			if name := authorName(item); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// bylineText returns the whitespace-collapsed text of n with a leading "By"
+// removed, or "" when the text is too long to be a name.
+func bylineText(n *stdxhtml.Node) string {
+	text := strings.Join(strings.Fields(internal.GetTextContent(n)), " ")
+	if len(text) > 3 && strings.EqualFold(text[:3], "by ") {
+		text = strings.TrimSpace(text[3:])
+	}
+	if utf8.RuneCountInString(text) > maxAuthorLength {
+		return ""
+	}
+	return text
+}
@@ -2,8 +2,10 @@ package html
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // BatchResult holds the results of a batch extraction operation.
@@ -22,6 +24,21 @@ type BatchResult struct {
 	Cancelled int
 }
 
+// Err returns a *BatchError listing every item that failed or was cancelled,
+// or nil when all items succeeded. Results still holds the successful items.
+func (br *BatchResult) Err() error {
+	var items []ItemError
+	for i, err := range br.Errors {
+		if err != nil {
+			items = append(items, ItemError{Index: i, Err: err})
+		}
+	}
+	if items == nil {
+		return nil
+	}
+	return &BatchError{Items: items, Total: len(br.Errors)}
+}
+
 // extractFunc is a function type for extracting content from a single input.
 type extractFunc func() (*Result, error)
 
@@ -79,6 +96,23 @@ func ExtractBatchWithContext(ctx context.Context, htmlContents [][]byte, cfg ...
 	})
 }
 
+// ExtractBatchWithBudget extracts content from multiple HTML byte slices concurrently
+// within an overall time budget.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractBatchWithBudget for how the budget is applied.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractBatchWithBudget(htmlContents [][]byte, budget time.Duration, cfg ...Config) *BatchResult {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return uniformErrorBatch(len(htmlContents), err)
+	}
+	return withProcessorBatch(pooled, c, len(htmlContents), func(p *Processor) *BatchResult {
+		return p.ExtractBatchWithBudget(htmlContents, budget)
+	})
+}
+
 // ExtractBatchFiles extracts content from multiple HTML files concurrently.
 // This is a convenience function that uses a pooled Processor for efficiency.
 // The concurrency level is controlled by the WorkerPoolSize configuration (default: 4).
@@ -144,6 +178,31 @@ func (p *Processor) ExtractBatchWithContext(ctx context.Context, htmlContents []
 	return p.runBatchWithContext(ctx, extractors)
 }
 
+// ExtractBatchWithBudget extracts content from multiple HTML byte slices concurrently,
+// aborting the whole batch once budget has elapsed, on top of the per-item ProcessingTimeout.
+// Items completed within the budget keep their results; items still running or not
+// yet started are counted in BatchResult.Cancelled with context.DeadlineExceeded as
+// their error. A budget of zero or less means no overall limit, as with ExtractBatch.
+func (p *Processor) ExtractBatchWithBudget(htmlContents [][]byte, budget time.Duration) *BatchResult {
+	if br := p.prepareBatch(len(htmlContents)); br != nil {
+		return br
+	}
+
+	ctx := context.Background()
+	if budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget)
+		defer cancel()
+	}
+
+	extractors := make([]extractFunc, len(htmlContents))
+	for i, content := range htmlContents {
+		extractors[i] = p.extractorForBytesWithContext(ctx, content)
+	}
+
+	return p.runBatchWithContext(ctx, extractors)
+}
+
 // ExtractBatchFiles extracts content from multiple HTML files concurrently.
 // The concurrency level is controlled by the WorkerPoolSize configuration (default: 4).
 // Each extraction is performed independently with automatic encoding detection.
@@ -184,6 +243,14 @@ func (p *Processor) extractorForBytes(htmlBytes []byte) extractFunc {
 	}
 }
 
+// extractorForBytesWithContext creates an extractFunc for byte slice input
+// that is aborted when ctx is done.
+func (p *Processor) extractorForBytesWithContext(ctx context.Context, htmlBytes []byte) extractFunc {
+	return func() (*Result, error) {
+		return p.ExtractWithContext(ctx, htmlBytes)
+	}
+}
+
 // extractorForFile creates an extractFunc for file path input.
 func (p *Processor) extractorForFile(filePath string) extractFunc {
 	return func() (*Result, error) {
@@ -264,10 +331,15 @@ func (p *Processor) runBatchWithContext(ctx context.Context, extractors []extrac
 			mu.Lock()
 			defer mu.Unlock()
 
-			if err != nil {
+			switch {
+			case err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()):
+				// Aborted mid-extraction by the batch context.
+				br.Errors[idx] = err
+				br.Cancelled++
+			case err != nil:
 				br.Errors[idx] = err
 				br.Failed++
-			} else {
+			default:
 				br.Results[idx] = result
 				br.Success++
 			}
@@ -291,13 +363,13 @@ func (p *Processor) closedBatchResult(count int) *BatchResult {
 	return br
 }
 
-// prepareBatch runs the input guards shared by all four ExtractBatch* methods
+// prepareBatch runs the input guards shared by all the ExtractBatch* methods
 // before any work is dispatched. It returns a non-nil *BatchResult for the
 // short-circuit cases — a nil/closed processor (closedBatchResult), a batch
 // exceeding maxBatchSize (uniformErrorBatch), or an empty batch — and nil to
 // signal "validation passed, build the extractors and proceed". Centralizing
 // these guards removes a 13-line preamble that was duplicated verbatim across
-// the four methods. It is safe to call on a nil *Processor (closedBatchResult
+// the methods. It is safe to call on a nil *Processor (closedBatchResult
 // does not dereference its receiver).
 func (p *Processor) prepareBatch(count int) *BatchResult {
 	if p == nil || p.closed.Load() {
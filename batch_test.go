@@ -1,12 +1,13 @@
 package html_test
 
 // batch_test.go - Tests for batch extraction functions
-// Tests for Processor.ExtractBatch, ExtractBatchWithContext, ExtractBatchFiles, ExtractBatchFilesWithContext
+// Tests for Processor.ExtractBatch, ExtractBatchWithContext, ExtractBatchWithBudget, ExtractBatchFiles, ExtractBatchFilesWithContext
 
 import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -201,6 +202,39 @@ func TestExtractBatchWithContextTimeout(t *testing.T) {
 	}
 }
 
+// TestExtractBatchWithBudget tests the overall time budget of ExtractBatchWithBudget.
+func TestExtractBatchWithBudget(t *testing.T) {
+	t.Parallel()
+
+	p := testutil.NewTestProcessor(t)
+	docs := createNDocs(50)
+
+	result := p.ExtractBatchWithBudget(docs, time.Minute)
+	if result.Success != len(docs) || result.Cancelled != 0 {
+		t.Errorf("generous budget: success=%d cancelled=%d, want all %d to succeed", result.Success, result.Cancelled, len(docs))
+	}
+
+	// Which items finish before an exhausted budget is timing-dependent, but
+	// none may fail, and every cancelled item reports the deadline.
+	result = p.ExtractBatchWithBudget(docs, time.Nanosecond)
+	if result.Failed != 0 || result.Success+result.Cancelled != len(docs) {
+		t.Errorf("exhausted budget: success=%d failed=%d cancelled=%d, want success+cancelled = %d",
+			result.Success, result.Failed, result.Cancelled, len(docs))
+	}
+	for i, err := range result.Errors {
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Errors[%d] = %v, want context.DeadlineExceeded", i, err)
+		}
+		if (err == nil) != (result.Results[i] != nil) {
+			t.Errorf("item %d has result %v and error %v", i, result.Results[i] != nil, err)
+		}
+	}
+
+	if br := html.ExtractBatchWithBudget(docs[:2], 0); br.Success != 2 {
+		t.Errorf("zero budget: success=%d, want 2 with no overall limit", br.Success)
+	}
+}
+
 // TestExtractBatchFiles tests the ExtractBatchFiles processor method.
 func TestExtractBatchFiles(t *testing.T) {
 	t.Parallel()
@@ -314,6 +348,42 @@ func TestBatchResultStructure(t *testing.T) {
 	}
 }
 
+// TestBatchResultErr tests the BatchError aggregate of failed items.
+func TestBatchResultErr(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.MaxInputSize = 64
+	p, err := html.New(cfg)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer p.Close()
+
+	small := []byte(`<p>ok</p>`)
+	large := make([]byte, 128)
+	result := p.ExtractBatch([][]byte{small, large, small, large})
+
+	err = result.Err()
+	var batchErr *html.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Err() = %v, want *BatchError", err)
+	}
+	if batchErr.Total != 4 || len(batchErr.Items) != 2 || batchErr.Items[0].Index != 1 || batchErr.Items[1].Index != 3 {
+		t.Errorf("BatchError = %+v, want items 1 and 3 of 4", batchErr)
+	}
+	if !errors.Is(err, html.ErrInputTooLarge) {
+		t.Errorf("errors.Is(%v, ErrInputTooLarge) = false, want true", err)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "html: 2 of 4 batch items failed: item 1: ") {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	if err := p.ExtractBatch([][]byte{small}).Err(); err != nil {
+		t.Errorf("Err() = %v, want nil when every item succeeded", err)
+	}
+}
+
 // TestConcurrentBatchOperations tests concurrent batch operations.
 func TestConcurrentBatchOperations(t *testing.T) {
 	t.Parallel()
@@ -259,3 +259,159 @@ func BenchmarkMediaExtraction(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkExtractMetadataOnly(b *testing.B) {
+	// Disable the cache so Extract does the full work on every iteration.
+	cfg := html.DefaultConfig()
+	cfg.MaxCacheEntries = 0
+	p, _ := html.New(cfg)
+	defer p.Close()
+
+	// A typical article page: a small head and roughly 100KB of body.
+	var body strings.Builder
+	for i := 0; i < 1000; i++ {
+		body.WriteString(`<p>Paragraph with <a href="/link">a link</a> and <img src="/img.png" alt="image"> content.</p>`)
+	}
+	htmlContent := `<html><head><title>Benchmark</title><meta name="description" content="desc">
+		<meta property="og:image" content="https://example.com/a.png"></head><body><article>` +
+		body.String() + `</article></body></html>`
+
+	b.Run("Extract", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := p.Extract([]byte(htmlContent)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("MetadataOnly", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := p.ExtractMetadataOnly(htmlContent); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkExtractUncached measures the full extraction pipeline, including
+// text building, with the result cache disabled so every iteration does work.
+func BenchmarkExtractUncached(b *testing.B) {
+	cfg := html.DefaultConfig()
+	cfg.MaxCacheEntries = 0
+	p, _ := html.New(cfg)
+	defer p.Close()
+	var sb strings.Builder
+	sb.WriteString("<html><body><article>")
+	for i := 0; i < 100; i++ {
+		sb.WriteString(fmt.Sprintf("<h2>Section %d</h2>", i))
+		for j := 0; j < 10; j++ {
+			sb.WriteString(fmt.Sprintf("<p>Paragraph %d in section %d with some content.</p>", j, i))
+		}
+	}
+	sb.WriteString("</article></body></html>")
+	doc := []byte(sb.String())
+	small := []byte(`<html><body><article><h1>T</h1><p>This is a paragraph with some content.</p><p>Another paragraph with more text.</p></article></body></html>`)
+	b.Run("large", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.Extract(doc)
+		}
+	})
+	b.Run("small", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.Extract(small)
+		}
+	})
+}
+
+// BenchmarkExtractAllLinksBaseURL compares link extraction on a large document
+// with the base detected from the document against a configured BaseURL,
+// which skips detection.
+func BenchmarkExtractAllLinksBaseURL(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`<html><head><link rel="canonical" href="https://example.com/post"></head><body>`)
+	for i := 0; i < 2000; i++ {
+		sb.WriteString(fmt.Sprintf(`<p>Paragraph %d with <a href="/page/%d">a link</a> and <img src="img/%d.png"></p>`, i, i, i))
+	}
+	sb.WriteString("</body></html>")
+	htmlContent := sb.String()
+
+	for _, bc := range []struct {
+		name    string
+		baseURL string
+	}{
+		{"Detected", ""},
+		{"Configured", "https://example.com/"},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			cfg := html.DefaultConfig()
+			cfg.BaseURL = bc.baseURL
+			cfg.MaxLinks = 0
+			p, _ := html.New(cfg)
+			defer p.Close()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.ExtractAllLinks([]byte(htmlContent)); err != nil {
+					b.Fatalf("ExtractAllLinks() failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkExtractParallelWalks measures a page above the 1MB threshold at
+// which the text, image, link and media walks run concurrently, against
+// WorkerPoolSize 1, which keeps them serial.
+func BenchmarkExtractParallelWalks(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("<html><body><article>")
+	for i := 0; sb.Len() < 2<<20; i++ {
+		sb.WriteString(fmt.Sprintf(`<h2>Section %d</h2><p>Paragraph %d with <a href="/page/%d">a link</a> and some more words to read.</p>`, i, i, i))
+		sb.WriteString(fmt.Sprintf(`<figure><img src="/img/%d.jpg" alt="Figure %d"></figure><video src="/v/%d.mp4"></video><audio src="/a/%d.mp3"></audio>`, i, i, i, i))
+	}
+	sb.WriteString("</article></body></html>")
+	htmlContent := []byte(sb.String())
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			cfg := html.DefaultConfig()
+			cfg.MaxCacheEntries = 0
+			cfg.WorkerPoolSize = workers
+			cfg.InlineImageFormat = "markdown"
+			p, _ := html.New(cfg)
+			defer p.Close()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.Extract(htmlContent); err != nil {
+					b.Fatalf("Extract() failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSplitSectionsManyParagraphs(b *testing.B) {
+	cfg := html.DefaultConfig()
+	cfg.SplitSections = true
+	cfg.MaxCacheEntries = 0
+	p, _ := html.New(cfg)
+	defer p.Close()
+
+	// One long section, written one child at a time into the same buffer.
+	var sb strings.Builder
+	sb.WriteString("<html><body><article><h2>Only section</h2>")
+	for i := 0; i < 4000; i++ {
+		sb.WriteString(fmt.Sprintf("<p>Paragraph %d of the only section, with some content.</p>", i))
+	}
+	sb.WriteString("</article></body></html>")
+	htmlContent := []byte(sb.String())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := p.Extract(htmlContent)
+		if err != nil {
+			b.Fatalf("Extract() failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package html
+
+// bidi.go wraps right-to-left runs of the extracted text in Unicode bidi
+// isolation marks, for IsolateRTLText.
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// rtlIsolate (U+2067 RIGHT-TO-LEFT ISOLATE) opens an isolated RTL run.
+	rtlIsolate = "\u2067"
+	// popIsolate (U+2069 POP DIRECTIONAL ISOLATE) closes it.
+	popIsolate = "\u2069"
+)
+
+// isRTLRune reports whether r is a Hebrew or Arabic script character.
+func isRTLRune(r rune) bool {
+	return (r >= 0x0590 && r <= 0x05FF) || // Hebrew
+		(r >= 0x0600 && r <= 0x06FF) || // Arabic
+		(r >= 0x0750 && r <= 0x077F) || // Arabic Supplement
+		(r >= 0x08A0 && r <= 0x08FF) || // Arabic Extended-A
+		(r >= 0xFB1D && r <= 0xFB4F) || // Hebrew presentation forms
+		(r >= 0xFB50 && r <= 0xFDFF) || // Arabic Presentation Forms-A
+		(r >= 0xFE70 && r <= 0xFEFF) // Arabic Presentation Forms-B
+}
+
+// isolateRTL wraps each right-to-left run of text in rtlIsolate and
+// popIsolate. A run starts and ends with a Hebrew or Arabic character and
+// takes in the spaces, digits and punctuation between them, but no other
+// letter and no line break. It returns the new text and the byte offsets in
+// text where marks were inserted, in ascending order.
+func isolateRTL(text string) (string, []int) {
+	var marks []int
+	start, end := -1, -1
+	for i, r := range text {
+		switch {
+		case isRTLRune(r):
+			if start < 0 {
+				start = i
+			}
+			end = i + utf8.RuneLen(r)
+		case start >= 0 && (r == '\n' || unicode.IsLetter(r)):
+			marks = append(marks, start, end)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		marks = append(marks, start, end)
+	}
+	if marks == nil {
+		return text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(text) + len(marks)*len(rtlIsolate))
+	prev := 0
+	for i, pos := range marks {
+		b.WriteString(text[prev:pos])
+		if i%2 == 0 {
+			b.WriteString(rtlIsolate)
+		} else {
+			b.WriteString(popIsolate)
+		}
+		prev = pos
+	}
+	b.WriteString(text[prev:])
+	return b.String(), marks
+}
+
+// shiftSpans moves the located spans from the text given to isolateRTL to its
+// result, isolated, marks being the insertion offsets it returned. A span
+// starting where a mark was inserted starts after the mark, and one ending
+// there ends before it; marks inside a span become part of its Text.
+func shiftSpans(isolated string, spans []TextSpan, marks []int) {
+	for i := range spans {
+		if spans[i].Offset < 0 {
+			continue
+		}
+		start, end := spans[i].Offset, spans[i].Offset+len(spans[i].Text)
+		spans[i].Offset = start + sort.SearchInts(marks, start+1)*len(rtlIsolate)
+		spans[i].Text = isolated[spans[i].Offset : end+sort.SearchInts(marks, end)*len(rtlIsolate)]
+	}
+}
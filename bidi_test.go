@@ -0,0 +1,66 @@
+package html
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsolateRTL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in, want string
+	}{
+		{"plain English", "plain English"},
+		{"see שלום עולם, 2024 here", "see \u2067שלום עולם\u2069, 2024 here"},
+		{"مرحبا 123 بالعالم.", "\u2067مرحبا 123 بالعالم\u2069."},
+		{"שלום\nשלום", "\u2067שלום\u2069\n\u2067שלום\u2069"},
+	}
+	for _, tt := range tests {
+		if got, _ := isolateRTL(tt.in); got != tt.want {
+			t.Errorf("isolateRTL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsolateRTLText(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>The word <b lang="he">שלום</b> means peace.</p>
+		<p lang="ar">مرحبا بالعالم</p>
+	</article></body></html>`)
+
+	cfg := DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.TrackPositions = true
+	cfg.TrackSegmentLanguage = true
+	result, err := Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(result.Text, rtlIsolate) {
+		t.Errorf("Text = %q, want no marks without IsolateRTLText", result.Text)
+	}
+
+	cfg.IsolateRTLText = true
+	result, err = Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"The word \u2067שלום\u2069 means peace.", "\u2067مرحبا بالعالم\u2069"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want it to contain %q", result.Text, want)
+		}
+	}
+	for _, span := range result.TextSpans {
+		if span.Offset < 0 || result.Text[span.Offset:span.Offset+len(span.Text)] != span.Text {
+			t.Errorf("TextSpan %+v does not match Text %q", span, result.Text)
+		}
+	}
+	for _, span := range result.LanguageSpans {
+		if span.Offset < 0 || result.Text[span.Offset:span.Offset+len(span.Text)] != span.Text {
+			t.Errorf("LanguageSpan %+v does not match Text %q", span, result.Text)
+		}
+	}
+}
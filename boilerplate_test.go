@@ -0,0 +1,52 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestIncludeBoilerplate(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body>
+		<header>Site header</header>
+		<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+		<main><p>Main content paragraph.</p></main>
+		<aside>Sidebar note</aside>
+		<div class="cookie-banner">Cookie notice</div>
+		<footer>Legal disclaimer © 2024</footer>
+		<script>var tracking = "invisible";</script>
+		<style>.x { color: red; }</style>
+		<div hidden>Hidden text</div>
+		<p style="display: none">Not displayed</p>
+	</body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+
+	plain, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(plain.Text, "Legal disclaimer") {
+		t.Fatalf("default extraction kept footer text: %q", plain.Text)
+	}
+
+	cfg.IncludeBoilerplate = true
+	full, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"Site header", "Home", "About", "Main content paragraph.", "Sidebar note", "Cookie notice", "Legal disclaimer"} {
+		if !strings.Contains(full.Text, want) {
+			t.Errorf("IncludeBoilerplate Text missing %q: %q", want, full.Text)
+		}
+	}
+	for _, unwanted := range []string{"tracking", "color: red", "Hidden text", "Not displayed"} {
+		if strings.Contains(full.Text, unwanted) {
+			t.Errorf("IncludeBoilerplate Text contains invisible %q: %q", unwanted, full.Text)
+		}
+	}
+}
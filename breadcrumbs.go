@@ -0,0 +1,203 @@
+package html
+
+// breadcrumbs.go extracts the category path of a page from a JSON-LD
+// BreadcrumbList or a breadcrumb navigation element.
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+const (
+	// maxBreadcrumbs bounds the number of breadcrumb entries kept.
+	maxBreadcrumbs = 20
+	// maxBreadcrumbLength bounds the length of one entry, so that a container
+	// merely classed "breadcrumb" cannot contribute a paragraph as a crumb.
+	maxBreadcrumbLength = 100
+)
+
+// collectBreadcrumbs returns the breadcrumb trail of doc, from the first
+// JSON-LD BreadcrumbList with named items, or else from the first element
+// whose aria-label, class or id mentions "breadcrumb". Entries are in
+// trail order, starting at the site root.
+func collectBreadcrumbs(doc *stdxhtml.Node) []string {
+	if doc == nil {
+		return nil
+	}
+	var jsonLD []string
+	var container *stdxhtml.Node
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		if n.Data == "script" {
+			if jsonLD == nil && isJSONLDScript(n) {
+				if data, ok := decodeJSONLD(n); ok {
+					jsonLD = jsonLDBreadcrumbs(data, 0)
+				}
+			}
+			return false
+		}
+		if container == nil && isBreadcrumbContainer(n) {
+			container = n
+			return false
+		}
+		return true
+	})
+	if len(jsonLD) > 0 {
+		return jsonLD
+	}
+	if container != nil {
+		return breadcrumbTrail(container)
+	}
+	return nil
+}
+
+// jsonLDBreadcrumbs returns the item names of the first BreadcrumbList in a
+// decoded JSON-LD value, ordered by their position property.
+func jsonLDBreadcrumbs(v any, depth int) []string {
+	if depth > 16 {
+		return nil
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		if jsonLDHasType(v["@type"], "BreadcrumbList") {
+			if crumbs := breadcrumbListItems(v["itemListElement"]); len(crumbs) > 0 {
+				return crumbs
+			}
+		}
+		for _, child := range v {
+			if crumbs := jsonLDBreadcrumbs(child, depth+1); len(crumbs) > 0 {
+				return crumbs
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if crumbs := jsonLDBreadcrumbs(child, depth+1); len(crumbs) > 0 {
+				return crumbs
+			}
+		}
+	}
+	return nil
+}
+
+// jsonLDHasType reports whether a JSON-LD @type value, a string or an array
+// of strings, names typ.
+func jsonLDHasType(v any, typ string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == typ
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// breadcrumbListItems returns the names of the ListItem entries of an
+// itemListElement value. A ListItem's name may sit on the item itself or on
+// its nested item object.
+func breadcrumbListItems(v any) []string {
+	elements, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	type crumb struct {
+		position float64
+		name     string
+	}
+	crumbs := make([]crumb, 0, len(elements))
+	for i, element := range elements {
+		item, ok := element.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := item["name"].(string)
+		if name == "" {
+			if nested, ok := item["item"].(map[string]any); ok {
+				name, _ = nested["name"].(string)
+			}
+		}
+		if name = breadcrumbText(name); name == "" {
+			continue
+		}
+		position, ok := item["position"].(float64)
+		if !ok {
+			position = float64(i + 1)
+		}
+		crumbs = append(crumbs, crumb{position, name})
+	}
+	sort.SliceStable(crumbs, func(i, j int) bool { return crumbs[i].position < crumbs[j].position })
+	names := make([]string, 0, min(len(crumbs), maxBreadcrumbs))
+	for _, c := range crumbs {
+		if len(names) == maxBreadcrumbs {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// isBreadcrumbContainer reports whether n is labeled or classed as breadcrumb navigation.
+func isBreadcrumbContainer(n *stdxhtml.Node) bool {
+	for _, key := range [...]string{"aria-label", "class", "id"} {
+		if strings.Contains(strings.ToLower(internal.GetAttr(n, key)), "breadcrumb") {
+			return true
+		}
+	}
+	return false
+}
+
+// breadcrumbTrail returns the entries of a breadcrumb container: the text of
+// each list item when the trail is a list, otherwise the text of each link
+// followed by the aria-current element for the page itself.
+func breadcrumbTrail(container *stdxhtml.Node) []string {
+	var items, links []string
+	var current string
+	internal.WalkNodes(container, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode || len(items) == maxBreadcrumbs {
+			return n.Type != stdxhtml.ElementNode
+		}
+		switch {
+		case n.Data == "li":
+			if text := breadcrumbText(internal.GetTextContent(n)); text != "" {
+				items = append(items, text)
+			}
+			return false
+		case n.Data == "a":
+			if text := breadcrumbText(internal.GetTextContent(n)); text != "" && len(links) < maxBreadcrumbs {
+				links = append(links, text)
+			}
+			return false
+		case current == "" && internal.GetAttr(n, "aria-current") != "":
+			current = breadcrumbText(internal.GetTextContent(n))
+			return false
+		}
+		return true
+	})
+	if len(items) > 0 {
+		return items
+	}
+	if current != "" && len(links) < maxBreadcrumbs && (len(links) == 0 || links[len(links)-1] != current) {
+		links = append(links, current)
+	}
+	return links
+}
+
+// breadcrumbText collapses the whitespace of s and trims the separators that
+// trails put between entries. Entries longer than maxBreadcrumbLength yield "".
+func breadcrumbText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Trim(s, " >/|»›→·•-")
+	if utf8.RuneCountInString(s) > maxBreadcrumbLength {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestBreadcrumbs(t *testing.T) {
+	t.Parallel()
+
+	const article = `<article><p>The article body is long enough to be selected as the main content.</p></article>`
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "json-ld list ordered by position",
+			doc: `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+				{"@type":"WebPage","name":"Page"},
+				{"@type":"BreadcrumbList","itemListElement":[
+					{"@type":"ListItem","position":2,"item":{"@id":"/shoes","name":"Shoes"}},
+					{"@type":"ListItem","position":1,"name":"Home","item":"/"},
+					{"@type":"ListItem","position":3,"name":"Running"}]}]}</script></head>
+				<body><nav aria-label="Breadcrumb"><a href="/">Start</a></nav>` + article + `</body></html>`,
+			want: []string{"Home", "Shoes", "Running"},
+		},
+		{
+			name: "aria-labeled nav with a list",
+			doc: `<html><body><nav aria-label="breadcrumb"><ol>
+				<li><a href="/">Home</a> /</li><li><a href="/docs">Docs</a> /</li><li aria-current="page">Install</li>
+				</ol></nav>` + article + `</body></html>`,
+			want: []string{"Home", "Docs", "Install"},
+		},
+		{
+			name: "classed container with links and current page",
+			doc: `<html><body><div class="site-breadcrumbs"><a href="/">Home</a> &rsaquo; <a href="/news">News</a>
+				&rsaquo; <span aria-current="page">Today</span></div>` + article + `</body></html>`,
+			want: []string{"Home", "News", "Today"},
+		},
+		{
+			name: "no breadcrumbs",
+			doc:  `<html><body><nav><a href="/">Home</a></nav>` + article + `</body></html>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := html.Extract([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if !reflect.DeepEqual(result.Breadcrumbs, tt.want) {
+				t.Errorf("Breadcrumbs = %q, want %q", result.Breadcrumbs, tt.want)
+			}
+		})
+	}
+
+	t.Run("gated by ExtractMetadata", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ExtractMetadata = false
+		result, err := html.Extract([]byte(tests[1].doc), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.Breadcrumbs != nil {
+			t.Errorf("Breadcrumbs = %q, want none when ExtractMetadata is false", result.Breadcrumbs)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package html
+
+// comments.go collects the contents of HTML comments.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxComments bounds how many comments a single document contributes.
+const maxComments = 1000
+
+// collectComments returns the trimmed text of every non-empty comment in doc,
+// in document order, including conditional comments such as "[if IE]>...".
+func collectComments(doc *stdxhtml.Node) []string {
+	var comments []string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if len(comments) >= maxComments {
+			return false
+		}
+		if n.Type == stdxhtml.CommentNode {
+			if text := strings.TrimSpace(n.Data); text != "" {
+				comments = append(comments, text)
+			}
+		}
+		return true
+	})
+	return comments
+}
@@ -0,0 +1,126 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestIncludeComments(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<!-- build: 2024-05-01 abc123 --><html><head><!--[if IE]><link rel="stylesheet" href="ie.css"><![endif]--></head>
+		<body><article><p>Visible text.</p><!-- editor: check figures --><!--   --></article></body></html>`)
+
+	def, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if def.Comments != nil {
+		t.Errorf("Comments = %q, want nil by default", def.Comments)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeComments = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := []string{
+		"build: 2024-05-01 abc123",
+		`[if IE]><link rel="stylesheet" href="ie.css"><![endif]`,
+		"editor: check figures",
+	}
+	if strings.Join(result.Comments, "|") != strings.Join(want, "|") {
+		t.Errorf("Comments = %q, want %q", result.Comments, want)
+	}
+	if strings.Contains(result.Text, "editor") {
+		t.Errorf("Text = %q, comments must not appear in text", result.Text)
+	}
+}
+
+func TestExtractComments(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body class="single comments-open">
+		<article>
+			<h1>Why tabs beat spaces</h1>
+			<p class="byline">By Ada. <a href="#comments" class="comments-link">2 comments</a></p>
+			<p>Tabs let every reader choose the indentation width they prefer, which is the whole point of this rather long article.</p>
+			<div class="commentary-note"><p>Editor's commentary stays with the article.</p></div>
+		</article>
+		<section id="comments" class="comments-area">
+			<ol class="comment-list">
+				<li class="comment"><p>Spaces forever, this is a long and passionate reply from a reader who disagrees with everything.</p></li>
+				<li class="comment"><p>Tabs are fine.</p></li>
+			</ol>
+		</section>
+		<div id="disqus_thread"><p>Disqus reply.</p></div>
+	</body></html>`)
+
+	cfg := html.DefaultConfig()
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.CommentThread != "" {
+		t.Errorf("CommentThread without ExtractComments = %q, want empty", result.CommentThread)
+	}
+
+	cfg.ExtractComments = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"Spaces forever", "Tabs are fine.", "Disqus reply."} {
+		if !strings.Contains(result.CommentThread, want) {
+			t.Errorf("CommentThread = %q, want it to contain %q", result.CommentThread, want)
+		}
+		if strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want no %q", result.Text, want)
+		}
+	}
+	for _, want := range []string{"Tabs let every reader", "Editor's commentary"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want it to contain %q", result.Text, want)
+		}
+	}
+	for _, unwanted := range []string{"Tabs let every reader", "2 comments", "commentary"} {
+		if strings.Contains(result.CommentThread, unwanted) {
+			t.Errorf("CommentThread = %q, want no %q", result.CommentThread, unwanted)
+		}
+	}
+}
+
+func TestExtractCommentsKeepsPostWrapper(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<div class="post has-comments">
+			<article><h1>Release notes</h1>
+				<p>This release rewrites the scheduler so that long-running jobs no longer starve short ones, and it adds retries.</p>
+			</article>
+			<div class="comments"><p>Great release, thanks for the retries!</p></div>
+		</div>
+		<div class="comments"><div class="comments"><p>Nested reply thread.</p></div></div>
+	</body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractComments = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if !strings.Contains(result.Text, "rewrites the scheduler") {
+		t.Errorf("Text = %q, want the article kept", result.Text)
+	}
+	if strings.Contains(result.CommentThread, "scheduler") {
+		t.Errorf("CommentThread = %q, want the post wrapper left out", result.CommentThread)
+	}
+	for _, want := range []string{"Great release", "Nested reply thread."} {
+		if !strings.Contains(result.CommentThread, want) {
+			t.Errorf("CommentThread = %q, want it to contain %q", result.CommentThread, want)
+		}
+	}
+}
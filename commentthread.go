@@ -0,0 +1,101 @@
+package html
+
+// commentthread.go separates the reader comment thread of forum and blog
+// pages from the article for ExtractComments.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// commentContainerTags are the elements that can hold a comment thread. Inline
+// elements such as a "3 comments" link in the byline are left alone.
+var commentContainerTags = map[string]bool{
+	"aside": true, "details": true, "div": true, "footer": true,
+	"ol": true, "section": true, "ul": true,
+}
+
+// commentContainerNames are the class names and ids marking a comment
+// thread, compared whole and lower-cased so that a "has-comments" class on
+// the post wrapper does not count.
+var commentContainerNames = map[string]bool{
+	"comments": true, "comment-list": true, "commentlist": true, "comments-area": true,
+	"comments-section": true, "comment-section": true, "comment-thread": true,
+	"disqus_thread": true, "disqus": true, "discussion": true, "discussions": true,
+}
+
+// isCommentContainer reports whether n is an element of commentContainerTags
+// whose id or one of whose classes is in commentContainerNames.
+func isCommentContainer(n *stdxhtml.Node) bool {
+	if n.Type != stdxhtml.ElementNode || !commentContainerTags[n.Data] {
+		return false
+	}
+	if commentContainerNames[strings.ToLower(strings.TrimSpace(internal.GetAttr(n, "id")))] {
+		return true
+	}
+	for _, class := range strings.Fields(strings.ToLower(internal.GetAttr(n, "class"))) {
+		if commentContainerNames[class] {
+			return true
+		}
+	}
+	return false
+}
+
+// extractCommentThread detaches the innermost comment containers from doc, so
+// that they neither win article detection nor reach Result.Text, and returns
+// their combined text in document order. A container holding an <article>, or
+// more text than the rest of the body, is taken to wrap the post itself and
+// left in place.
+func (p *Processor) extractCommentThread(doc *stdxhtml.Node) string {
+	body := internal.FindElementByTag(doc, "body")
+	if body == nil {
+		return ""
+	}
+	bodyText := textLength(body)
+
+	var candidates []*stdxhtml.Node
+	internal.WalkNodes(body, func(n *stdxhtml.Node) bool {
+		if isCommentContainer(n) && internal.FindElementByTag(n, "article") == nil {
+			if length := textLength(n); length <= bodyText-length {
+				candidates = append(candidates, n)
+			}
+		}
+		return true
+	})
+
+	// Keep only the innermost candidates: those with no candidate inside.
+	outer := make(map[*stdxhtml.Node]bool, len(candidates))
+	for _, n := range candidates {
+		for a := n.Parent; a != nil; a = a.Parent {
+			outer[a] = true
+		}
+	}
+	containers := candidates[:0]
+	for _, n := range candidates {
+		if !outer[n] {
+			containers = append(containers, n)
+		}
+	}
+	if len(containers) == 0 {
+		return ""
+	}
+
+	thread := &stdxhtml.Node{Type: stdxhtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, n := range containers {
+		n.Parent.RemoveChild(n)
+		thread.AppendChild(n)
+	}
+	buf := getTextBuffer()
+	defer putTextBuffer(buf)
+	p.writeStructuredText(thread, buf, nil, nil)
+	return p.cleanText(buf.String())
+}
+
+// textLength returns the length in bytes of the text under n, without
+// surrounding whitespace.
+func textLength(n *stdxhtml.Node) int {
+	return len(strings.TrimSpace(internal.GetTextContent(n)))
+}
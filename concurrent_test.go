@@ -174,6 +174,60 @@ func TestConcurrentCacheClear(t *testing.T) {
 	wg.Wait()
 }
 
+// TestConcurrentCacheReconfigure tests changing the cache TTL and size while
+// extractions are running.
+func TestConcurrentCacheReconfigure(t *testing.T) {
+	processor, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer processor.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				doc := fmt.Sprintf("<html><body><p>Document %d-%d</p></body></html>", id, j%5)
+				if _, err := processor.Extract([]byte(doc)); err != nil {
+					t.Errorf("Extract() failed: %v", err)
+				}
+			}
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 50; j++ {
+			processor.SetMaxCacheEntries(j % 10)
+			processor.SetCacheTTL(time.Duration(j) * time.Millisecond)
+		}
+	}()
+	wg.Wait()
+
+	processor.SetCacheTTL(time.Hour)
+	processor.SetMaxCacheEntries(10)
+	doc := []byte("<html><body><p>Cached document</p></body></html>")
+	for i := 0; i < 2; i++ {
+		if _, err := processor.Extract(doc); err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+	}
+	hits := processor.GetStatistics().CacheHits
+	if hits == 0 {
+		t.Error("CacheHits = 0, want a hit for the repeated document")
+	}
+
+	processor.SetMaxCacheEntries(0)
+	if _, err := processor.Extract(doc); err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if got := processor.GetStatistics().CacheHits; got != hits {
+		t.Errorf("CacheHits = %d after disabling the cache, want %d kept", got, hits)
+	}
+}
+
 // TestConcurrentAuditCollector tests concurrent audit logging.
 func TestConcurrentAuditCollector(t *testing.T) {
 	config := AuditConfig{
@@ -840,3 +894,55 @@ func TestConcurrentPackageLevelExtract(t *testing.T) {
 		t.Errorf("Concurrent package-level Extract had %d errors", errorCount.Load())
 	}
 }
+
+// TestExtractParallelWalksMatchSerial checks that a page large enough for the
+// concurrent text, image, link and media walks yields the same result as the
+// serial path taken with WorkerPoolSize 1.
+func TestExtractParallelWalksMatchSerial(t *testing.T) {
+	t.Parallel()
+
+	page := make([]byte, 0, parallelExtractThreshold+4096)
+	page = append(page, "<html><body><article>"...)
+	for i := 0; len(page) < parallelExtractThreshold+1024; i++ {
+		page = fmt.Appendf(page, `<p>Paragraph %d with <a href="/p/%d">link %d</a>.</p><img src="/i/%d.png" alt="img %d"><video src="/v/%d.mp4"></video><audio src="/a/%d.mp3"></audio>`, i, i, i, i, i, i, i)
+	}
+	page = append(page, "</article></body></html>"...)
+
+	extract := func(workers int) *Result {
+		cfg := DefaultConfig()
+		cfg.MaxCacheEntries = 0
+		cfg.WorkerPoolSize = workers
+		cfg.InlineLinkFormat = "markdown"
+		p, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer p.Close()
+		result, err := p.Extract(page)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		return result
+	}
+
+	serial, parallel := extract(1), extract(4)
+	if serial.Text != parallel.Text {
+		t.Error("Text differs between serial and parallel extraction")
+	}
+	if len(serial.Images) == 0 || len(serial.Images) != len(parallel.Images) || len(serial.Links) != len(parallel.Links) ||
+		len(serial.Videos) != len(parallel.Videos) || len(serial.Audios) != len(parallel.Audios) {
+		t.Fatalf("counts differ: serial %d/%d/%d/%d, parallel %d/%d/%d/%d",
+			len(serial.Images), len(serial.Links), len(serial.Videos), len(serial.Audios),
+			len(parallel.Images), len(parallel.Links), len(parallel.Videos), len(parallel.Audios))
+	}
+	for i := range serial.Images {
+		if serial.Images[i] != parallel.Images[i] {
+			t.Fatalf("Images[%d] = %+v, want %+v", i, parallel.Images[i], serial.Images[i])
+		}
+	}
+	for i := range serial.Links {
+		if serial.Links[i] != parallel.Links[i] {
+			t.Fatalf("Links[%d] = %+v, want %+v", i, parallel.Links[i], serial.Links[i])
+		}
+	}
+}
@@ -2,9 +2,11 @@ package html
 
 import (
 	"fmt"
-	"regexp"
+	"net/http"
 	"strings"
 	"time"
+
+	"github.com/cybergodev/html/internal"
 )
 
 // Default configuration values.
@@ -23,6 +25,10 @@ const (
 	DefaultMaxDepth = 500
 	// DefaultProcessingTimeout is the default per-document processing timeout.
 	DefaultProcessingTimeout = 30 * time.Second
+	// DefaultMaxDataURLSize is the default maximum length of a data: image URL (100 KB).
+	DefaultMaxDataURLSize = internal.MaxDataURILength
+	// DefaultExcerptLength is the default maximum length of Result.Excerpt in characters.
+	DefaultExcerptLength = 200
 )
 
 // Configuration limits - reference Default* constants for consistency
@@ -61,21 +67,22 @@ const (
 	cacheKeySample = 4096
 
 	// Buffer size estimates for pre-allocation
-	initialTextSize   = 4096 // Initial capacity for text builder
-	initialSliceCap   = 16   // Initial capacity for result slices
-	initialMapCap     = 8    // Initial capacity for result maps
-	imageHTMLBufExtra = 64   // Extra buffer for HTML image tag generation
-	extractTagCap     = 16   // Initial capacity for tag attribute extraction
-	linkMapCap        = 64   // Initial capacity for link deduplication map
+	maxPooledTextBuffer = 1 << 20 // Largest text buffer returned to the pool
+	initialSliceCap     = 16      // Initial capacity for result slices
+	initialMapCap       = 8       // Initial capacity for result maps
+	imageHTMLBufExtra   = 64      // Extra buffer for HTML image tag generation
+	extractTagCap       = 16      // Initial capacity for tag attribute extraction
+	linkMapCap          = 64      // Initial capacity for link deduplication map
 
 	// Processing thresholds
-	wordsPerMinute = 200 // Average reading speed for reading time estimation
+	wordsPerMinute    = 200 // Average reading speed for reading time estimation
+	cjkCharsPerMinute = 500 // Average CJK reading speed, in characters, for reading time estimation
 )
 
 // Pre-compiled regex patterns for media URL detection.
 var (
-	videoRegex = regexp.MustCompile(`(?i)https?://[^\s<>"',;)}\]]{1,500}\.(?:mp4|webm|ogg|mov|avi|wmv|flv|mkv|m4v|3gp)`)
-	audioRegex = regexp.MustCompile(`(?i)https?://[^\s<>"',;)}\]]{1,500}\.(?:mp3|wav|ogg|m4a|aac|flac|wma|opus|oga)`)
+	videoRegex = mediaURLRegex(videoRegexExtensions)
+	audioRegex = mediaURLRegex(audioRegexExtensions)
 )
 
 // ============================================================================
@@ -96,45 +103,99 @@ var (
 type Config struct {
 	// === Resource Management ===
 	MaxInputSize      int           // Maximum HTML input size in bytes. Default: 50MB. Must be positive and <= 50MB.
-	MaxCacheEntries   int           // Maximum number of cache entries. Set to 0 to disable caching. Default: 2000.
-	CacheTTL          time.Duration // Time-to-live for cache entries. Default: 1 hour.
+	MaxCacheEntries   int           // Maximum number of cache entries. Set to 0 to disable caching. Adjustable later with Processor.SetMaxCacheEntries. Default: 2000.
+	CacheTTL          time.Duration // Time-to-live for cache entries. Adjustable later with Processor.SetCacheTTL. Default: 1 hour.
 	CacheCleanup      time.Duration // Interval for background cleanup of expired cache entries. Set to 0 to disable. Default: 5 minutes.
 	WorkerPoolSize    int           // Number of concurrent workers for batch processing. Default: 4. Must be positive and <= 256.
 	ProcessingTimeout time.Duration // Maximum time allowed for processing a single document. Default: 30 seconds. Set to 0 for no timeout.
+	AutoDecompress    bool          // Lets ExtractReader detect and decompress gzip and zlib/deflate streams. MaxInputSize applies to the decompressed size. Default: false.
 
 	// === Security ===
 	EnableSanitization bool        // Controls whether HTML sanitization is applied. Default: true. Should only be disabled for trusted input.
 	MaxDepth           int         // Maximum allowed nesting depth of HTML elements. Prevents stack overflow. Default: 500.
+	MaxDataURLSize     int         // Maximum length in bytes of a data: URL kept in Images and Links; longer ones are dropped. 0 drops all data URLs. Default: 100KB. Must be <= 100KB.
 	AllowedBaseDir     string      // Restricts file operations to this directory. Empty (default) means no restriction. Use when accepting file paths from untrusted input.
 	Audit              AuditConfig // Security audit logging configuration.
 
 	// === Content Extraction ===
-	ExtractArticle bool // Enables article extraction mode. When true, identifies and extracts main content. Default: true.
-	PreserveImages bool // Controls whether images are preserved in output. Default: true.
-	PreserveLinks  bool // Controls whether links are preserved in output. Default: true.
-	PreserveVideos bool // Controls whether video elements are extracted. Default: true.
-	PreserveAudios bool // Controls whether audio elements are extracted. Default: true.
+	ExtractArticle        bool // Enables article extraction mode. When true, identifies and extracts main content. Default: true.
+	PreserveImages        bool // Controls whether images are preserved in output. Default: true.
+	PreserveLinks         bool // Controls whether links are preserved in output. Default: true.
+	PreserveVideos        bool // Controls whether video elements are extracted. Default: true.
+	PreserveAudios        bool // Controls whether audio elements are extracted. Default: true.
+	MaxImages             int  // Maximum number of images in Result.Images; the walk stops once it is reached. 0 means unlimited. Default: 0.
+	MaxVideos             int  // Maximum number of videos in Result.Videos; later sources and scans are skipped once it is reached. 0 means unlimited. Default: 0.
+	MaxAudios             int  // Maximum number of audios in Result.Audios; later sources and scans are skipped once it is reached. 0 means unlimited. Default: 0.
+	MaxParagraphs         int  // Stops Result.Text after this many paragraphs of the content, such as a <p>, heading, list or table, for fast previews. The text ends at a paragraph boundary; images, links and other fields still cover the whole content. 0 means unlimited. Default: 0.
+	DisableMediaRegexScan bool // Skips the regex scan of the raw HTML for video and audio URLs outside media tags, which costs CPU and can pick up URLs from script config blobs. Media referenced only in scripts or text is then missed. Default: false.
+	DeduplicateImages     bool // Keeps only the first of images sharing a resolved URL (ignoring size query parameters such as w, h and quality) in Result.Images, taking alt text from a duplicate when the first has none. Default: false.
+	SkipDecorativeImages  bool // Drops decorative images, those with empty alt text such as spacers and icons, from Result.Images after DeduplicateImages. The others keep their document-order Position, so [IMAGE:n] placeholders and inline Markdown or HTML in Text, which still covers every image, stay aligned. Default: false.
+	ResolveNoscriptImages bool // Lifts <img> elements out of <noscript> fallbacks before sanitization removes them, recovering the real URLs of lazy-loaded images. Default: false.
+	IncludeInlineSVG      bool // Replaces each outermost inline <svg> with an image in Result.Images, whose URL is a data: URL of the graphic reduced to an allowlist of shape, text, gradient and filter elements and attributes, so without scripts, styles, animations, event handlers or external references, with MimeType "image/svg+xml" and its <title> or <desc> as alt text. Subject to MaxDataURLSize. Default: false.
+	PreserveFootnotes     bool // Renders footnote references inline as "[n]" and collects their definitions into Result.Footnotes. Default: false.
+	FoldCase              bool // Uses Unicode case folding when matching class/id patterns and comparing link hosts. Default: false.
+	FoldAccents           bool // Ignores accents when matching class/id patterns and comparing link hosts (e.g. "café" matches "cafe"). Default: false.
+	IncludeBoilerplate    bool // Keeps nav, aside, header, footer and class/id-matched boilerplate in the text, removing only invisible elements (script, style, hidden). Trades precision for completeness; combine with ExtractArticle=false for full-page text. Default: false.
+	IncludeImageAltInText bool // Writes the alt text of each image into Result.Text at its position, so full-text search matches image descriptions. Alt text repeating the image's <figcaption>, which is already in the text, is skipped. Only applies when InlineImageFormat is "none", since the other formats carry the alt text. Default: false.
+	IncludeTemplates      bool // Extracts the content of <template> elements as regular page content, for client-rendered pages whose markup ships in templates. Template content is inert and skipped otherwise. Default: false.
+	PreserveParagraphs    bool // Keeps the line and paragraph breaks of block elements in Result.Text, collapsing only runs of spaces within a line. When false, Text is flattened onto a single line, except for blocks kept by PreservePreformatted. Default: true.
+	PreservePreformatted  bool // Keeps line breaks and indentation inside <pre> blocks instead of collapsing their whitespace. Default: false.
+	StripControlChars     bool // Removes C0 and C1 control characters other than tab and newline from Result.Text. Default: false.
+	StripEmoji            bool // Removes emoji, including flags, skin tones and joined sequences, from Result.Text for pipelines that cannot handle them. Default: false.
+	ArticleScoreThreshold int  // Minimum content score for an element to be an article candidate. 0 accepts any positive score. Default: 0.
+	MinContentTextLength  int  // Text length in bytes below which the default scorer penalizes an element as too short to be the article, when selecting the content node. Lower it for sites with terse content such as product pages or definitions. Default: 0 (50 bytes).
+	MergeTopCandidates    bool // Merges all non-overlapping candidates scoring at least ArticleScoreThreshold (half the best score when 0) in document order, instead of keeping only the best. Default: false.
+	SplitSections         bool // Splits the content at each <h1>-<h6> heading into Result.Sections. Default: false.
+	TrackPositions        bool // Records the source element of each text segment in Result.TextSpans, so that a matched snippet can be mapped back to the document. Default: false.
+	TrackSegmentLanguage  bool // Records the language of each run of text, from the nearest lang or xml:lang attribute, in Result.LanguageSpans, so translation pipelines can route or skip segments. Default: false.
+	ComputeKeywords       bool // Counts the 20 most frequent terms of Result.Text into Result.Keywords, lower-cased and without StopWords, numbers and terms under 3 characters, for lightweight tagging. Default: false.
+	CountSignificantWords bool // Counts the words of Result.Text other than StopWords into Result.SignificantWordCount, for term statistics that leave out articles and prepositions. Default: false.
+	ExtractAbbreviations  bool // Collects the title of each <abbr> in the content into Result.Abbreviations, keyed by the abbreviation, for glossary and accessibility tools. Default: false.
+	ExpandAbbreviations   bool // Follows the first occurrence of each <abbr title> in Result.Text with its expansion, as in "NASA (National Aeronautics and Space Administration)". Default: false.
+	ExtractLists          bool // Collects the items of each <ul> and <ol> in the content into Result.Lists, for ingredients, steps or feature lists. Default: false.
+	ExtractFeeds          bool // Collects RSS and Atom feeds declared with <link rel="alternate"> into Result.Feeds. Default: false.
+	ExtractComments       bool // Moves the reader comment thread out of the document into Result.CommentThread, so comments neither win article detection nor appear in Text. The thread is the innermost div, section, aside, footer or list elements with an id or class such as "comments", "comment-list", "disqus_thread" or "discussion", unless they hold an <article> or most of the page's text. Unrelated to IncludeComments. Default: false.
+	IncludeComments       bool // Collects the text of HTML comments, such as build stamps or editorial notes, into Result.Comments. Comments never appear in Text. Default: false.
+	IncludeDebugInfo      bool // Fills Result.DebugInfo with the nodes walked and the elements removed by sanitization and by boilerplate cleaning, to explain why little text was extracted. Counted during the existing passes. Default: false.
+	ExtractMetadata       bool // Populates document metadata: CanonicalURL, PublishedAt/ModifiedAt, Author, Robots, Microdata, Breadcrumbs, SocialImage, SitemapURL, MetaRefresh, DeclaredCharset, BaseTarget, and the meta description used for Excerpt. Default: true.
+	WordsPerMinute        int  // Reading speed used for Result.ReadingTime; CJK-dominant text is timed at 500 characters per minute instead. Default: 0 (200 words per minute).
+	ExcerptLength         int  // Maximum length in characters of Result.Excerpt, which prefers the meta description over the extracted text. 0 disables excerpts. Default: 200.
 
 	// === Output Formats ===
 	InlineImageFormat string // How images are formatted in text output. Options: "none", "markdown", "html", "placeholder". Default: "none".
 	InlineLinkFormat  string // How links are formatted in text output. Options: "none", "markdown", "html". Default: "none".
 	TableFormat       string // How tables are formatted in output. Options: "markdown", "html". Default: "markdown".
-	Encoding          string // Character encoding of input HTML. Leave empty for auto-detection. Common: "utf-8", "windows-1252", "gbk".
+	LineBreakMode     string // How <br> is rendered in Result.Text: "newline" keeps a line break, as for addresses and poetry, "space" joins the lines as prose, and "paragraph" starts a new paragraph. <br> inside <pre> is always a line break. Default: "newline".
+	IsolateRTLText    bool   // Wraps each right-to-left run of Result.Text, Hebrew or Arabic script with the spaces, digits and punctuation between, in U+2067 RIGHT-TO-LEFT ISOLATE and U+2069 POP DIRECTIONAL ISOLATE, so text mixing directions displays in reading order. TextSpans and LanguageSpans include the marks. Default: false.
+	Encoding          string // Forced character encoding of input HTML, overriding detection for all Extract* inputs. Leave empty for auto-detection. Common: "utf-8", "windows-1252", "gbk", "shift_jis".
 
 	// === Link Extraction ===
-	ResolveRelativeURLs  bool   // Controls whether relative URLs are resolved to absolute URLs. Requires BaseURL. Default: true.
-	BaseURL              string // Base URL for resolving relative URLs. Example: "https://example.com"
-	IncludeImages        bool   // Controls whether image URLs are included in link extraction. Default: true.
-	IncludeVideos        bool   // Controls whether video URLs are included in link extraction. Default: true.
-	IncludeAudios        bool   // Controls whether audio URLs are included in link extraction. Default: true.
-	IncludeCSS           bool   // Controls whether CSS stylesheet URLs are included in link extraction. Default: true.
-	IncludeJS            bool   // Controls whether JavaScript URLs are included in link extraction. Default: true.
-	IncludeContentLinks  bool   // Controls whether content links (a[href]) are included. Default: true.
-	IncludeExternalLinks bool   // Controls whether external links are included. Default: true.
-	IncludeIcons         bool   // Controls whether favicon/icon URLs are included in link extraction. Default: true.
+	ResolveRelativeURLs         bool   // Controls whether relative URLs are resolved to absolute URLs. Requires BaseURL. Default: true.
+	BaseURL                     string // Base URL for resolving relative URLs. Example: "https://example.com"
+	IncludeImages               bool   // Controls whether image URLs are included in link extraction. Default: true.
+	IncludeVideos               bool   // Controls whether video URLs are included in link extraction. Default: true.
+	IncludeAudios               bool   // Controls whether audio URLs are included in link extraction. Default: true.
+	IncludeCSS                  bool   // Controls whether CSS stylesheet URLs are included in link extraction. Default: true.
+	IncludeCSSResources         bool   // Adds the url() and @import references of <style> elements and style attributes to ExtractAllLinks and ScanLinks, as "image", "font" or "css" resources subject to IncludeImages, IncludeFonts and IncludeCSS. Default: false.
+	IncludeJS                   bool   // Controls whether JavaScript URLs are included in link extraction. Default: true.
+	IncludeContentLinks         bool   // Controls whether content links (a[href]) are included. Default: true.
+	IncludeExternalLinks        bool   // Controls whether external links are included. Default: true.
+	IncludeIcons                bool   // Controls whether favicon/icon URLs are included in link extraction. Default: true.
+	IncludeFonts                bool   // Controls whether font URLs, from <link rel="preload" as="font"> and CSS references found with IncludeCSSResources, are included in link extraction. Default: true.
+	IgnoreFragments             bool   // Strips the #fragment of content links (a[href]) before deduplication, so /page, /page#a and /page#b yield one LinkResource, titled from the link without a fragment when there is one, else from the first seen. Default: false.
+	FixSchemelessDomains        bool   // Treats hrefs that look like a host name written without a scheme, such as "www.example.com/page" or "example.com/x", as protocol-relative, so they resolve with the scheme of the base instead of being appended to its directory. Only names starting with "www." or followed by a path qualify, and a name ending in a file extension needs "www.", so "page.html" and "index.php/x" stay relative. Default: false.
+	MaxLinks                    int    // Maximum number of unique links returned by ExtractAllLinks and ScanLinks. Extra links yield a partial result and ErrMaxLinksExceeded. 0 means unlimited. Default: 0.
+	ExtractLinksPreSanitization bool   // Fills Result.Links from the whole document before sanitization, as ExtractAllLinks sees it, instead of from the extracted content only. Text is still built from the sanitized content. Default: false.
 
 	// === Extension ===
-	Scorer Scorer `json:"-"` // Optional custom scorer for content extraction. If nil, the default scorer is used.
+	Scorer                    Scorer                `json:"-"` // Optional custom scorer for content extraction. If nil, the default scorer is used.
+	ContentScorer             func(ContentNode) int `json:"-"` // Optional function replacing the scorer's Score when selecting the article node; ShouldRemove still comes from Scorer or the default scorer. Must be safe for concurrent use. Default: nil.
+	ContentFilter             ContentFilter         // Extra tags to remove and classes to remove or keep on top of the built-in boilerplate heuristics, for sites that misuse semantic tags such as <footer> for content. Default: empty.
+	HTTPClient                *http.Client          `json:"-"` // Client used by ExtractFromURL, for custom timeouts, transports, proxies or redirect policies. If nil, a shared client with a 30 second timeout is used. Default: nil.
+	AdditionalVideoExtensions []string              // Extra video file extensions, such as "ts", recognized in media tags, the raw-HTML URL scan and link classification, with Type "video/<ext>". A leading dot is optional; letters and digits only. Default: nil.
+	AdditionalAudioExtensions []string              // Extra audio file extensions, such as "mka", recognized like AdditionalVideoExtensions, with Type "audio/<ext>". Default: nil.
+	StopWords                 map[string]bool       // Words, matched case-insensitively, left out of Result.Keywords and Result.SignificantWordCount. When nil, the built-in list for the language of the <html lang> attribute is used: English, French, German or Spanish, and English otherwise. An empty map disables stop words; see DefaultStopWords to extend a built-in list. Default: nil.
+	CaptureDataAttributes     []string              // data-* attributes, such as "data-price" or "data-title", whose values are collected from every element of the document into Result.DataAttributes, for single-page apps that embed content in attributes. The "data-" prefix may be omitted. Default: nil.
 }
 
 // DefaultConfig returns a Config with all default values.
@@ -151,19 +212,24 @@ func DefaultConfig() Config {
 		// Security
 		EnableSanitization: true,
 		MaxDepth:           DefaultMaxDepth,
+		MaxDataURLSize:     DefaultMaxDataURLSize,
 		Audit:              DefaultAuditConfig(),
 
 		// Content Extraction
-		ExtractArticle: true,
-		PreserveImages: true,
-		PreserveLinks:  true,
-		PreserveVideos: true,
-		PreserveAudios: true,
+		ExtractArticle:     true,
+		PreserveImages:     true,
+		PreserveLinks:      true,
+		PreserveVideos:     true,
+		PreserveAudios:     true,
+		PreserveParagraphs: true,
+		ExtractMetadata:    true,
+		ExcerptLength:      DefaultExcerptLength,
 
 		// Output Formats
 		InlineImageFormat: "none",
 		InlineLinkFormat:  "none",
 		TableFormat:       "markdown",
+		LineBreakMode:     "newline",
 
 		// Link Extraction
 		ResolveRelativeURLs:  true,
@@ -175,6 +241,7 @@ func DefaultConfig() Config {
 		IncludeContentLinks:  true,
 		IncludeExternalLinks: true,
 		IncludeIcons:         true,
+		IncludeFonts:         true,
 	}
 }
 
@@ -201,8 +268,30 @@ func (c Config) Validate() error {
 		return newConfigError("MaxDepth", c.MaxDepth, "must be positive")
 	case c.MaxDepth > maxConfigDepth:
 		return newConfigError("MaxDepth", c.MaxDepth, fmt.Sprintf("exceeds maximum %d", maxConfigDepth))
+	case c.MaxDataURLSize < 0:
+		return newConfigError("MaxDataURLSize", c.MaxDataURLSize, "cannot be negative")
+	case c.MaxDataURLSize > DefaultMaxDataURLSize:
+		return newConfigError("MaxDataURLSize", c.MaxDataURLSize, fmt.Sprintf("exceeds maximum %d", DefaultMaxDataURLSize))
 	case c.ProcessingTimeout < 0:
 		return newConfigError("ProcessingTimeout", c.ProcessingTimeout, "cannot be negative")
+	case c.WordsPerMinute < 0:
+		return newConfigError("WordsPerMinute", c.WordsPerMinute, "cannot be negative")
+	case c.ExcerptLength < 0:
+		return newConfigError("ExcerptLength", c.ExcerptLength, "cannot be negative")
+	case c.ArticleScoreThreshold < 0:
+		return newConfigError("ArticleScoreThreshold", c.ArticleScoreThreshold, "cannot be negative")
+	case c.MaxLinks < 0:
+		return newConfigError("MaxLinks", c.MaxLinks, "cannot be negative")
+	case c.MaxImages < 0:
+		return newConfigError("MaxImages", c.MaxImages, "cannot be negative")
+	case c.MaxVideos < 0:
+		return newConfigError("MaxVideos", c.MaxVideos, "cannot be negative")
+	case c.MaxAudios < 0:
+		return newConfigError("MaxAudios", c.MaxAudios, "cannot be negative")
+	case c.MinContentTextLength < 0:
+		return newConfigError("MinContentTextLength", c.MinContentTextLength, "cannot be negative")
+	case c.MaxParagraphs < 0:
+		return newConfigError("MaxParagraphs", c.MaxParagraphs, "cannot be negative")
 	}
 
 	// Validate format strings
@@ -215,6 +304,18 @@ func (c Config) Validate() error {
 	if err := validateFormat("TableFormat", c.TableFormat, []string{"markdown", "html"}); err != nil {
 		return err
 	}
+	if err := validateFormat("LineBreakMode", c.LineBreakMode, []string{"newline", "space", "paragraph"}); err != nil {
+		return err
+	}
+	if c.Encoding != "" && !internal.IsSupportedEncoding(c.Encoding) {
+		return newConfigError("Encoding", c.Encoding, "unsupported character encoding")
+	}
+	if err := validateExtensions("AdditionalVideoExtensions", c.AdditionalVideoExtensions); err != nil {
+		return err
+	}
+	if err := validateExtensions("AdditionalAudioExtensions", c.AdditionalAudioExtensions); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -234,6 +335,17 @@ func validateFormat(field, value string, allowed []string) error {
 	return newConfigError(field, value, fmt.Sprintf("valid values: %s", strings.Join(allowed, ", ")))
 }
 
+// validateExtensions validates that each non-blank entry of exts is a file
+// extension of letters and digits, optionally with a leading dot.
+func validateExtensions(field string, exts []string) error {
+	for _, ext := range exts {
+		if strings.TrimSpace(ext) != "" && !validExtension(ext) {
+			return newConfigError(field, ext, "must contain only letters and digits")
+		}
+	}
+	return nil
+}
+
 // HighSecurityConfig returns a configuration optimized for high-security environments.
 // This includes reduced limits, shorter timeouts, and comprehensive audit logging.
 func HighSecurityConfig() Config {
@@ -288,6 +400,12 @@ func MarkdownConfig() Config {
 type Result struct {
 	// Text is the extracted plain-text content of the document.
 	Text string `json:"text"`
+	// TextSpans lists the segments of Text with the element each came from, in output order;
+	// empty unless TrackPositions is set.
+	TextSpans []TextSpan `json:"text_spans,omitempty"`
+	// LanguageSpans splits Text into runs of segments sharing the language declared by the
+	// nearest lang attribute, in output order; empty unless TrackSegmentLanguage is set.
+	LanguageSpans []LanguageSpan `json:"language_spans,omitempty"`
 	// Title is the document title from <title>, or the first <h1>/<h2> when absent.
 	Title string `json:"title"`
 	// Images lists extracted <img> elements in document order; empty when PreserveImages is false.
@@ -301,11 +419,97 @@ type Result struct {
 	// ProcessingTime is the wall-clock time spent on this extraction. It is omitted from
 	// JSON and serialized as processing_time_ms by MarshalJSON.
 	ProcessingTime time.Duration `json:"-"`
-	// WordCount is the number of whitespace-separated words in Text.
+	// WordCount is the number of words in Text. CJK ideographs and kana count as one word each.
 	WordCount int `json:"word_count"`
-	// ReadingTime is the estimated reading time based on WordCount. It is omitted from
-	// JSON and serialized as reading_time_ms by MarshalJSON.
+	// ReadingTime is the estimated reading time based on WordCount and Config.WordsPerMinute,
+	// or on the character count for CJK-dominant text. It is omitted from JSON and
+	// serialized as reading_time_ms by MarshalJSON.
 	ReadingTime time.Duration `json:"-"`
+	// ArticleConfidence rates from 0 to 1 how clearly the article node stood out: the winning
+	// candidate's score margin over the runner-up combined with its content density. Values
+	// near 0 suggest article detection failed and ExtractArticle=false may give better text.
+	// Always 0 when ExtractArticle is disabled or no element scored.
+	ArticleConfidence float64 `json:"article_confidence"`
+	// LinkDensity is the ratio of words inside links to all words in the content node
+	// (0 to 1). High values indicate navigation-heavy or link-farm pages.
+	LinkDensity float64 `json:"link_density"`
+	// SocialImage is the Open Graph image URL (og:image), falling back to twitter:image, as written.
+	SocialImage string `json:"social_image,omitempty"`
+	// SocialImageWidth is the og:image:width value in pixels (0 when absent or invalid, or when
+	// SocialImage is the twitter:image fallback).
+	SocialImageWidth int `json:"social_image_width,omitempty"`
+	// SocialImageHeight is the og:image:height value in pixels (0 when absent or invalid, or when
+	// SocialImage is the twitter:image fallback).
+	SocialImageHeight int `json:"social_image_height,omitempty"`
+	// MainImage is the representative image of the page: SocialImage when present, otherwise
+	// the first large image (300px wide or tall) in the content, otherwise its first image
+	// not declared smaller than 50px. Unlike SocialImage, which is kept as written, it is resolved
+	// against BaseURL or the document base when ResolveRelativeURLs is enabled.
+	MainImage string `json:"main_image,omitempty"`
+	// CanonicalURL is the page's canonical address from <link rel="canonical">, falling back
+	// to og:url. Unlike the base URL used for link resolution, it keeps the full path.
+	CanonicalURL string `json:"canonical_url,omitempty"`
+	// PublishedAt is the publication time from article:published_time and similar meta tags,
+	// JSON-LD datePublished, or a <time> element; nil when absent or unparseable.
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// ModifiedAt is the last-modified time from article:modified_time, og:updated_time,
+	// JSON-LD dateModified, or a <time itemprop="dateModified">; nil when absent or unparseable.
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	// Author is the byline from JSON-LD author.name, <meta name="author">, article:author,
+	// a rel="author" link, or an element whose class mentions "author" or "byline".
+	Author string `json:"author,omitempty"`
+	// Robots holds the crawler directives from <meta name="robots"> and <meta http-equiv="X-Robots-Tag">.
+	Robots RobotsDirectives `json:"robots"`
+	// SitemapURL is the sitemap linked via <link rel="sitemap">, resolved against the document base.
+	SitemapURL string `json:"sitemap_url,omitempty"`
+	// MetaRefresh is the redirect target of a <meta http-equiv="refresh"> tag, resolved against
+	// the document base, so that crawlers can follow client-side redirects. Empty when the page
+	// has no refresh tag or only reloads itself.
+	MetaRefresh string `json:"meta_refresh,omitempty"`
+	// DeclaredCharset is the lower-cased charset the document declares in <meta charset> or
+	// <meta http-equiv="Content-Type">, as written and whatever the input encoding, so that a
+	// page declaring the wrong charset can be spotted even when its bytes are already UTF-8.
+	DeclaredCharset string `json:"declared_charset,omitempty"`
+	// BaseTarget is the target of the first <base> element that has one, such as "_blank", the
+	// browsing context links without their own target open in.
+	BaseTarget string `json:"base_target,omitempty"`
+	// Microdata lists the top-level schema.org microdata items (itemscope elements) in document order.
+	Microdata []MicrodataItem `json:"microdata,omitempty"`
+	// Breadcrumbs is the category path of the page, root first, from a JSON-LD BreadcrumbList
+	// or the links of a breadcrumb navigation element.
+	Breadcrumbs []string `json:"breadcrumbs,omitempty"`
+	// Feeds lists the RSS and Atom feeds the page advertises; empty unless ExtractFeeds is set.
+	Feeds []FeedLink `json:"feeds,omitempty"`
+	// Comments lists the trimmed text of the document's HTML comments in order; empty unless
+	// IncludeComments is set.
+	Comments []string `json:"comments,omitempty"`
+	// CommentThread is the text of the page's reader comment section, kept out of Text;
+	// empty unless ExtractComments is set.
+	CommentThread string `json:"comment_thread,omitempty"`
+	// DataAttributes maps each attribute listed in CaptureDataAttributes, such as "data-price",
+	// to its non-empty values in document order; empty unless CaptureDataAttributes is set.
+	DataAttributes map[string][]string `json:"data_attributes,omitempty"`
+	// Footnotes lists footnote definitions in order of first reference; empty unless PreserveFootnotes is set.
+	Footnotes []Footnote `json:"footnotes,omitempty"`
+	// Sections splits the content at each heading; empty unless SplitSections is set.
+	Sections []Section `json:"sections,omitempty"`
+	// Abbreviations maps the text of each <abbr> in the content to its title, the first given;
+	// empty unless ExtractAbbreviations is set.
+	Abbreviations map[string]string `json:"abbreviations,omitempty"`
+	// Lists holds the <ul> and <ol> lists of the content in document order; empty unless
+	// ExtractLists is set.
+	Lists []ListInfo `json:"lists,omitempty"`
+	// Keywords holds the most frequent terms of Text with their counts, most frequent first;
+	// empty unless ComputeKeywords is set.
+	Keywords []KeywordCount `json:"keywords,omitempty"`
+	// SignificantWordCount is the number of words in Text other than stop words; 0 unless
+	// CountSignificantWords is set.
+	SignificantWordCount int `json:"significant_word_count,omitempty"`
+	// Excerpt is a short summary for snippets and previews: the meta description when present,
+	// otherwise the start of Text, truncated at a word boundary to ExcerptLength characters.
+	Excerpt string `json:"excerpt,omitempty"`
+	// DebugInfo counts what the extraction walked and removed; nil unless IncludeDebugInfo is set.
+	DebugInfo *DebugInfo `json:"debug_info,omitempty"`
 }
 
 // ImageInfo holds information about an extracted image.
@@ -320,6 +524,8 @@ type ImageInfo struct {
 	Width string `json:"width"`
 	// Height is the intrinsic height attribute, as an unparsed string.
 	Height string `json:"height"`
+	// MimeType is the media type of a data: URL image (e.g. "image/png"), empty for other URLs.
+	MimeType string `json:"mime_type,omitempty"`
 	// IsDecorative is true when Alt is empty, indicating a decorative image.
 	IsDecorative bool `json:"is_decorative"`
 	// Position is the 1-based ordinal of the image within the extracted content (0 if unplaced).
@@ -334,7 +540,9 @@ type LinkInfo struct {
 	Text string `json:"text"`
 	// Title is the advisory title (the title attribute).
 	Title string `json:"title"`
-	// IsExternal is true when the URL targets a different host than BaseURL.
+	// IsExternal is true when the URL targets a different host than the document: BaseURL
+	// when set, otherwise a declared <base href>, og:url or canonical link. Without any of
+	// these, every absolute URL is external.
 	IsExternal bool `json:"is_external"`
 	// IsNoFollow is true when the link's rel attribute contains "nofollow".
 	IsNoFollow bool `json:"is_nofollow"`
@@ -346,9 +554,14 @@ type LinkInfo struct {
 type VideoInfo struct {
 	// URL is the video source URL.
 	URL string `json:"url"`
-	// Type is the detected video type (the file container, or "embed" for iframe embeds).
+	// Type is the detected video type: the file container, "embed" for iframe embeds, or
+	// "hls" or "dash" for a streaming manifest.
 	Type string `json:"type"`
-	// Poster is the poster-frame URL for <video> elements.
+	// StreamManifest is true when URL is an HLS (.m3u8) or DASH (.mpd) manifest, detected from
+	// its extension or from the MIME type of its <source>, rather than a media file.
+	StreamManifest bool `json:"stream_manifest"`
+	// Poster is the poster-frame URL for <video> elements, or the thumbnail derived from the
+	// video ID of a YouTube or Dailymotion embed.
 	Poster string `json:"poster"`
 	// Width is the width attribute, as an unparsed string.
 	Width string `json:"width"`
@@ -356,6 +569,134 @@ type VideoInfo struct {
 	Height string `json:"height"`
 	// Duration is the duration attribute, as an unparsed string.
 	Duration string `json:"duration"`
+	// Platform is the hosting platform ("youtube", "vimeo", "dailymotion") for recognized embeds.
+	Platform string `json:"platform"`
+	// VideoID is the platform-specific video ID for recognized embeds.
+	VideoID string `json:"video_id"`
+	// CanonicalURL is the platform's canonical watch URL for recognized embeds. Embeds of
+	// the same video through different URL forms share one CanonicalURL.
+	CanonicalURL string `json:"canonical_url"`
+	// Tracks lists the subtitle, caption and other text tracks of a <video> element.
+	Tracks []TrackInfo `json:"tracks,omitempty"`
+}
+
+// TrackInfo holds a timed text track (typically WebVTT) declared by a <track> element.
+type TrackInfo struct {
+	// Kind is the kind attribute: "subtitles" (the default), "captions", "descriptions",
+	// "chapters" or "metadata".
+	Kind string `json:"kind"`
+	// Language is the srclang attribute, e.g. "en".
+	Language string `json:"language"`
+	// Src is the track URL, resolved against the document base like other links.
+	Src string `json:"src"`
+	// Label is the user-visible track title (the label attribute).
+	Label string `json:"label"`
+}
+
+// Footnote holds a footnote definition referenced from the extracted content.
+type Footnote struct {
+	// ID is the id of the definition element (the reference href without '#').
+	ID string `json:"id"`
+	// Text is the definition's text content, without back-reference links.
+	Text string `json:"text"`
+}
+
+// MicrodataItem is an HTML microdata item: an element with itemscope and the
+// itemprop values found inside it.
+type MicrodataItem struct {
+	// Type is the first itemtype URL, such as "https://schema.org/Recipe"; may be empty.
+	Type string `json:"type"`
+	// Properties maps each itemprop name to its values in document order. Properties of
+	// nested items use dotted names, e.g. "offers.price" for a nested Offer item.
+	Properties map[string][]string `json:"properties"`
+}
+
+// FeedLink is a syndication feed advertised by the document.
+type FeedLink struct {
+	// URL is the feed address, resolved against the document base like other links.
+	URL string `json:"url"`
+	// Title is the title attribute of the <link> element; may be empty.
+	Title string `json:"title"`
+	// Type is the feed format: "rss" or "atom".
+	Type string `json:"type"`
+}
+
+// RobotsDirectives holds the crawler directives a page declares. Directives
+// from multiple robots meta tags are combined, and "none" sets both NoIndex
+// and NoFollow.
+type RobotsDirectives struct {
+	// NoIndex asks crawlers not to index the page ("noindex").
+	NoIndex bool `json:"noindex"`
+	// NoFollow asks crawlers not to follow the page's links ("nofollow").
+	NoFollow bool `json:"nofollow"`
+	// NoArchive asks search engines not to show a cached copy ("noarchive").
+	NoArchive bool `json:"noarchive"`
+}
+
+// Section is a part of the extracted content that starts at a heading.
+type Section struct {
+	// Heading is the heading's text; empty for content before the first heading.
+	Heading string `json:"heading"`
+	// Level is the heading level (1 for <h1> through 6 for <h6>); 0 for content before the first heading.
+	Level int `json:"level"`
+	// Text is the section's text up to the next heading of any level, excluding the heading itself.
+	Text string `json:"text"`
+}
+
+// LanguageSpan is a run of Result.Text in a single language.
+type LanguageSpan struct {
+	// Lang is the value of the nearest lang (or xml:lang) attribute, such as "en" or "fr-CA",
+	// as written; empty when no ancestor declares a language or it is declared unknown with lang="".
+	Lang string `json:"lang"`
+	// Text is the run: the slice of Result.Text from the start of its first segment to the end
+	// of its last, or the whitespace-collapsed text of a single segment not found in Result.Text.
+	Text string `json:"text"`
+	// Offset is the byte offset of Text in Result.Text, or -1 when it does not appear there
+	// verbatim, as for tables rendered as Markdown.
+	Offset int `json:"offset"`
+}
+
+// TextSpan is a segment of Result.Text with the location of its source.
+type TextSpan struct {
+	// Text is the whitespace-collapsed text of the segment: a text node, or a whole table or
+	// preserved <pre> block.
+	Text string `json:"text"`
+	// Path is the XPath-like location of the element holding the text, such as
+	// "/html/body/article/p[2]", in the sanitized document. A position is given only among
+	// siblings with the same tag.
+	Path string `json:"path"`
+	// Offset is the byte offset of Text in Result.Text, or -1 when Text does not appear there
+	// verbatim, as for tables rendered as Markdown.
+	Offset int `json:"offset"`
+}
+
+// ListInfo is a <ul> or <ol> list of the extracted content.
+type ListInfo struct {
+	// Ordered is true for <ol> lists and false for <ul> lists.
+	Ordered bool `json:"ordered"`
+	// Items holds the whitespace-collapsed text of each <li>. The items of a nested list follow
+	// the item containing them, indented by two spaces per nesting level.
+	Items []string `json:"items"`
+}
+
+// DebugInfo holds per-extraction counts explaining how the content was reduced.
+type DebugInfo struct {
+	// NodesWalked is the number of nodes, of any type, in the parsed document.
+	NodesWalked int `json:"nodes_walked"`
+	// SanitizedElements is the number of elements that sanitization removed with their content,
+	// such as <script> or <iframe>; 0 when EnableSanitization is false.
+	SanitizedElements int `json:"sanitized_elements"`
+	// RemovedElements is the number of elements of the content node removed, with their content,
+	// as boilerplate by the scorer's ShouldRemove and ContentFilter.
+	RemovedElements int `json:"removed_elements"`
+}
+
+// KeywordCount is a term of the extracted text and how often it occurs.
+type KeywordCount struct {
+	// Term is the lower-cased term.
+	Term string `json:"term"`
+	// Count is the number of occurrences of Term in Result.Text.
+	Count int `json:"count"`
 }
 
 // AudioInfo holds information about an extracted audio.
@@ -374,8 +715,22 @@ type LinkResource struct {
 	URL string
 	// Title is a human-readable label for the resource.
 	Title string
-	// Type categorizes the resource: "link", "image", "video", "audio", "css", "js", "icon", or "media".
+	// Type categorizes the resource: "link", "image", "video", "audio", "css", "js", "icon", "font",
+	// or "media".
 	Type string
+	// Original is the href or src exactly as written, before resolution. It equals URL when
+	// ResolveRelativeURLs is disabled or no base URL is known. When several elements resolve to
+	// the same URL, it is the value from the element that also supplied Title.
+	Original string
+	// FileType is the lower-cased extension of a "link" resource pointing to a downloadable
+	// file, such as "pdf", "docx" or "zip", and empty for pages and other resource types.
+	FileType string
+	// MIMEType is the trimmed type attribute of a <link> element, such as "font/woff2".
+	MIMEType string
+	// CrossOrigin is the CORS mode of a <link> element from its crossorigin attribute:
+	// "anonymous", also for a bare or invalid crossorigin, "use-credentials", or empty
+	// when the attribute is absent.
+	CrossOrigin string
 }
 
 // Statistics holds processor statistics.
@@ -0,0 +1,46 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestArticleConfidence(t *testing.T) {
+	t.Parallel()
+
+	para := func(s string) string {
+		return "<p>" + strings.Repeat(s+" is described in detail, with care, examples, and reasons. ", 8) + "</p>"
+	}
+	clear := `<html><body><nav><a href="/">Home</a> <a href="/a">About</a></nav>
+		<article>` + para("The topic") + para("The method") + para("The result") + `</article>
+		<footer><a href="/privacy">Privacy</a></footer></body></html>`
+	ambiguous := `<html><body>
+		<div class="story">` + para("The first story") + para("Its sequel") + `</div>
+		<div class="story">` + para("The second story") + para("Its sequel") + `</div></body></html>`
+
+	extract := func(doc string, cfg html.Config) *html.Result {
+		t.Helper()
+		result, err := html.Extract([]byte(doc), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		return result
+	}
+
+	high := extract(clear, html.DefaultConfig())
+	low := extract(ambiguous, html.DefaultConfig())
+	if high.ArticleConfidence < 0.7 || high.ArticleConfidence > 1 {
+		t.Errorf("clear article ArticleConfidence = %.3f, want >= 0.7", high.ArticleConfidence)
+	}
+	if low.ArticleConfidence >= high.ArticleConfidence || low.ArticleConfidence > 0.5 {
+		t.Errorf("ambiguous ArticleConfidence = %.3f, want below 0.5 and below clear article (%.3f)", low.ArticleConfidence, high.ArticleConfidence)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	if got := extract(clear, cfg).ArticleConfidence; got != 0 {
+		t.Errorf("ArticleConfidence = %.3f with ExtractArticle=false, want 0", got)
+	}
+}
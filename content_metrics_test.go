@@ -0,0 +1,185 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/html"
+)
+
+// TestLinkDensity verifies that Result.LinkDensity separates link-heavy pages
+// from prose-heavy pages.
+func TestLinkDensity(t *testing.T) {
+	t.Parallel()
+
+	var links strings.Builder
+	for i := 0; i < 20; i++ {
+		links.WriteString(`<li><a href="/page">Related page link</a></li>`)
+	}
+
+	tests := []struct {
+		name    string
+		html    string
+		checkFn func(float64) bool
+	}{
+		{
+			name:    "link heavy",
+			html:    `<html><body><ul>` + links.String() + `</ul><p>Short intro.</p></body></html>`,
+			checkFn: func(d float64) bool { return d > 0.8 },
+		},
+		{
+			name: "prose heavy",
+			html: `<html><body><article><p>` + strings.Repeat("This is a long paragraph of prose. ", 40) +
+				`See <a href="/more">more</a>.</p></article></body></html>`,
+			checkFn: func(d float64) bool { return d > 0 && d < 0.05 },
+		},
+		{
+			name:    "no text",
+			html:    `<html><body></body></html>`,
+			checkFn: func(d float64) bool { return d == 0 },
+		},
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := html.Extract([]byte(tt.html), cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if !tt.checkFn(result.LinkDensity) {
+				t.Errorf("LinkDensity = %f, failed check", result.LinkDensity)
+			}
+		})
+	}
+}
+
+// TestReadingTime verifies the configurable reading speed and the per-character
+// estimate used for CJK text.
+func TestReadingTime(t *testing.T) {
+	t.Parallel()
+
+	english := `<html><body><p>` + strings.Repeat("word ", 400) + `</p></body></html>`
+	chinese := `<html><body><p>` + strings.Repeat("这是一个测试句子。", 100) + `</p></body></html>`
+
+	tests := []struct {
+		name string
+		html string
+		wpm  int
+		want time.Duration
+	}{
+		{name: "default speed", html: english, want: 2 * time.Minute},
+		{name: "custom speed", html: english, wpm: 400, want: time.Minute},
+		{name: "cjk by characters", html: chinese, want: 108 * time.Second},
+		{name: "cjk ignores words per minute", html: chinese, wpm: 50, want: 108 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := html.DefaultConfig()
+			cfg.ExtractArticle = false
+			cfg.WordsPerMinute = tt.wpm
+			result, err := html.Extract([]byte(tt.html), cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.ReadingTime != tt.want {
+				t.Errorf("ReadingTime = %v, want %v", result.ReadingTime, tt.want)
+			}
+		})
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.WordsPerMinute = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject negative WordsPerMinute")
+	}
+}
+
+// TestWordCountCJK verifies that CJK ideographs are counted individually rather
+// than as one word per unspaced run.
+func TestWordCountCJK(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	result, err := html.Extract([]byte(`<html><body><p>我们使用 Go 语言。</p></body></html>`), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.WordCount != 7 {
+		t.Errorf("WordCount = %d, want 7 (text %q)", result.WordCount, result.Text)
+	}
+}
+
+// TestExcerpt verifies excerpt source selection and word-boundary truncation.
+func TestExcerpt(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("alpha beta gamma ", 30)
+
+	tests := []struct {
+		name   string
+		html   string
+		length int
+		want   string
+	}{
+		{
+			name:   "meta description preferred",
+			html:   `<html><head><meta name="description" content="  A short   summary. "></head><body><p>Body text.</p></body></html>`,
+			length: 200,
+			want:   "A short summary.",
+		},
+		{
+			name:   "og description fallback",
+			html:   `<html><head><meta property="og:description" content="Social summary"></head><body><p>Body text.</p></body></html>`,
+			length: 200,
+			want:   "Social summary",
+		},
+		{
+			name:   "text when no description",
+			html:   `<html><body><p>First paragraph.</p><p>Second one.</p></body></html>`,
+			length: 200,
+			want:   "First paragraph. Second one.",
+		},
+		{
+			name:   "truncated at word boundary",
+			html:   `<html><body><p>` + long + `</p></body></html>`,
+			length: 20,
+			want:   "alpha beta gamma...",
+		},
+		{
+			name:   "cjk truncated at character",
+			html:   `<html><body><p>这是一个很长的中文段落用于测试摘要</p></body></html>`,
+			length: 5,
+			want:   "这是一个很...",
+		},
+		{
+			name:   "disabled",
+			html:   `<html><head><meta name="description" content="Summary"></head><body><p>Text</p></body></html>`,
+			length: 0,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := html.DefaultConfig()
+			cfg.ExtractArticle = false
+			cfg.ExcerptLength = tt.length
+			result, err := html.Extract([]byte(tt.html), cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.Excerpt != tt.want {
+				t.Errorf("Excerpt = %q, want %q", result.Excerpt, tt.want)
+			}
+		})
+	}
+
+	if html.DefaultConfig().ExcerptLength != html.DefaultExcerptLength {
+		t.Errorf("DefaultConfig().ExcerptLength = %d, want %d", html.DefaultConfig().ExcerptLength, html.DefaultExcerptLength)
+	}
+}
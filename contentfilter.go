@@ -0,0 +1,92 @@
+package html
+
+// contentfilter.go applies Config.ContentFilter on top of the built-in
+// boilerplate removal heuristics.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// ContentFilter augments the built-in heuristics that remove boilerplate such
+// as navigation, sidebars and footers from the extracted content. Tag and
+// class names are matched case-insensitively; classes match whole tokens of
+// the class attribute.
+type ContentFilter struct {
+	// AdditionalRemoveTags lists extra element names to remove, such as "figure".
+	AdditionalRemoveTags []string
+	// KeepClasses lists classes whose elements are never removed, even when their tag,
+	// class, id or RemoveClasses say otherwise; for example a <footer class="footnotes">.
+	KeepClasses []string
+	// RemoveClasses lists extra classes whose elements are removed, such as "newsletter-signup".
+	RemoveClasses []string
+}
+
+// contentFilter is the compiled form of a ContentFilter.
+type contentFilter struct {
+	removeTags    map[string]bool
+	keepClasses   map[string]bool
+	removeClasses map[string]bool
+}
+
+// newContentFilter compiles f, returning nil when it has no entries.
+func newContentFilter(f ContentFilter) *contentFilter {
+	if len(f.AdditionalRemoveTags) == 0 && len(f.KeepClasses) == 0 && len(f.RemoveClasses) == 0 {
+		return nil
+	}
+	return &contentFilter{
+		removeTags:    lowerSet(f.AdditionalRemoveTags),
+		keepClasses:   lowerSet(f.KeepClasses),
+		removeClasses: lowerSet(f.RemoveClasses),
+	}
+}
+
+// lowerSet returns the trimmed, lower-cased non-empty entries of values as a set.
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// keeps reports whether n has one of the KeepClasses.
+func (f *contentFilter) keeps(n *stdxhtml.Node) bool {
+	return n.Type == stdxhtml.ElementNode && hasClassIn(n, f.keepClasses)
+}
+
+// removes reports whether n has one of the AdditionalRemoveTags or RemoveClasses.
+func (f *contentFilter) removes(n *stdxhtml.Node) bool {
+	return n.Type == stdxhtml.ElementNode && (f.removeTags[n.Data] || hasClassIn(n, f.removeClasses))
+}
+
+// hasClassIn reports whether any token of the class attribute of n is in classes.
+func hasClassIn(n *stdxhtml.Node, classes map[string]bool) bool {
+	if len(classes) == 0 {
+		return false
+	}
+	for _, class := range strings.Fields(internal.GetAttr(n, "class")) {
+		if classes[strings.ToLower(class)] {
+			return true
+		}
+	}
+	return false
+}
+
+// filteredRemover applies a contentFilter before the ShouldRemove decision of
+// the wrapped scorer.
+type filteredRemover struct {
+	internal.Scorer
+	filter *contentFilter
+}
+
+func (r filteredRemover) ShouldRemove(n *stdxhtml.Node) bool {
+	if r.filter.keeps(n) {
+		return false
+	}
+	return r.filter.removes(n) || r.Scorer.ShouldRemove(n)
+}
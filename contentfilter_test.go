@@ -0,0 +1,56 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestContentFilter(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body><article>
+		<p>The main body of the article.</p>
+		<figure><img src="/chart.png" alt="Chart"><figcaption>Figure caption</figcaption></figure>
+		<div class="Newsletter-Signup">Subscribe to our newsletter</div>
+		<footer class="footnotes"><p>1. Source of the claim.</p></footer>
+		<footer class="site-footer"><p>Copyright notice</p></footer>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(result.Text, "Source of the claim") {
+		t.Errorf("footer kept without ContentFilter: %q", result.Text)
+	}
+	if !strings.Contains(result.Text, "Figure caption") || !strings.Contains(result.Text, "Subscribe") {
+		t.Errorf("Text = %q, want figure and newsletter without ContentFilter", result.Text)
+	}
+
+	cfg.ContentFilter = html.ContentFilter{
+		AdditionalRemoveTags: []string{"FIGURE"},
+		KeepClasses:          []string{"footnotes"},
+		RemoveClasses:        []string{"newsletter-signup"},
+	}
+	result, err = html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"The main body of the article.", "1. Source of the claim."} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want it to contain %q", result.Text, want)
+		}
+	}
+	for _, unwanted := range []string{"Figure caption", "Subscribe", "Copyright notice"} {
+		if strings.Contains(result.Text, unwanted) {
+			t.Errorf("Text = %q, want %q removed", result.Text, unwanted)
+		}
+	}
+	if len(result.Images) != 0 {
+		t.Errorf("Images = %+v, want none from the removed <figure>", result.Images)
+	}
+}
@@ -0,0 +1,100 @@
+package html
+
+// cssresources.go finds the resources referenced from CSS, in <style> elements
+// and inline style attributes, for IncludeCSSResources.
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// cssURLRegex matches an @import target, quoted or wrapped in url(), in its
+// first group, and any other url() reference in its second.
+var cssURLRegex = regexp.MustCompile(`(?i)@import\s+(?:url\(\s*)?["']?([^"'()\s;]+)|url\(\s*["']?([^"'()]+?)["']?\s*\)`)
+
+// fontExtensions are the file extensions that make a CSS reference a "font".
+var fontExtensions = map[string]bool{".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true}
+
+// extractCSSLinks adds the resources referenced by the text of a <style>
+// element n, or by the style attribute of any other element, to linkMap.
+// @import targets are "css", font files "font" and other references "image",
+// subject to IncludeCSS, IncludeFonts and IncludeImages. A URL already in linkMap from an
+// element such as <img> or <link> keeps that entry.
+func (p *Processor) extractCSSLinks(n *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
+	if n.Data == "style" {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == stdxhtml.TextNode {
+				p.addCSSLinks(c.Data, baseURL, linkMap)
+			}
+		}
+	}
+	if style := internal.GetAttr(n, "style"); style != "" {
+		p.addCSSLinks(style, baseURL, linkMap)
+	}
+}
+
+// addCSSLinks adds the url() and @import references of the CSS text css to linkMap.
+func (p *Processor) addCSSLinks(css, baseURL string, linkMap map[string]LinkResource) {
+	if !strings.Contains(css, "(") && !strings.Contains(css, "@") {
+		return
+	}
+	for _, m := range cssURLRegex.FindAllStringSubmatch(css, -1) {
+		raw, resourceType := strings.TrimSpace(m[2]), ""
+		if m[1] != "" {
+			raw, resourceType = m[1], "css"
+		}
+		if raw == "" || raw[0] == '#' || !internal.IsValidURL(raw) || !p.allowDataURL(raw) {
+			continue
+		}
+		if resourceType == "" {
+			resourceType = cssResourceType(raw)
+		}
+		if (resourceType == "css" && !p.config.IncludeCSS) || (resourceType == "image" && !p.config.IncludeImages) ||
+			(resourceType == "font" && !p.config.IncludeFonts) {
+			continue
+		}
+
+		resolvedURL := p.resolveURLIfEnabled(baseURL, raw)
+		if _, ok := linkMap[resolvedURL]; ok {
+			continue
+		}
+		title := ""
+		if !strings.HasPrefix(resolvedURL, "data:") {
+			title = lastPathSegment(resolvedURL)
+		}
+		if title == "" {
+			title = resourceType
+		}
+		linkMap[resolvedURL] = LinkResource{
+			URL:      resolvedURL,
+			Title:    title,
+			Type:     resourceType,
+			Original: raw,
+		}
+	}
+}
+
+// cssResourceType classifies a url() reference by the extension of its path.
+func cssResourceType(raw string) string {
+	if strings.HasPrefix(raw, "data:") {
+		if strings.HasPrefix(raw, "data:font/") || strings.HasPrefix(raw, "data:application/font") {
+			return "font"
+		}
+		return "image"
+	}
+	if i := strings.IndexAny(raw, "?#"); i >= 0 {
+		raw = raw[:i]
+	}
+	ext := strings.ToLower(path.Ext(raw))
+	switch {
+	case fontExtensions[ext]:
+		return "font"
+	case ext == ".css":
+		return "css"
+	}
+	return "image"
+}
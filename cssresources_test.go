@@ -0,0 +1,70 @@
+package html_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestIncludeCSSResources(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<style>
+			@import url("theme.css") screen;
+			@import 'print.css';
+			@font-face { font-family: Body; src: url(/fonts/body.woff2) format("woff2"); }
+			.hero { background: url( '/img/hero.jpg?v=2' ) no-repeat; }
+			.gradient { fill: url(#grad); }
+		</style>
+	</head><body>
+		<div style="background-image: url(&quot;https://cdn.example.org/bg.png&quot;)">Hello</div>
+		<img src="/img/hero.jpg?v=2" alt="Hero">
+	</body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.BaseURL = "https://example.com/"
+	cfg.IncludeCSSResources = true
+
+	want := map[string]string{
+		"https://example.com/theme.css":        "css",
+		"https://example.com/print.css":        "css",
+		"https://example.com/fonts/body.woff2": "font",
+		"https://example.com/img/hero.jpg?v=2": "image",
+		"https://cdn.example.org/bg.png":       "image",
+	}
+
+	extracted, err := html.ExtractAllLinks([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	scanned, err := html.ScanLinks(strings.NewReader(doc), cfg)
+	if err != nil {
+		t.Fatalf("ScanLinks() failed: %v", err)
+	}
+	for name, links := range map[string][]html.LinkResource{"ExtractAllLinks": extracted, "ScanLinks": scanned} {
+		got := make(map[string]string, len(links))
+		for _, link := range links {
+			got[link.URL] = link.Type
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s types = %v, want %v", name, got, want)
+		}
+	}
+	for _, link := range extracted {
+		if link.Type == "image" && strings.HasSuffix(link.URL, "hero.jpg?v=2") && link.Title != "Hero" {
+			t.Errorf("hero image Title = %q, want the <img> entry to be kept", link.Title)
+		}
+	}
+
+	cfg.IncludeCSSResources = false
+	links, err := html.ExtractAllLinks([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	if len(links) != 1 {
+		t.Errorf("links without IncludeCSSResources = %+v, want only the <img>", links)
+	}
+}
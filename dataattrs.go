@@ -0,0 +1,63 @@
+package html
+
+// dataattrs.go collects the values of the data-* attributes listed in
+// Config.CaptureDataAttributes.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxDataAttributeValues bounds how many values a single document contributes.
+const maxDataAttributeValues = 1000
+
+// dataAttributeSet returns the lower-cased attribute names of names as a set,
+// adding the "data-" prefix to names given without it. It returns nil when
+// names has no non-empty entry.
+func dataAttributeSet(names []string) map[string]bool {
+	set := lowerSet(names)
+	if len(set) == 0 {
+		return nil
+	}
+	attrs := make(map[string]bool, len(set))
+	for name := range set {
+		if !strings.HasPrefix(name, "data-") {
+			name = "data-" + name
+		}
+		attrs[name] = true
+	}
+	return attrs
+}
+
+// collectDataAttributes returns the trimmed, non-empty values of the attributes
+// in names found on elements anywhere in doc, keyed by attribute name, in
+// document order.
+func collectDataAttributes(doc *stdxhtml.Node, names map[string]bool) map[string][]string {
+	var values map[string][]string
+	count := 0
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if count >= maxDataAttributeValues {
+			return false
+		}
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		for _, attr := range n.Attr {
+			key := strings.ToLower(attr.Key)
+			if !names[key] {
+				continue
+			}
+			if val := strings.TrimSpace(attr.Val); val != "" && count < maxDataAttributeValues {
+				if values == nil {
+					values = make(map[string][]string)
+				}
+				values[key] = append(values[key], val)
+				count++
+			}
+		}
+		return true
+	})
+	return values
+}
@@ -0,0 +1,42 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestCaptureDataAttributes(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head><meta data-title="Head title"></head><body>
+		<nav data-price="9.99">Menu</nav>
+		<div id="app" data-title="Widget" data-price=" 19.99 " data-ignored="x">
+			<p>Buy the widget.</p>
+			<span DATA-PRICE="">empty</span>
+		</div>
+	</body></html>`
+
+	cfg := html.DefaultConfig()
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.DataAttributes != nil {
+		t.Errorf("DataAttributes = %v, want nil without CaptureDataAttributes", result.DataAttributes)
+	}
+
+	cfg.CaptureDataAttributes = []string{"data-price", "Title", "data-missing"}
+	result, err = html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := map[string][]string{
+		"data-title": {"Head title", "Widget"},
+		"data-price": {"9.99", "19.99"},
+	}
+	if !reflect.DeepEqual(result.DataAttributes, want) {
+		t.Errorf("DataAttributes = %v, want %v", result.DataAttributes, want)
+	}
+}
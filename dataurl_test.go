@@ -0,0 +1,103 @@
+package html_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+// pngDataURL returns a base64 data URL for a blank PNG of the given size.
+func pngDataURL(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDataURLImages(t *testing.T) {
+	t.Parallel()
+
+	src := pngDataURL(t, 40, 30)
+	doc := `<html><body><p>Inline chart below.</p><img src="` + src + `" alt="chart"></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Images) != 1 {
+		t.Fatalf("Images = %d, want 1", len(result.Images))
+	}
+	img := result.Images[0]
+	if img.MimeType != "image/png" {
+		t.Errorf("MimeType = %q, want image/png", img.MimeType)
+	}
+	if img.Width != "40" || img.Height != "30" {
+		t.Errorf("size = %sx%s, want 40x30 from the PNG header", img.Width, img.Height)
+	}
+
+	t.Run("attributes win over header", func(t *testing.T) {
+		withAttrs := strings.Replace(doc, `alt="chart"`, `alt="chart" width="400" height="300"`, 1)
+		result, err := html.Extract([]byte(withAttrs), cfg)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if len(result.Images) != 1 || result.Images[0].Width != "400" || result.Images[0].Height != "300" {
+			t.Errorf("Images = %+v, want width/height attributes kept", result.Images)
+		}
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		small := cfg
+		small.MaxDataURLSize = len(src) - 1
+		result, err := html.Extract([]byte(doc), small)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if len(result.Images) != 0 {
+			t.Errorf("Images = %+v, want data URL over MaxDataURLSize dropped", result.Images)
+		}
+
+		links, err := html.ExtractAllLinks([]byte(doc), small)
+		if err != nil {
+			t.Fatalf("ExtractAllLinks() error = %v", err)
+		}
+		for _, link := range links {
+			if strings.HasPrefix(link.URL, "data:") {
+				t.Errorf("ExtractAllLinks() kept data URL over MaxDataURLSize")
+			}
+		}
+	})
+
+	t.Run("larger than URL length limit", func(t *testing.T) {
+		big := pngDataURL(t, 600, 600)
+		if len(big) < 2000 {
+			t.Fatalf("fixture too small: %d bytes", len(big))
+		}
+		result, err := html.Extract([]byte(strings.Replace(doc, src, big, 1)), cfg)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if len(result.Images) != 1 || result.Images[0].Width != "600" {
+			t.Errorf("Images = %+v, want data URL within MaxDataURLSize kept", result.Images)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		bad := html.DefaultConfig()
+		bad.MaxDataURLSize = html.DefaultMaxDataURLSize + 1
+		var cfgErr *html.ConfigError
+		if err := bad.Validate(); !errors.As(err, &cfgErr) {
+			t.Errorf("Validate() = %v, want ConfigError", err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package html
+
+// dates.go extracts publication and modification timestamps from meta tags,
+// JSON-LD blocks, and <time> elements.
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxJSONLDSize bounds the JSON-LD blocks decoded for date extraction.
+const maxJSONLDSize = 256 * 1024
+
+// dateLayouts are tried in order when parsing date strings. They cover ISO 8601
+// variants emitted by most CMSs, the RFC formats used by HTTP-style headers,
+// and a few common human-readable forms.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// documentDates collects date candidates by source while walking the document.
+type documentDates struct {
+	metaPublished, metaModified     string
+	jsonLDPublished, jsonLDModified string
+	timePublished, timeModified     string
+	firstTime                       string
+}
+
+// collectDates gathers date candidates from the whole document. It must run
+// before sanitization, which strips the <script type="application/ld+json">
+// blocks that carry JSON-LD dates.
+func collectDates(doc *stdxhtml.Node) documentDates {
+	var d documentDates
+	if doc == nil {
+		return d
+	}
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		switch n.Data {
+		case "meta":
+			key, content := metaKeyContent(n)
+			if key == "" {
+				key = strings.ToLower(internal.GetAttr(n, "itemprop"))
+			}
+			switch key {
+			case "article:published_time", "og:published_time", "datepublished", "pubdate",
+				"publish_date", "date", "dc.date", "dc.date.issued", "dcterms.created", "dcterms.issued":
+				d.metaPublished = firstParsable(d.metaPublished, content)
+			case "article:modified_time", "og:updated_time", "datemodified", "last-modified",
+				"dcterms.modified":
+				d.metaModified = firstParsable(d.metaModified, content)
+			}
+		case "script":
+			if isJSONLDScript(n) {
+				published, modified := jsonLDDates(n)
+				d.jsonLDPublished = firstParsable(d.jsonLDPublished, published)
+				d.jsonLDModified = firstParsable(d.jsonLDModified, modified)
+			}
+			return false
+		case "time":
+			value := strings.TrimSpace(internal.GetAttr(n, "datetime"))
+			if value == "" {
+				value = strings.TrimSpace(internal.GetTextContent(n))
+			}
+			switch strings.ToLower(internal.GetAttr(n, "itemprop")) {
+			case "datepublished":
+				d.timePublished = firstParsable(d.timePublished, value)
+			case "datemodified":
+				d.timeModified = firstParsable(d.timeModified, value)
+			default:
+				if hasAttr(n, "pubdate") {
+					d.timePublished = firstParsable(d.timePublished, value)
+				} else if internal.GetAttr(n, "datetime") != "" {
+					d.firstTime = firstParsable(d.firstTime, value)
+				}
+			}
+		}
+		return true
+	})
+	return d
+}
+
+// apply populates result.PublishedAt and result.ModifiedAt. Each value comes
+// from the highest-priority source that parses: meta tags, then JSON-LD
+// datePublished/dateModified, then <time> elements marked with itemprop or
+// pubdate, and for PublishedAt finally the first <time datetime> in the page.
+// Values that no layout parses are skipped, so a field stays nil rather than
+// holding a guessed time.
+func (d documentDates) apply(result *Result) {
+	result.PublishedAt = parseFirstDate(d.metaPublished, d.jsonLDPublished, d.timePublished, d.firstTime)
+	result.ModifiedAt = parseFirstDate(d.metaModified, d.jsonLDModified, d.timeModified)
+}
+
+// jsonLDDates returns the first datePublished and dateModified strings found
+// anywhere in the JSON-LD block n, including inside @graph arrays.
+func jsonLDDates(n *stdxhtml.Node) (published, modified string) {
+	data, ok := decodeJSONLD(n)
+	if !ok {
+		return "", ""
+	}
+	var walk func(v any, depth int)
+	walk = func(v any, depth int) {
+		if depth > 16 {
+			return
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			if s, ok := v["datePublished"].(string); ok {
+				published = firstParsable(published, s)
+			}
+			if s, ok := v["dateModified"].(string); ok {
+				modified = firstParsable(modified, s)
+			}
+			for _, child := range v {
+				walk(child, depth+1)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(data, 0)
+	return published, modified
+}
+
+// decodeJSONLD decodes the JSON body of a <script type="application/ld+json">
+// element, rejecting blocks larger than maxJSONLDSize.
+func decodeJSONLD(n *stdxhtml.Node) (any, bool) {
+	if n.FirstChild == nil || n.FirstChild.Type != stdxhtml.TextNode || len(n.FirstChild.Data) > maxJSONLDSize {
+		return nil, false
+	}
+	var data any
+	if err := json.Unmarshal([]byte(n.FirstChild.Data), &data); err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// isJSONLDScript reports whether n is a <script type="application/ld+json"> element.
+func isJSONLDScript(n *stdxhtml.Node) bool {
+	return n.Data == "script" && strings.EqualFold(strings.TrimSpace(internal.GetAttr(n, "type")), "application/ld+json")
+}
+
+// firstParsable returns current if set, and otherwise candidate when it parses as a date.
+func firstParsable(current, candidate string) string {
+	if current != "" {
+		return current
+	}
+	if _, ok := parseDate(candidate); ok {
+		return strings.TrimSpace(candidate)
+	}
+	return ""
+}
+
+// parseFirstDate parses the first non-empty value.
+func parseFirstDate(values ...string) *time.Time {
+	for _, v := range values {
+		if t, ok := parseDate(v); ok {
+			return &t
+		}
+	}
+	return nil
+}
+
+// parseDate parses s with each of dateLayouts in turn.
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// hasAttr reports whether n has the attribute key, regardless of its value.
+func hasAttr(n *stdxhtml.Node, key string) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
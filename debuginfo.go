@@ -0,0 +1,35 @@
+package html
+
+// debuginfo.go counts removed elements for Result.DebugInfo by wrapping the
+// recorders and scorers the sanitization and cleaning passes already call.
+
+import (
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// countingRecorder counts the tags blocked by sanitization before passing
+// every event on to the wrapped recorder.
+type countingRecorder struct {
+	internal.AuditRecorder
+	blockedTags *int
+}
+
+func (r *countingRecorder) RecordBlockedTag(tag string) {
+	*r.blockedTags++
+	r.AuditRecorder.RecordBlockedTag(tag)
+}
+
+// countingRemover counts the elements the wrapped scorer removes.
+type countingRemover struct {
+	internal.Scorer
+	removed *int
+}
+
+func (r countingRemover) ShouldRemove(n *stdxhtml.Node) bool {
+	if r.Scorer.ShouldRemove(n) {
+		*r.removed++
+		return true
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package html_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestIncludeDebugInfo(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<script>track()</script>
+		<main><p>Only paragraph.</p><iframe src="/ad"></iframe>
+		<nav><a href="/">Home</a></nav>
+		<div class="sidebar">Related</div></main>
+	</body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.DebugInfo != nil {
+		t.Errorf("DebugInfo = %+v, want nil by default", result.DebugInfo)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.IncludeDebugInfo = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	debug := result.DebugInfo
+	if debug == nil {
+		t.Fatal("DebugInfo = nil, want counts")
+	}
+	if debug.NodesWalked < 15 {
+		t.Errorf("NodesWalked = %d, want every node of the document", debug.NodesWalked)
+	}
+	if debug.SanitizedElements != 2 {
+		t.Errorf("SanitizedElements = %d, want 2 (script and iframe)", debug.SanitizedElements)
+	}
+	if debug.RemovedElements != 2 {
+		t.Errorf("RemovedElements = %d, want 2 (nav and sidebar)", debug.RemovedElements)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"debug_info":{"nodes_walked":`) {
+		t.Errorf("JSON = %s, want debug_info", data)
+	}
+}
@@ -0,0 +1,53 @@
+package html
+
+import "github.com/cybergodev/html/internal"
+
+// DetectCharset detects the character encoding of data using the same BOM,
+// meta-declaration, and statistical analysis that Extract applies to its input.
+// It returns a normalized charset name such as "utf-8", "windows-1252", or "gbk".
+func DetectCharset(data []byte) string {
+	charset, _ := DetectCharsetWithConfidence(data)
+	return charset
+}
+
+// DetectCharsetWithConfidence is like DetectCharset but also returns a
+// confidence score in the range 0-100. Scores below 50 indicate that no
+// candidate decoded cleanly and the result is a best-effort fallback.
+func DetectCharsetWithConfidence(data []byte) (string, int) {
+	match := internal.NewEncodingDetector().DetectCharsetSmart(data)
+	return match.Charset, match.Confidence
+}
+
+// ConvertToUTF8 detects the character encoding of data and converts it to UTF-8.
+// It returns the converted bytes and the detected charset name. When data is
+// already UTF-8 the returned slice may share memory with data.
+func ConvertToUTF8(data []byte) ([]byte, string, error) {
+	return internal.NewEncodingDetector().DetectAndConvert(data)
+}
+
+// EncodingMatch is one candidate encoding scored by DetectCharsetCandidates.
+type EncodingMatch struct {
+	Charset    string // Normalized charset name, such as "windows-1252"
+	Confidence int    // Confidence in the range 0-100
+	Score      int    // Raw score the confidence is derived from
+	Valid      bool   // Whether the data decodes cleanly with Charset
+}
+
+// DetectCharsetCandidates scores every candidate encoding that decodes data
+// and returns the matches sorted by descending confidence, so that the
+// runner-ups of DetectCharset can be inspected when diagnosing mojibake. A
+// charset declared by a byte order mark or meta tag gets a small boost. The
+// first candidate usually, but not always, agrees with DetectCharset, which
+// trusts a clean declared charset without scoring the alternatives. Empty
+// data has no candidates.
+func DetectCharsetCandidates(data []byte) []EncodingMatch {
+	if len(data) == 0 {
+		return nil
+	}
+	matches := internal.NewEncodingDetector().DetectCharsetCandidates(data)
+	candidates := make([]EncodingMatch, len(matches))
+	for i, m := range matches {
+		candidates[i] = EncodingMatch(m)
+	}
+	return candidates
+}
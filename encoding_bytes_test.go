@@ -1,11 +1,14 @@
 package html_test
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/cybergodev/html"
+	"golang.org/x/text/encoding/unicode/utf32"
 )
 
 // TestExtractWithEncodingDetection tests automatic encoding detection in Extract
@@ -68,6 +71,24 @@ func TestExtractWithEncodingDetection(t *testing.T) {
 			t.Errorf("Expected %q, got: %s", expected, result.Text)
 		}
 	})
+
+	t.Run("UTF-32 with BOM", func(t *testing.T) {
+		htmlContent := `<html><head><title>Grüße</title></head><body><p>Schöne Grüße aus München</p></body></html>`
+		for _, order := range []utf32.Endianness{utf32.LittleEndian, utf32.BigEndian} {
+			htmlBytes, err := utf32.UTF32(order, utf32.UseBOM).NewEncoder().Bytes([]byte(htmlContent))
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			result, err := html.Extract(htmlBytes)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if result.Title != "Grüße" || !strings.Contains(result.Text, "Schöne Grüße aus München") {
+				t.Errorf("Title = %q, Text = %q, want decoded UTF-32", result.Title, result.Text)
+			}
+		}
+	})
 }
 
 // TestExtractToMarkdownEncoding tests automatic encoding detection in ExtractToMarkdown
@@ -279,3 +300,156 @@ func TestExtractWithForcedEncoding(t *testing.T) {
 		t.Errorf("Found replacement character, forced decode may be wrong: %q", result.Text)
 	}
 }
+
+// TestForcedEncodingValidation verifies that Config.Encoding is checked against
+// the supported charsets and that aliases are accepted.
+func TestForcedEncodingValidation(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"utf-8", "UTF8", "windows-1252", "cp1251", "gb2312", "Shift_JIS", "latin1", "iso-2022-jp"}
+	for _, enc := range valid {
+		cfg := html.DefaultConfig()
+		cfg.Encoding = enc
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Encoding %q: unexpected error: %v", enc, err)
+		}
+	}
+
+	invalid := []string{"klingon", "utf-9", "windows-9999"}
+	for _, enc := range invalid {
+		cfg := html.DefaultConfig()
+		cfg.Encoding = enc
+		err := cfg.Validate()
+		if !errors.Is(err, html.ErrInvalidConfig) {
+			t.Errorf("Encoding %q: expected ErrInvalidConfig, got %v", enc, err)
+		}
+		if _, err := html.New(cfg); err == nil {
+			t.Errorf("New() with Encoding %q should fail", enc)
+		}
+	}
+}
+
+// TestExtractFromFileWithForcedEncoding verifies that the forced encoding
+// overrides a wrong meta charset declaration for file input.
+func TestExtractFromFileWithForcedEncoding(t *testing.T) {
+	t.Parallel()
+
+	// "中文" in GBK, declared (wrongly) as ISO-8859-1.
+	content := []byte("<html><head><meta charset=\"iso-8859-1\"></head><body><p>\xd6\xd0\xce\xc4</p></body></html>")
+	path := filepath.Join(t.TempDir(), "gbk.html")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.Encoding = "GB2312"
+	p, err := html.New(cfg)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer p.Close()
+
+	result, err := p.ExtractFromFile(path)
+	if err != nil {
+		t.Fatalf("ExtractFromFile failed: %v", err)
+	}
+	if !strings.Contains(result.Text, "中文") {
+		t.Errorf("Expected forced GBK decode, got: %q", result.Text)
+	}
+}
+
+// TestExtractWithDeclaredThaiEncoding verifies that a declared windows-874
+// (and its TIS-620 alias) page decodes to Thai script.
+func TestExtractWithDeclaredThaiEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, charset := range []string{"windows-874", "tis-620"} {
+		htmlBytes := []byte("<html><head><meta charset=\"" + charset + "\"></head><body><p>\xc0\xd2\xc9\xd2\xe4\xb7\xc2</p></body></html>")
+		result, err := html.Extract(htmlBytes)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if !strings.Contains(result.Text, "ภาษาไทย") {
+			t.Errorf("charset %s: expected Thai text, got %q", charset, result.Text)
+		}
+	}
+}
+
+func TestDetectCharset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"ascii", []byte("<html><body>Hello</body></html>"), "utf-8"},
+		{"utf-8", []byte("<html><body>Ünïcödé 中文</body></html>"), "utf-8"},
+		{"utf-8 bom", []byte("\xef\xbb\xbf<p>Hi</p>"), "utf-8"},
+		{"declared windows-1252", []byte("<meta charset=\"windows-1252\"><p>caf\xe9</p>"), "windows-1252"},
+		{"declared shift_jis", []byte("<meta charset=\"Shift_JIS\"><p>\x93\xfa\x96\x7b</p>"), "shift_jis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := html.DetectCharset(tt.data); got != tt.want {
+				t.Errorf("DetectCharset() = %q, want %q", got, tt.want)
+			}
+			charset, confidence := html.DetectCharsetWithConfidence(tt.data)
+			if charset != tt.want {
+				t.Errorf("DetectCharsetWithConfidence() charset = %q, want %q", charset, tt.want)
+			}
+			if confidence < 0 || confidence > 100 {
+				t.Errorf("DetectCharsetWithConfidence() confidence = %d, want 0-100", confidence)
+			}
+		})
+	}
+}
+
+func TestDetectCharsetCandidates(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("<meta charset=\"windows-1252\"><p>Company\x92s caf\xe9 cr\xe8me br\xfbl\xe9e</p>")
+	candidates := html.DetectCharsetCandidates(data)
+	if len(candidates) < 2 {
+		t.Fatalf("DetectCharsetCandidates() = %+v, want several candidates", candidates)
+	}
+	if candidates[0].Charset != "windows-1252" {
+		t.Errorf("first candidate = %q, want windows-1252", candidates[0].Charset)
+	}
+	for i, c := range candidates {
+		if c.Confidence < 0 || c.Confidence > 100 {
+			t.Errorf("%s: Confidence = %d, want 0-100", c.Charset, c.Confidence)
+		}
+		if c.Charset == "utf-8" {
+			t.Errorf("utf-8 listed for invalid UTF-8 data: %+v", c)
+		}
+		if i > 0 && c.Confidence > candidates[i-1].Confidence {
+			t.Errorf("candidates not sorted by confidence: %+v", candidates)
+		}
+	}
+
+	if got := html.DetectCharsetCandidates(nil); len(got) != 0 {
+		t.Errorf("DetectCharsetCandidates(nil) = %+v, want none", got)
+	}
+}
+
+func TestConvertToUTF8(t *testing.T) {
+	t.Parallel()
+
+	converted, charset, err := html.ConvertToUTF8([]byte("<meta charset=\"windows-1252\"><p>Company\x92s caf\xe9</p>"))
+	if err != nil {
+		t.Fatalf("ConvertToUTF8() failed: %v", err)
+	}
+	if charset != "windows-1252" {
+		t.Errorf("charset = %q, want windows-1252", charset)
+	}
+	if !strings.Contains(string(converted), "Company’s café") {
+		t.Errorf("ConvertToUTF8() = %q, want decoded windows-1252 text", converted)
+	}
+
+	converted, charset, err = html.ConvertToUTF8([]byte("plain ascii"))
+	if err != nil || charset != "utf-8" || string(converted) != "plain ascii" {
+		t.Errorf("ConvertToUTF8(ascii) = %q, %q, %v", converted, charset, err)
+	}
+}
@@ -38,9 +38,27 @@ var (
 	// This error indicates an internal bug and should be reported to the library maintainers.
 	ErrInternalPanic = errors.New("html: internal panic recovered")
 
+	// ErrMaxLinksExceeded is returned alongside a partial result when link extraction
+	// stops at MaxLinks. The returned links are valid; callers that accept a
+	// truncated set can check for it with errors.Is and continue.
+	ErrMaxLinksExceeded = errors.New("html: max links exceeded")
+
 	// ErrMultipleConfigs is returned when more than one Config is provided to a function.
 	// Package-level functions like Extract accept at most one optional Config.
 	ErrMultipleConfigs = errors.New("html: at most one Config may be provided")
+
+	// ErrFetchFailed is returned by ExtractFromURL when the page cannot be
+	// retrieved: an unsupported URL, a transport error, or a non-2xx status.
+	ErrFetchFailed = errors.New("html: fetch failed")
+
+	// ErrInvalidSelector is returned by ExtractSelector and ExtractLinksFromSelector
+	// when the selector is empty or uses syntax outside the supported subset, and
+	// by ExtractTextByTag when the tag is not a plain tag name.
+	ErrInvalidSelector = errors.New("html: invalid selector")
+
+	// ErrSelectorNotMatched is returned by ExtractSelector and
+	// ExtractLinksFromSelector when no element of the document matches the selector.
+	ErrSelectorNotMatched = errors.New("html: selector matched no element")
 )
 
 // InputError provides context for input-related errors.
@@ -222,3 +240,75 @@ func newFileError(op, path string, err error) *FileError {
 		FileErr: err,
 	}
 }
+
+// ExtractError provides context for a document that failed to process.
+// It supports errors.Is() checking against the wrapped sentinel, such as
+// ErrInvalidHTML or ErrMaxDepthExceeded.
+type ExtractError struct {
+	Op     string // Stage that failed: "parse" (reading or tokenizing the HTML) or "walk" (traversing the parsed tree)
+	Offset int    // Byte offset in the UTF-8 input where the failure was detected, or -1 when unknown
+	Err    error  // Underlying error, wrapping the sentinel error and its cause
+}
+
+// Error returns a formatted error message.
+func (e *ExtractError) Error() string {
+	if e.Offset >= 0 {
+		return fmt.Sprintf("%v (op=%s, offset=%d)", e.Err, e.Op, e.Offset)
+	}
+	return fmt.Sprintf("%v (op=%s)", e.Err, e.Op)
+}
+
+// Unwrap returns the underlying error for errors.Is() support.
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// ItemError is the error of a single item of a batch.
+type ItemError struct {
+	Index int   // Index of the item in the batch input
+	Err   error // Error the item failed with
+}
+
+// Error returns a formatted error message.
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error for errors.Is() support.
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError reports the items of a batch that failed or were cancelled, in
+// input order. It is returned by BatchResult.Err; use errors.As to inspect the
+// items, or errors.Is to check whether any of them failed with a given error.
+type BatchError struct {
+	Items []ItemError // Failed and cancelled items, in input order
+	Total int         // Number of items in the batch
+}
+
+// Error returns a formatted error message naming the first failure.
+func (e *BatchError) Error() string {
+	if len(e.Items) == 0 {
+		return fmt.Sprintf("html: 0 of %d batch items failed", e.Total)
+	}
+	return fmt.Sprintf("html: %d of %d batch items failed: %v", len(e.Items), e.Total, e.Items[0])
+}
+
+// Unwrap returns the item errors for errors.Is() and errors.As() support.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item
+	}
+	return errs
+}
+
+// newExtractError creates a new ExtractError with the provided details.
+func newExtractError(op string, offset int, err error) *ExtractError {
+	return &ExtractError{
+		Op:     op,
+		Offset: offset,
+		Err:    err,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 )
@@ -147,6 +148,71 @@ func TestConfigError(t *testing.T) {
 	})
 }
 
+func TestExtractError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error message format", func(t *testing.T) {
+		err := newExtractError("parse", 42, fmt.Errorf("%w: %w", ErrInvalidHTML, errors.New("unexpected EOF")))
+		want := "html: invalid HTML: unexpected EOF (op=parse, offset=42)"
+		if err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+		if !errors.Is(err, ErrInvalidHTML) {
+			t.Error("ExtractError should match the wrapped sentinel")
+		}
+
+		unknown := newExtractError("walk", -1, ErrMaxDepthExceeded)
+		if unknown.Error() != "html: max depth exceeded (op=walk)" {
+			t.Errorf("Error() = %q, want no offset", unknown.Error())
+		}
+	})
+
+	t.Run("depth violation", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxDepth = 10
+		p, err := New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+
+		_, err = p.Extract([]byte(strings.Repeat("<div>", 50) + "text"))
+		var extractErr *ExtractError
+		if !errors.As(err, &extractErr) {
+			t.Fatalf("error = %v, want *ExtractError", err)
+		}
+		if extractErr.Op != "walk" || !errors.Is(err, ErrMaxDepthExceeded) {
+			t.Errorf("got op %q, err %v; want walk and ErrMaxDepthExceeded", extractErr.Op, err)
+		}
+	})
+
+	t.Run("scan read error offset", func(t *testing.T) {
+		p, err := New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+
+		head := `<html><body><a href="/a">A</a>`
+		r := io.MultiReader(strings.NewReader(head), failingReader{})
+		_, err = p.ScanLinks(r)
+		var extractErr *ExtractError
+		if !errors.As(err, &extractErr) {
+			t.Fatalf("error = %v, want *ExtractError", err)
+		}
+		if extractErr.Op != "parse" || extractErr.Offset != len(head) || !errors.Is(err, ErrInvalidHTML) {
+			t.Errorf("got op %q offset %d, err %v; want parse at %d", extractErr.Op, extractErr.Offset, err, len(head))
+		}
+	})
+}
+
+// failingReader always fails, standing in for a broken network stream.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
 func TestFileError(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,98 @@
+package html
+
+// explain.go reports the article candidates that ExtractArticle chooses from,
+// for debugging article detection.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxExplainCandidates is the number of candidates ExplainArticleDetection returns.
+const maxExplainCandidates = 10
+
+// CandidateScore describes an element considered as the article by article detection.
+type CandidateScore struct {
+	// Path is the XPath-like location of the element, as in TextSpan.Path.
+	Path string `json:"path"`
+	// Tag is the element name.
+	Tag string `json:"tag"`
+	// ID is the id attribute.
+	ID string `json:"id,omitempty"`
+	// Class is the class attribute.
+	Class string `json:"class,omitempty"`
+	// Score is the content score, from ContentScorer when set and otherwise from the scorer.
+	Score int `json:"score"`
+	// TextLength is the number of characters of whitespace-collapsed text in the element.
+	TextLength int `json:"text_length"`
+	// LinkDensity is the ratio of words inside links to all words in the element (0 to 1).
+	LinkDensity float64 `json:"link_density"`
+}
+
+// ExplainArticleDetection returns the highest scoring article candidates of
+// htmlContent, best first and ties in document order, to show why Extract
+// picked its content node. The document is prepared as for Extract, including
+// sanitization and the ExtractComments detachment, and scored with the
+// processor's Scorer or ContentScorer and ArticleScoreThreshold. Extract uses
+// the first candidate unless it ties with another or MergeTopCandidates is
+// set; it falls back to <body> when there is none, and this returns an empty
+// slice.
+func (p *Processor) ExplainArticleDetection(htmlContent string) ([]CandidateScore, error) {
+	return recoverPanic(func() ([]CandidateScore, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		if err := p.validateInput([]byte(htmlContent)); err != nil {
+			return nil, err
+		}
+		if p.isBlankContent(htmlContent) {
+			return []CandidateScore{}, nil
+		}
+
+		doc, err := stdxhtml.Parse(strings.NewReader(htmlContent))
+		if err != nil {
+			return nil, newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+		}
+		if err := p.validateDepthTraversal(doc, 0); err != nil {
+			return nil, err
+		}
+		p.prepareDocument(doc, internal.NoOpAuditRecorder{}, nil)
+		root, _, err := p.selectionRoot(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		type candidate struct {
+			node  *stdxhtml.Node
+			score int
+		}
+		var candidates []candidate
+		p.walkCandidates(root, func(n *stdxhtml.Node, score int) {
+			candidates = append(candidates, candidate{n, score})
+		})
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].score > candidates[j].score
+		})
+		candidates = candidates[:min(len(candidates), maxExplainCandidates)]
+
+		paths := make(map[*stdxhtml.Node]string)
+		scores := make([]CandidateScore, len(candidates))
+		for i, c := range candidates {
+			scores[i] = CandidateScore{
+				Path:        nodePath(c.node, paths),
+				Tag:         c.node.Data,
+				ID:          internal.GetAttr(c.node, "id"),
+				Class:       internal.GetAttr(c.node, "class"),
+				Score:       c.score,
+				TextLength:  utf8.RuneCountInString(strings.Join(strings.Fields(internal.GetTextContent(c.node)), " ")),
+				LinkDensity: internal.CalculateLinkWordDensity(c.node),
+			}
+		}
+		return scores, nil
+	})
+}
@@ -0,0 +1,93 @@
+package html_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExplainArticleDetection(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body>
+		<nav id="menu"><a href="/a">Home</a> <a href="/b">About</a> <a href="/c">Contact</a></nav>
+		<article id="story" class="post">
+			<p>The first paragraph of the story, long enough to be scored as real content, with commas, clauses, and detail.</p>
+			<p>The second paragraph continues the story, adding more sentences, more commas, and more words to read.</p>
+			<p>A third paragraph closes the story, again with plenty of text, so that it clearly wins the scoring.</p>
+		</article>
+	</body></html>`
+
+	p, err := html.New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	candidates, err := p.ExplainArticleDetection(doc)
+	if err != nil {
+		t.Fatalf("ExplainArticleDetection() failed: %v", err)
+	}
+	if len(candidates) == 0 {
+		t.Fatal("ExplainArticleDetection() returned no candidates")
+	}
+	best := candidates[0]
+	if best.Tag != "article" || best.ID != "story" || best.Class != "post" || best.Path != "/html/body/article" {
+		t.Errorf("best candidate = %+v, want the <article>", best)
+	}
+	if best.TextLength < 200 || best.LinkDensity != 0 {
+		t.Errorf("best candidate TextLength = %d, LinkDensity = %v", best.TextLength, best.LinkDensity)
+	}
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].Score > candidates[i-1].Score {
+			t.Errorf("candidates not sorted by score: %+v", candidates)
+		}
+		if candidates[i].Tag == "nav" && candidates[i].Score >= best.Score {
+			t.Errorf("nav scored %d, not below article %d", candidates[i].Score, best.Score)
+		}
+	}
+
+	result, err := p.Extract([]byte(doc))
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.WordCount == 0 {
+		t.Error("Extract() found no text")
+	}
+
+	p.Close()
+	if _, err := p.ExplainArticleDetection(doc); !errors.Is(err, html.ErrProcessorClosed) {
+		t.Errorf("ExplainArticleDetection() after Close error = %v, want ErrProcessorClosed", err)
+	}
+}
+
+func TestExplainArticleDetectionMatchesExtractPreparation(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body>
+		<article id="story">
+			<p>The story itself, long enough to be scored as real content, with commas, clauses, and detail.</p>
+			<p>A second paragraph continues the story, adding more sentences, more commas, and more words.</p>
+			<p>A third paragraph closes the story, again with plenty of text, so that it is the longer part.</p>
+		</article>
+		<section id="comments">
+			<p>A reader comment that is long, with commas, clauses, and opinions, so that it would score well.</p>
+		</section>
+	</body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractComments = true
+	p, err := html.New(cfg)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer p.Close()
+	candidates, err := p.ExplainArticleDetection(doc)
+	if err != nil {
+		t.Fatalf("ExplainArticleDetection() failed: %v", err)
+	}
+	for _, c := range candidates {
+		if c.ID == "comments" || c.Path == "/html/body/section" {
+			t.Errorf("candidate %+v is inside the detached comment thread", c)
+		}
+	}
+}
@@ -1,18 +1,23 @@
 package html
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	htmlstd "html"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/cybergodev/html/internal"
 	stdxhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // markdownEscapeReplacer escapes characters that could break Markdown link/image syntax.
@@ -135,6 +140,56 @@ func Extract(htmlBytes []byte, cfg ...Config) (*Result, error) {
 	})
 }
 
+// prepareDocument runs the passes that shape a parsed, depth-validated
+// document before content selection, for Extract and ExplainArticleDetection
+// alike. beforeSanitize, when not nil, runs while scripts and the raw markup
+// are still present. Sanitization only runs on a depth-validated tree, so its
+// recursion is bounded by MaxDepth.
+func (p *Processor) prepareDocument(doc *stdxhtml.Node, audit internal.AuditRecorder, beforeSanitize func()) {
+	if p.config.IncludeTemplates {
+		unwrapTemplates(doc)
+	}
+
+	// Fallback images live in <noscript>, which sanitization removes.
+	if p.config.ResolveNoscriptImages {
+		liftNoscriptImages(doc)
+	}
+
+	// Inline SVG is removed by sanitization, so it is swapped for images first.
+	var svgImages []svgPlaceholder
+	if p.config.IncludeInlineSVG && p.config.PreserveImages {
+		svgImages = replaceInlineSVGs(doc)
+	}
+
+	if beforeSanitize != nil {
+		beforeSanitize()
+	}
+
+	// Sanitize DOM in-place (avoids render + re-parse overhead).
+	if p.config.EnableSanitization {
+		internal.SanitizeDOM(doc, audit)
+	}
+	setSVGSources(svgImages)
+}
+
+// selectionRoot detaches the reader comment thread from doc when
+// ExtractComments is set, returning its text, and returns the node content
+// selection starts from: the Selector scope when one is set, else doc.
+func (p *Processor) selectionRoot(doc *stdxhtml.Node) (*stdxhtml.Node, string, error) {
+	var commentThread string
+	if p.config.ExtractComments {
+		commentThread = p.extractCommentThread(doc)
+	}
+	if p.selector == nil {
+		return doc, commentThread, nil
+	}
+	scoped, err := p.selector.scope(doc)
+	if err != nil {
+		return nil, "", err
+	}
+	return scoped, commentThread, nil
+}
+
 // ExtractFromFile extracts content from an HTML file with automatic encoding detection.
 // This is a convenience function that uses a pooled Processor for efficiency.
 //
@@ -266,8 +321,8 @@ func ExtractFromFileWithContext(ctx context.Context, filePath string, cfg ...Con
 //   - ErrProcessorClosed if the processor is nil or has been closed
 //   - ErrInputTooLarge (wrapped in *InputError) if len(htmlBytes) exceeds MaxInputSize
 //   - a wrapped error if character-encoding detection or conversion fails
-//   - ErrInvalidHTML if the bytes cannot be parsed as HTML
-//   - ErrMaxDepthExceeded if element nesting exceeds MaxDepth
+//   - ErrInvalidHTML (wrapped in *ExtractError) if the bytes cannot be parsed as HTML
+//   - ErrMaxDepthExceeded (wrapped in *ExtractError) if element nesting exceeds MaxDepth
 //   - ErrProcessingTimeout if processing exceeds ProcessingTimeout
 //   - ErrInternalPanic if an unexpected internal panic is recovered
 func (p *Processor) Extract(htmlBytes []byte) (*Result, error) {
@@ -336,7 +391,7 @@ func (p *Processor) extractCoreWithContext(ctx context.Context, htmlBytes []byte
 	// wrongly treat as "no key" and skip caching.
 	var cacheKey [16]byte
 	hasCacheKey := false
-	if p.config.MaxCacheEntries > 0 {
+	if p.cache.MaxEntries() > 0 {
 		cacheKey = p.generateCacheKey(utf8String)
 		hasCacheKey = true
 		if cached := p.cache.Get(cacheKey); cached != nil {
@@ -438,7 +493,7 @@ func (p *Processor) processContentWithContext(ctx context.Context, htmlContent s
 
 	doc, err := stdxhtml.Parse(strings.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrInvalidHTML, err)
+		return nil, newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
 	}
 
 	// Check context before depth validation
@@ -455,19 +510,35 @@ func (p *Processor) processContentWithContext(ctx context.Context, htmlContent s
 	// first bounds every later recursive pass to MaxDepth. Depth is measured on
 	// the raw parsed tree; sanitization only removes nodes, so this is at most
 	// marginally stricter than the previous sanitize-then-validate order.
-	if err := p.validateDepthTraversal(doc, 0); err != nil {
+	nodes, err := p.countNodesWithinDepth(doc, 0)
+	if err != nil {
 		return nil, err
 	}
+	var debug *DebugInfo
+	if p.config.IncludeDebugInfo {
+		debug = &DebugInfo{NodesWalked: nodes}
+	}
 
-	// Sanitize DOM in-place (avoids render + re-parse overhead). Runs only on a
-	// depth-validated tree, so its recursion is bounded by MaxDepth.
-	if p.config.EnableSanitization {
-		if p.audit != nil && p.config.Audit.Enabled {
-			internal.SanitizeDOM(doc, p.auditAdapter)
-		} else {
-			internal.SanitizeDOM(doc, internal.NoOpAuditRecorder{})
-		}
+	// Date and author candidates include JSON-LD scripts, and document-wide
+	// links are taken as ExtractAllLinks sees them, so both are gathered
+	// before sanitization.
+	var signals documentSignals
+	var documentLinks []LinkInfo
+	var audit internal.AuditRecorder = internal.NoOpAuditRecorder{}
+	if p.audit != nil && p.config.Audit.Enabled {
+		audit = p.auditAdapter
+	}
+	if debug != nil {
+		audit = &countingRecorder{AuditRecorder: audit, blockedTags: &debug.SanitizedElements}
 	}
+	p.prepareDocument(doc, audit, func() {
+		if p.config.ExtractMetadata {
+			signals = collectSignals(doc)
+		}
+		if p.config.ExtractLinksPreSanitization && p.config.PreserveLinks {
+			documentLinks = p.extractLinksWithPosition(doc, p.documentOrigin(doc))
+		}
+	})
 
 	// Check context before document extraction
 	select {
@@ -476,7 +547,7 @@ func (p *Processor) processContentWithContext(ctx context.Context, htmlContent s
 	default:
 	}
 
-	return p.extractFromDocument(doc, originalHTML)
+	return p.extractFromDocument(doc, originalHTML, signals, documentLinks, debug)
 }
 
 // ExtractFromFile extracts content from an HTML file with automatic encoding detection.
@@ -700,6 +771,13 @@ var depthStackPool = sync.Pool{
 // It folds depth validation into a single traversal rather than scanning the
 // tree twice (once for depth, once for extraction).
 func (p *Processor) validateDepthTraversal(root *stdxhtml.Node, initialDepth int) error {
+	_, err := p.countNodesWithinDepth(root, initialDepth)
+	return err
+}
+
+// countNodesWithinDepth is validateDepthTraversal, also returning the number
+// of nodes walked, for DebugInfo.
+func (p *Processor) countNodesWithinDepth(root *stdxhtml.Node, initialDepth int) (int, error) {
 	// Use iterative approach with explicit stack to avoid stack overflow
 	// on deeply nested documents (MaxDepth can be up to 500). The stack is
 	// borrowed from a pool and returned when traversal completes, so a
@@ -713,12 +791,14 @@ func (p *Processor) validateDepthTraversal(root *stdxhtml.Node, initialDepth int
 
 	stack = append(stack, depthStackEntry{root, initialDepth})
 
+	nodes := 0
 	for len(stack) > 0 {
 		entry := stack[len(stack)-1]
 		stack = stack[:len(stack)-1]
+		nodes++
 
 		if entry.depth > p.config.MaxDepth {
-			return ErrMaxDepthExceeded
+			return nodes, newExtractError("walk", -1, ErrMaxDepthExceeded)
 		}
 
 		// Add children to stack in reverse order for correct traversal order
@@ -726,60 +806,88 @@ func (p *Processor) validateDepthTraversal(root *stdxhtml.Node, initialDepth int
 			stack = append(stack, depthStackEntry{c, entry.depth + 1})
 		}
 	}
-	return nil
+	return nodes, nil
 }
 
-func (p *Processor) extractFromDocument(doc *stdxhtml.Node, htmlContent string) (*Result, error) {
-	result := &Result{}
+// extractFromDocument extracts the Result from the sanitized doc. documentLinks,
+// collected before sanitization, replaces the content links in Result.Links
+// when ExtractLinksPreSanitization is set. debug, when non-nil, becomes
+// Result.DebugInfo and receives the count of elements removed by cleaning.
+func (p *Processor) extractFromDocument(doc *stdxhtml.Node, htmlContent string, signals documentSignals, documentLinks []LinkInfo, debug *DebugInfo) (*Result, error) {
+	result := &Result{DebugInfo: debug}
 	result.Title = p.extractTitle(doc)
-
-	contentNode := doc
-	if p.config.ExtractArticle {
-		if article := p.extractArticleNode(doc); article != nil {
+	// Base detection walks the whole document, so it runs once for every
+	// metadata, link and image URL below.
+	baseURL, originURL := p.documentBase(doc)
+	var description string
+	if p.config.ExtractMetadata {
+		description = p.extractMetadata(doc, baseURL, result)
+		signals.apply(result)
+	}
+	if p.config.ExtractFeeds {
+		result.Feeds = p.extractFeeds(doc, baseURL)
+	}
+	if p.config.IncludeComments {
+		result.Comments = collectComments(doc)
+	}
+	if p.dataAttributes != nil {
+		result.DataAttributes = collectDataAttributes(doc, p.dataAttributes)
+	}
+	contentNode, commentThread, err := p.selectionRoot(doc)
+	if err != nil {
+		return nil, err
+	}
+	result.CommentThread = commentThread
+	if p.selector == nil && p.config.ExtractArticle {
+		article, confidence := p.extractArticleNode(doc)
+		if article != nil {
 			contentNode = article
 		}
+		result.ArticleConfidence = confidence
+	}
+	if p.config.PreserveFootnotes {
+		result.Footnotes = p.extractFootnotes(doc, contentNode)
+	}
+	contentNode = p.cleanContentNode(contentNode, debug)
+	if p.config.ExtractAbbreviations || p.config.ExpandAbbreviations {
+		abbreviations := p.extractAbbreviations(contentNode)
+		if p.config.ExtractAbbreviations {
+			result.Abbreviations = abbreviations
+		}
 	}
-	contentNode = internal.CleanContentNode(contentNode)
+	result.LinkDensity = internal.CalculateLinkWordDensity(contentNode)
 
 	imageFormat := p.imageFormat
 	linkFormat := p.linkFormat
-
 	// Use placeholder path if either image or link format is not "none"
-	if imageFormat != "none" || linkFormat != "none" {
-		images := p.extractImagesWithPosition(contentNode)
-		links := p.extractLinksWithPosition(contentNode)
-
-		if p.config.PreserveImages {
-			result.Images = images
+	placeholders := imageFormat != "none" || linkFormat != "none"
+
+	// The text, image, link, video and audio walks only read the DOM and each
+	// write their own variable, so they can run concurrently on large pages.
+	var text string
+	var spans []TextSpan
+	var langs []string
+	var images []ImageInfo
+	var links []LinkInfo
+	tasks := make([]func(), 0, 5)
+	tasks = append(tasks, func() {
+		var imageCounter, linkCounter *int
+		if placeholders {
+			imageCounter, linkCounter = new(int), new(int)
 		}
-		if p.config.PreserveLinks {
-			result.Links = links
-		}
-
-		sb := internal.GetBuilder()
-		sb.Grow(initialTextSize)
-		imageCounter := 0
-		linkCounter := 0
-		internal.ExtractTextWithStructureAndImages(contentNode, sb, &imageCounter, &linkCounter, p.config.TableFormat)
-		textWithPlaceholders := internal.CleanText(sb.String(), nil)
-		internal.PutBuilder(sb)
-
-		// Apply formatters in order: images first, then links
-		result.Text = p.formatInlineImages(textWithPlaceholders, images, imageFormat)
-		result.Text = p.formatInlineLinks(result.Text, links, linkFormat)
-	} else {
-		result.Text = p.extractTextContent(contentNode, p.config.TableFormat)
-
-		if p.config.PreserveImages {
-			result.Images = p.extractImagesWithPosition(contentNode)
-		}
-		if p.config.PreserveLinks {
-			result.Links = p.extractLinksWithPosition(contentNode)
+		if p.config.TrackPositions || p.config.TrackSegmentLanguage {
+			text, spans, langs = p.trackedText(contentNode, imageCounter, linkCounter)
+		} else {
+			text = p.structuredText(contentNode, imageCounter, linkCounter, p.config.TableFormat)
 		}
+	})
+	if placeholders || p.config.PreserveImages {
+		tasks = append(tasks, func() { images = p.extractImagesWithPosition(contentNode) })
+	}
+	contentLinks := p.config.PreserveLinks && !p.config.ExtractLinksPreSanitization
+	if placeholders || contentLinks {
+		tasks = append(tasks, func() { links = p.extractLinksWithPosition(contentNode, originURL) })
 	}
-
-	result.WordCount = p.countWords(result.Text)
-	result.ReadingTime = p.calculateReadingTime(result.WordCount)
 
 	// Compute the media-reference gate once for both extractors. HasMediaReference
 	// scans the whole document, and the video and audio gates evaluate the same
@@ -788,17 +896,102 @@ func (p *Processor) extractFromDocument(doc *stdxhtml.Node, htmlContent string)
 	if p.config.PreserveVideos || p.config.PreserveAudios {
 		canContainMedia := len(htmlContent) > 0 &&
 			len(htmlContent) <= maxHTMLForRegex &&
-			internal.HasMediaReference(htmlContent)
+			(internal.HasMediaReference(htmlContent) || p.mediaExtensions.mayReference(htmlContent))
 		if p.config.PreserveVideos {
-			result.Videos = p.extractVideos(doc, htmlContent, canContainMedia)
+			tasks = append(tasks, func() { result.Videos = p.extractVideos(doc, htmlContent, canContainMedia) })
 		}
 		if p.config.PreserveAudios {
-			result.Audios = p.extractAudios(doc, htmlContent, canContainMedia)
+			tasks = append(tasks, func() { result.Audios = p.extractAudios(doc, htmlContent, canContainMedia) })
+		}
+	}
+	p.runExtractionTasks(len(htmlContent), tasks)
+	if p.resolveContentURLs {
+		p.resolveExtractedURLs(result, images, links, documentLinks)
+	}
+
+	if placeholders {
+		// Apply formatters in order: images first, then links
+		text = p.formatInlineImages(text, images, imageFormat)
+		text = p.formatInlineLinks(text, links, linkFormat)
+	}
+	if spans != nil {
+		locateSpans(text, spans)
+	}
+	if p.config.IsolateRTLText {
+		isolated, marks := isolateRTL(text)
+		shiftSpans(isolated, spans, marks)
+		text = isolated
+	}
+	result.Text = text
+	if spans != nil {
+		if p.config.TrackPositions {
+			result.TextSpans = spans
+		}
+		if p.config.TrackSegmentLanguage {
+			result.LanguageSpans = languageSpans(text, spans, langs)
 		}
 	}
+	result.MainImage = p.mainImage(baseURL, contentNode, result.SocialImage, images)
+	if p.config.PreserveImages {
+		result.Images = images
+	}
+	if contentLinks {
+		result.Links = links
+	} else if p.config.PreserveLinks {
+		result.Links = documentLinks
+	}
+	if p.config.DeduplicateImages && len(result.Images) > 1 {
+		result.Images = p.deduplicateImages(baseURL, result.Images)
+	}
+	if p.config.SkipDecorativeImages {
+		result.Images = withoutDecorativeImages(result.Images)
+	}
+
+	if p.config.SplitSections {
+		result.Sections = p.extractSections(contentNode)
+	}
+	if p.config.ExtractLists {
+		result.Lists = p.extractLists(contentNode)
+	}
+
+	result.WordCount = p.countWords(result.Text)
+	if p.config.ComputeKeywords || p.config.CountSignificantWords {
+		stopWords := p.documentStopWords(doc)
+		if p.config.ComputeKeywords {
+			result.Keywords = computeKeywords(result.Text, stopWords)
+		}
+		if p.config.CountSignificantWords {
+			result.SignificantWordCount = significantWordCount(result.Text, stopWords)
+		}
+	}
+	result.ReadingTime = p.calculateReadingTime(result.Text, result.WordCount)
+	result.Excerpt = p.buildExcerpt(description, result.Text)
 	return result, nil
 }
 
+// cleanContentNode removes non-content elements from node. A DefaultScorer
+// configured by the processor (e.g. with case/accent folding) also drives
+// removal; custom scorers only influence article selection. ContentFilter
+// applies on top of either heuristic.
+func (p *Processor) cleanContentNode(node *stdxhtml.Node, debug *DebugInfo) *stdxhtml.Node {
+	var remover internal.Scorer
+	switch ds, ok := p.scorer.(*internal.DefaultScorer); {
+	case p.config.IncludeBoilerplate:
+		remover = internal.InvisibleScorer()
+	case ok:
+		remover = ds
+	default:
+		remover = internal.SharedDefaultScorer()
+	}
+	if p.contentFilter != nil {
+		remover = filteredRemover{Scorer: remover, filter: p.contentFilter}
+	}
+	if debug != nil {
+		remover = countingRemover{Scorer: remover, removed: &debug.RemovedElements}
+	}
+	return internal.CleanContentNodeWithScorer(node, remover)
+}
+
 func (p *Processor) extractTitle(doc *stdxhtml.Node) string {
 	if doc == nil {
 		return ""
@@ -839,38 +1032,245 @@ func (p *Processor) extractTitle(doc *stdxhtml.Node) string {
 	return ""
 }
 
-func (p *Processor) extractArticleNode(doc *stdxhtml.Node) *stdxhtml.Node {
+// extractArticleNode returns the main content node and the confidence in that
+// choice, falling back to <body> with confidence 0 when no element scores.
+func (p *Processor) extractArticleNode(doc *stdxhtml.Node) (*stdxhtml.Node, float64) {
 	if doc == nil {
-		return nil
+		return nil, 0
 	}
 	// Pre-allocate map with initial capacity to reduce resizing
 	candidates := make(map[*stdxhtml.Node]int, initialMapCap)
+	p.walkCandidates(doc, func(n *stdxhtml.Node, score int) {
+		candidates[n] = score
+	})
+	bestNode := internal.SelectBestCandidate(candidates)
+	if bestNode == nil {
+		return internal.FindElementByTag(doc, "body"), 0
+	}
+	confidence := articleConfidence(bestNode, candidates)
+	if p.config.MergeTopCandidates && len(candidates) > 1 {
+		if merged := mergeTopCandidates(doc, candidates, p.config.ArticleScoreThreshold); merged != nil {
+			return merged, confidence
+		}
+	}
+	return bestNode, confidence
+}
 
+// walkCandidates calls fn, in document order, with each element of doc
+// scoring at least ArticleScoreThreshold, and at least 1, as an article
+// candidate.
+func (p *Processor) walkCandidates(doc *stdxhtml.Node, fn func(n *stdxhtml.Node, score int)) {
+	threshold := max(p.config.ArticleScoreThreshold, 1)
 	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
 		if n.Type == stdxhtml.ElementNode {
-			if score := p.scorer.Score(n); score > 0 {
-				candidates[n] = score
+			// Nothing inside script, style, noscript or an inert <template>
+			// renders, so it cannot be the article. IncludeTemplates unwraps
+			// templates before this runs.
+			if internal.IsInvisibleElement(n.Data) {
+				return false
+			}
+			if score := p.scoreNode(n); score >= threshold {
+				fn(n, score)
 			}
 		}
 		return true
 	})
-	if bestNode := internal.SelectBestCandidate(candidates); bestNode != nil {
-		return bestNode
+}
+
+// articleConfidence rates how clearly best stands out as the article, from 0
+// to 1. It combines the content density of best with the smaller of two score
+// margins: best over the strongest candidate outside it, and, inside best, the
+// strongest region over the strongest one disjoint from it. The second margin
+// catches a winning wrapper such as <body> that holds several equally strong
+// regions. Ancestors of best are ignored, since they score much like it.
+func articleConfidence(best *stdxhtml.Node, candidates map[*stdxhtml.Node]int) float64 {
+	outside := 0
+	var inside []*stdxhtml.Node
+	for n, score := range candidates {
+		switch {
+		case n == best || isAncestor(n, best):
+		case isAncestor(best, n):
+			inside = append(inside, n)
+		default:
+			outside = max(outside, score)
+		}
+	}
+	margin := scoreMargin(candidates[best], outside)
+
+	if len(inside) > 1 {
+		sort.Slice(inside, func(i, j int) bool {
+			return candidates[inside[i]] > candidates[inside[j]]
+		})
+		top, second := inside[0], 0
+		for _, n := range inside[1:] {
+			if !isAncestor(top, n) && !isAncestor(n, top) {
+				second = candidates[n]
+				break
+			}
+		}
+		margin = min(margin, scoreMargin(candidates[top], second))
 	}
-	return internal.FindElementByTag(doc, "body")
+
+	confidence := 0.6*margin + 0.4*internal.CalculateContentDensity(best)
+	return min(max(confidence, 0), 1)
 }
 
-func (p *Processor) extractTextContent(node *stdxhtml.Node, tableFormat string) string {
-	sb := internal.GetBuilder()
-	sb.Grow(initialTextSize)
-	internal.ExtractTextWithStructureAndImages(node, sb, nil, nil, tableFormat)
-	result := internal.CleanText(sb.String(), nil)
-	internal.PutBuilder(sb)
-	return result
+// scoreMargin returns how far score leads rival, as a fraction of score.
+func scoreMargin(score, rival int) float64 {
+	if score <= 0 {
+		return 0
+	}
+	return float64(score-min(rival, score)) / float64(score)
+}
+
+// scoreNode scores an article candidate with ContentScorer when set, and with
+// the processor's scorer otherwise.
+func (p *Processor) scoreNode(n *stdxhtml.Node) int {
+	if p.config.ContentScorer != nil {
+		return p.config.ContentScorer(contentNodeAdapter{n})
+	}
+	return p.scorer.Score(n)
 }
 
+// mergeTopCandidates combines the non-overlapping candidates scoring at least
+// threshold (or half the best score when threshold is 0) into a detached <div>
+// holding copies of them in document order. Where a candidate contains another,
+// only the higher-scoring one is kept. The copies leave doc untouched for the
+// later whole-document passes. It returns nil when fewer than two regions remain,
+// so the caller falls back to the single best candidate.
+func mergeTopCandidates(doc *stdxhtml.Node, candidates map[*stdxhtml.Node]int, threshold int) *stdxhtml.Node {
+	if threshold <= 0 {
+		best := 0
+		for _, score := range candidates {
+			best = max(best, score)
+		}
+		threshold = (best + 1) / 2
+	}
+
+	ranked := make([]*stdxhtml.Node, 0, len(candidates))
+	for n, score := range candidates {
+		if score >= threshold {
+			ranked = append(ranked, n)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		return candidates[ranked[i]] > candidates[ranked[j]]
+	})
+
+	selected := make(map[*stdxhtml.Node]bool, len(ranked))
+	for _, n := range ranked {
+		overlaps := false
+		for s := range selected {
+			if isAncestor(s, n) || isAncestor(n, s) {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			selected[n] = true
+		}
+	}
+	if len(selected) < 2 {
+		return nil
+	}
+
+	merged := &stdxhtml.Node{Type: stdxhtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if selected[n] {
+			merged.AppendChild(internal.CloneNode(n))
+			return false
+		}
+		return true
+	})
+	return merged
+}
+
+// writeStructuredText writes the structured text of node to buf, keeping
+// boilerplate elements when IncludeBoilerplate is set and <pre> whitespace
+// when PreservePreformatted is set.
+func (p *Processor) writeStructuredText(node *stdxhtml.Node, buf *bytes.Buffer, imageCounter, linkCounter *int) {
+	internal.ExtractStructuredText(node, buf, imageCounter, linkCounter, p.textOptions(p.config.TableFormat))
+}
+
+// structuredText returns the cleaned structured text of node. The raw text is
+// built in a pooled bytes.Buffer rather than a strings.Builder: Builder.Reset
+// discards the backing array, so a pooled Builder still allocated and regrew
+// its buffer on every call, while a pooled Buffer keeps its capacity and only
+// the final string is allocated.
+func (p *Processor) structuredText(node *stdxhtml.Node, imageCounter, linkCounter *int, tableFormat string) string {
+	buf := getTextBuffer()
+	defer putTextBuffer(buf)
+	opts := p.textOptions(tableFormat)
+	opts.MaxParagraphs = p.config.MaxParagraphs
+	internal.ExtractStructuredText(node, buf, imageCounter, linkCounter, opts)
+	return p.cleanText(buf.String())
+}
+
+// getTextBuffer returns an empty pooled buffer for building text.
+func getTextBuffer() *bytes.Buffer {
+	return internal.GetBuffer()
+}
+
+// putTextBuffer returns buf to the pool unless it grew past
+// maxPooledTextBuffer, so that one huge document does not pin its buffer.
+func putTextBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledTextBuffer {
+		return
+	}
+	internal.PutBuffer(buf)
+}
+
+// textOptions returns the structured text options for the processor's config.
+func (p *Processor) textOptions(tableFormat string) internal.TextOptions {
+	opts := internal.TextOptions{
+		TableFormat:          tableFormat,
+		IncludeBoilerplate:   p.config.IncludeBoilerplate,
+		PreservePreformatted: p.config.PreservePreformatted,
+		ImageAlt:             p.config.IncludeImageAltInText && p.imageFormat == "none",
+		LineBreak:            p.lineBreak,
+	}
+	if p.contentFilter != nil {
+		// Elements kept by ContentFilter survive cleaning, so the text walk
+		// must not skip them by tag either.
+		opts.Keep = p.contentFilter.keeps
+	}
+	return opts
+}
+
+// cleanText normalizes structured text output, first removing the characters
+// selected by StripControlChars and StripEmoji and flattening it onto one line
+// when PreserveParagraphs is disabled.
+func (p *Processor) cleanText(raw string) string {
+	if p.config.StripControlChars || p.config.StripEmoji {
+		raw = internal.StripRunes(raw, p.config.StripControlChars, p.config.StripEmoji)
+	}
+	if !p.config.PreserveParagraphs {
+		raw = internal.CollapseWhitespace(raw)
+	}
+	return internal.CleanText(raw, nil)
+}
+
+// formatInlineImages replaces the [IMAGE:n] placeholders with the images at
+// those positions. The text walk numbers every <img>, so placeholders with no
+// extracted image (past MaxImages, or without a URL) are dropped; the
+// "placeholder" format only drops those past MaxImages.
 func (p *Processor) formatInlineImages(textWithPlaceholders string, images []ImageInfo, format string) string {
-	if len(images) == 0 || format == "placeholder" || format == "none" {
+	if format == "none" {
+		return textWithPlaceholders
+	}
+	if format == "placeholder" {
+		if maxImages := p.config.MaxImages; maxImages > 0 && len(images) >= maxImages {
+			last := images[len(images)-1].Position
+			textWithPlaceholders = dropImagePlaceholders(textWithPlaceholders, func(n int) bool { return n > last })
+		}
+		return textWithPlaceholders
+	}
+	positions := make(map[int]bool, len(images))
+	for i := range images {
+		positions[images[i].Position] = true
+	}
+	textWithPlaceholders = dropImagePlaceholders(textWithPlaceholders, func(n int) bool { return !positions[n] })
+	if len(images) == 0 {
 		return textWithPlaceholders
 	}
 
@@ -934,6 +1334,56 @@ func (p *Processor) formatInlineImages(textWithPlaceholders string, images []Ima
 	return textWithPlaceholders
 }
 
+// dropImagePlaceholders removes the [IMAGE:n] placeholders of text for which
+// drop(n) is true. A placeholder alone on its line is removed with the line,
+// keeping the paragraph break around it.
+func dropImagePlaceholders(text string, drop func(int) bool) string {
+	const prefix = "[IMAGE:"
+	var b strings.Builder
+	rest := text
+	for {
+		i := strings.Index(rest, prefix)
+		if i < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[i+len(prefix):], ']')
+		n, err := -1, error(nil)
+		if end >= 0 {
+			n, err = strconv.Atoi(rest[i+len(prefix) : i+len(prefix)+end])
+		}
+		if end < 0 || err != nil || !drop(n) {
+			b.WriteString(rest[:i+len(prefix)])
+			rest = rest[i+len(prefix):]
+			continue
+		}
+		before, after := rest[:i], rest[i+len(prefix)+end+1:]
+		kept := strings.TrimRight(before, "\n")
+		lineBefore := len(kept) < len(before) || b.Len()+len(before) == 0
+		trimmed := strings.TrimLeft(after, "\n")
+		if lineBefore && (len(trimmed) < len(after) || after == "") {
+			// Alone on its line: keep the larger of the two line breaks, none
+			// at the start or end of the text.
+			breaks := max(len(before)-len(kept), len(after)-len(trimmed))
+			b.WriteString(kept)
+			if b.Len() > 0 && trimmed != "" {
+				b.WriteString(strings.Repeat("\n", breaks))
+			}
+			rest = trimmed
+			continue
+		}
+		b.WriteString(before)
+		if strings.HasSuffix(before, " ") && strings.HasPrefix(after, " ") {
+			after = after[1:]
+		}
+		rest = after
+	}
+	if b.Len() == 0 && rest == text {
+		return text
+	}
+	b.WriteString(rest)
+	return b.String()
+}
+
 func (p *Processor) formatInlineLinks(textWithPlaceholders string, links []LinkInfo, format string) string {
 	if len(links) == 0 || format == "none" {
 		return textWithPlaceholders
@@ -1044,8 +1494,12 @@ func (p *Processor) formatInlineLinks(textWithPlaceholders string, links []LinkI
 func (p *Processor) extractImagesWithPosition(node *stdxhtml.Node) []ImageInfo {
 	images := make([]ImageInfo, 0, initialSliceCap)
 	position := 0
+	maxImages := p.config.MaxImages
 
 	internal.WalkNodes(node, func(n *stdxhtml.Node) bool {
+		if maxImages > 0 && len(images) >= maxImages {
+			return false
+		}
 		if n.Type == stdxhtml.ElementNode && n.Data == "img" {
 			position++
 			img := p.parseImageNode(n, position)
@@ -1059,13 +1513,77 @@ func (p *Processor) extractImagesWithPosition(node *stdxhtml.Node) []ImageInfo {
 	return images
 }
 
+// imageSizeParams are query parameters that select a rendition of the same
+// image, so URLs differing only in them are treated as duplicates.
+var imageSizeParams = map[string]bool{
+	"w": true, "h": true, "width": true, "height": true, "size": true,
+	"resize": true, "fit": true, "crop": true, "dpr": true, "q": true, "quality": true,
+}
+
+// deduplicateImages keeps the first occurrence of each image, keyed on its
+// resolved URL without size query parameters. A later duplicate's alt text
+// fills in an empty alt on the kept image. The input slice is not modified.
+func (p *Processor) deduplicateImages(baseURL string, images []ImageInfo) []ImageInfo {
+	unique := make([]ImageInfo, 0, len(images))
+	index := make(map[string]int, len(images))
+	for _, img := range images {
+		key := imageDedupKey(internal.ResolveURL(baseURL, img.URL))
+		if i, ok := index[key]; ok {
+			if unique[i].Alt == "" && img.Alt != "" {
+				unique[i].Alt = img.Alt
+				unique[i].IsDecorative = false
+			}
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, img)
+	}
+	return unique
+}
+
+// withoutDecorativeImages returns the images that are not decorative, in
+// order, leaving their Position untouched so placeholders keep matching.
+func withoutDecorativeImages(images []ImageInfo) []ImageInfo {
+	kept := images[:0:0]
+	for _, img := range images {
+		if !img.IsDecorative {
+			kept = append(kept, img)
+		}
+	}
+	return kept
+}
+
+// imageDedupKey strips the fragment and size query parameters from an image URL.
+func imageDedupKey(rawURL string) string {
+	if strings.HasPrefix(rawURL, "data:") {
+		return rawURL
+	}
+	rawURL, _, _ = strings.Cut(rawURL, "#")
+	path, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	var kept []string
+	for _, param := range strings.Split(query, "&") {
+		name, _, _ := strings.Cut(param, "=")
+		if param != "" && !imageSizeParams[strings.ToLower(name)] {
+			kept = append(kept, param)
+		}
+	}
+	if len(kept) == 0 {
+		return path
+	}
+	return path + "?" + strings.Join(kept, "&")
+}
+
 func (p *Processor) parseImageNode(n *stdxhtml.Node, position int) ImageInfo {
 	img := ImageInfo{Position: position}
+	var style string
 
 	for _, attr := range n.Attr {
 		switch attr.Key {
 		case "src":
-			if !internal.IsValidURL(attr.Val) {
+			if !internal.IsValidURL(attr.Val) || !p.allowDataURL(attr.Val) {
 				return ImageInfo{}
 			}
 			img.URL = attr.Val
@@ -1077,6 +1595,8 @@ func (p *Processor) parseImageNode(n *stdxhtml.Node, position int) ImageInfo {
 			img.Width = attr.Val
 		case "height":
 			img.Height = attr.Val
+		case "style":
+			style = attr.Val
 		}
 	}
 
@@ -1084,18 +1604,84 @@ func (p *Processor) parseImageNode(n *stdxhtml.Node, position int) ImageInfo {
 		return ImageInfo{}
 	}
 
+	// Many CMS themes size images only through inline CSS; use it as a
+	// fallback when the width/height attributes are missing.
+	if style != "" {
+		if img.Width == "" {
+			img.Width = styleDimension(style, "width")
+		}
+		if img.Height == "" {
+			img.Height = styleDimension(style, "height")
+		}
+	}
+
+	if mimeType, _, _, ok := internal.ParseDataURL(img.URL); ok {
+		img.MimeType = mimeType
+		if img.Width == "" && img.Height == "" {
+			if w, h, ok := internal.DataURLImageSize(img.URL); ok {
+				img.Width, img.Height = strconv.Itoa(w), strconv.Itoa(h)
+			}
+		}
+	}
+
 	img.IsDecorative = img.Alt == ""
 	return img
 }
 
-func (p *Processor) extractLinksWithPosition(node *stdxhtml.Node) []LinkInfo {
+// allowDataURL reports whether url is either not a data: URL or one within MaxDataURLSize.
+func (p *Processor) allowDataURL(url string) bool {
+	return !strings.HasPrefix(url, "data:") || len(url) <= p.config.MaxDataURLSize
+}
+
+// styleDimension returns the pixel value of property (e.g. "width") from an
+// inline style declaration list, without the "px" unit, or "" when the property
+// is absent or not a plain pixel length.
+func styleDimension(style, property string) string {
+	for _, decl := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), property) {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		value = strings.TrimSpace(strings.TrimSuffix(value, "!important"))
+		if len(value) > 2 && strings.EqualFold(value[len(value)-2:], "px") {
+			value = strings.TrimSpace(value[:len(value)-2])
+		}
+		if isPixelNumber(value) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isPixelNumber reports whether s is a non-negative decimal number such as "640" or "480.5".
+func isPixelNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	seenDot := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+		case c == '.' && !seenDot && i > 0:
+			seenDot = true
+		default:
+			return false
+		}
+	}
+	return s[len(s)-1] != '.'
+}
+
+// extractLinksWithPosition collects the <a> elements under node, classifying
+// IsExternal against originURL (see documentOrigin).
+func (p *Processor) extractLinksWithPosition(node *stdxhtml.Node, originURL string) []LinkInfo {
 	links := make([]LinkInfo, 0, initialSliceCap)
 	position := 0
 
 	internal.WalkNodes(node, func(n *stdxhtml.Node) bool {
 		if n.Type == stdxhtml.ElementNode && n.Data == "a" {
 			position++
-			link := p.parseLinkNode(n)
+			link := p.parseLinkNode(n, originURL)
 			link.Position = position
 			if link.URL != "" {
 				links = append(links, link)
@@ -1107,7 +1693,7 @@ func (p *Processor) extractLinksWithPosition(node *stdxhtml.Node) []LinkInfo {
 	return links
 }
 
-func (p *Processor) parseLinkNode(n *stdxhtml.Node) LinkInfo {
+func (p *Processor) parseLinkNode(n *stdxhtml.Node, originURL string) LinkInfo {
 	link := LinkInfo{}
 
 	for _, attr := range n.Attr {
@@ -1132,36 +1718,81 @@ func (p *Processor) parseLinkNode(n *stdxhtml.Node) LinkInfo {
 	}
 
 	link.Text = internal.GetTextContent(n)
-	link.IsExternal = internal.IsExternalURL(link.URL)
+	link.IsExternal = p.isExternalLink(originURL, link.URL)
 	return link
 }
 
 func (p *Processor) countWords(text string) int {
-	if text == "" {
+	return internal.CountWords(text)
+}
+
+// calculateReadingTime estimates the reading time of text. CJK scripts are not
+// space-delimited, so text where CJK characters make up at least half of the
+// non-space characters is timed per character rather than per word.
+func (p *Processor) calculateReadingTime(text string, wordCount int) time.Duration {
+	if wordCount == 0 {
 		return 0
 	}
-	n := len(text)
-	count := 0
-	inWord := false
-	for i := 0; i < n; i++ {
-		c := text[i]
-		isSpace := c == ' ' || c == '\t' || c == '\n' || c == '\r'
-		if isSpace {
-			inWord = false
-		} else if !inWord {
-			inWord = true
-			count++
+	var minutes float64
+	if cjk, chars := cjkCharacterShare(text); cjk > 0 && cjk*2 >= chars {
+		minutes = float64(chars) / cjkCharsPerMinute
+	} else {
+		wpm := p.config.WordsPerMinute
+		if wpm <= 0 {
+			wpm = wordsPerMinute
 		}
+		minutes = float64(wordCount) / float64(wpm)
 	}
-	return count
+	return time.Duration(minutes * float64(time.Minute))
 }
 
-func (p *Processor) calculateReadingTime(wordCount int) time.Duration {
-	if wordCount == 0 {
-		return 0
+// buildExcerpt returns the excerpt for a document, preferring the meta description
+// over the extracted text. Whitespace is collapsed and the result is cut at the
+// last word boundary within ExcerptLength characters, with "..." appended when
+// truncated. Text without spaces (such as CJK) is cut at a character boundary.
+func (p *Processor) buildExcerpt(description, text string) string {
+	limit := p.config.ExcerptLength
+	if limit <= 0 {
+		return ""
 	}
-	minutes := float64(wordCount) / wordsPerMinute
-	return time.Duration(minutes * float64(time.Minute))
+	source := strings.Join(strings.Fields(description), " ")
+	if source == "" {
+		source = strings.Join(strings.Fields(text), " ")
+	}
+	if utf8.RuneCountInString(source) <= limit {
+		return source
+	}
+
+	cut := 0
+	for i := range source {
+		if limit == 0 {
+			cut = i
+			break
+		}
+		limit--
+	}
+	excerpt := source[:cut]
+	if source[cut] != ' ' {
+		if space := strings.LastIndexByte(excerpt, ' '); space > 0 {
+			excerpt = excerpt[:space]
+		}
+	}
+	return strings.TrimRight(excerpt, " ,;:") + "..."
+}
+
+// cjkCharacterShare returns the number of CJK characters and the number of
+// non-whitespace characters in text.
+func cjkCharacterShare(text string) (cjk, chars int) {
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		chars++
+		if internal.IsCJKRune(r) {
+			cjk++
+		}
+	}
+	return cjk, chars
 }
 
 // cloneResult returns a deep copy of a Result to prevent data races
@@ -1179,10 +1810,84 @@ func cloneResult(r *Result) *Result {
 	if r.Videos != nil {
 		clone.Videos = make([]VideoInfo, len(r.Videos))
 		copy(clone.Videos, r.Videos)
+		for i := range clone.Videos {
+			if clone.Videos[i].Tracks != nil {
+				clone.Videos[i].Tracks = append([]TrackInfo(nil), clone.Videos[i].Tracks...)
+			}
+		}
 	}
 	if r.Audios != nil {
 		clone.Audios = make([]AudioInfo, len(r.Audios))
 		copy(clone.Audios, r.Audios)
 	}
+	if r.Footnotes != nil {
+		clone.Footnotes = make([]Footnote, len(r.Footnotes))
+		copy(clone.Footnotes, r.Footnotes)
+	}
+	if r.PublishedAt != nil {
+		t := *r.PublishedAt
+		clone.PublishedAt = &t
+	}
+	if r.ModifiedAt != nil {
+		t := *r.ModifiedAt
+		clone.ModifiedAt = &t
+	}
+	if r.Microdata != nil {
+		clone.Microdata = make([]MicrodataItem, len(r.Microdata))
+		for i, item := range r.Microdata {
+			clone.Microdata[i] = MicrodataItem{Type: item.Type, Properties: make(map[string][]string, len(item.Properties))}
+			for name, values := range item.Properties {
+				clone.Microdata[i].Properties[name] = append([]string(nil), values...)
+			}
+		}
+	}
+	if r.Breadcrumbs != nil {
+		clone.Breadcrumbs = append([]string(nil), r.Breadcrumbs...)
+	}
+	if r.Comments != nil {
+		clone.Comments = append([]string(nil), r.Comments...)
+	}
+	if r.DataAttributes != nil {
+		clone.DataAttributes = make(map[string][]string, len(r.DataAttributes))
+		for name, values := range r.DataAttributes {
+			clone.DataAttributes[name] = append([]string(nil), values...)
+		}
+	}
+	if r.Feeds != nil {
+		clone.Feeds = make([]FeedLink, len(r.Feeds))
+		copy(clone.Feeds, r.Feeds)
+	}
+	if r.Sections != nil {
+		clone.Sections = make([]Section, len(r.Sections))
+		copy(clone.Sections, r.Sections)
+	}
+	if r.TextSpans != nil {
+		clone.TextSpans = make([]TextSpan, len(r.TextSpans))
+		copy(clone.TextSpans, r.TextSpans)
+	}
+	if r.LanguageSpans != nil {
+		clone.LanguageSpans = make([]LanguageSpan, len(r.LanguageSpans))
+		copy(clone.LanguageSpans, r.LanguageSpans)
+	}
+	if r.Abbreviations != nil {
+		clone.Abbreviations = make(map[string]string, len(r.Abbreviations))
+		for abbr, title := range r.Abbreviations {
+			clone.Abbreviations[abbr] = title
+		}
+	}
+	if r.Lists != nil {
+		clone.Lists = make([]ListInfo, len(r.Lists))
+		for i, list := range r.Lists {
+			clone.Lists[i] = ListInfo{Ordered: list.Ordered, Items: append([]string(nil), list.Items...)}
+		}
+	}
+	if r.Keywords != nil {
+		clone.Keywords = make([]KeywordCount, len(r.Keywords))
+		copy(clone.Keywords, r.Keywords)
+	}
+	if r.DebugInfo != nil {
+		debug := *r.DebugInfo
+		clone.DebugInfo = &debug
+	}
 	return &clone
 }
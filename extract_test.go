@@ -37,3 +37,30 @@ func TestContainsASCIIFold(t *testing.T) {
 		})
 	}
 }
+
+func TestStyleDimension(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		style    string
+		property string
+		want     string
+	}{
+		{"width:640px;height:480px", "width", "640"},
+		{"width:640px;height:480px", "height", "480"},
+		{"WIDTH: 300PX ; border: 0", "width", "300"},
+		{"height: 120.5px !important", "height", "120.5"},
+		{"width: 200", "width", "200"},
+		{"max-width: 640px", "width", ""},
+		{"width: 100%", "width", ""},
+		{"width: auto", "width", ""},
+		{"width: calc(100% - 10px)", "width", ""},
+		{"", "width", ""},
+	}
+
+	for _, tt := range tests {
+		if got := styleDimension(tt.style, tt.property); got != tt.want {
+			t.Errorf("styleDimension(%q, %q) = %q, want %q", tt.style, tt.property, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package html
+
+// fanout.go runs the independent per-document extraction walks concurrently on large inputs.
+
+import "sync"
+
+// parallelExtractThreshold is the input size in bytes from which the text,
+// image, link and media walks of a single document run concurrently. Below
+// it, goroutine start-up costs more than the walks save.
+const parallelExtractThreshold = 1 << 20
+
+// runExtractionTasks runs tasks, concurrently with at most WorkerPoolSize at a
+// time when the input is at least parallelExtractThreshold bytes, and serially
+// otherwise. It returns once every task has finished. A panic in a task is
+// re-raised on the calling goroutine, so the usual panic recovery of the
+// public API still applies.
+func (p *Processor) runExtractionTasks(inputSize int, tasks []func()) {
+	limit := min(p.config.WorkerPoolSize, len(tasks))
+	if inputSize < parallelExtractThreshold || limit <= 1 {
+		for _, task := range tasks {
+			task()
+		}
+		return
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var panicVal any
+	for _, task := range tasks {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicVal = r })
+				}
+			}()
+			task()
+		}()
+	}
+	wg.Wait()
+	if panicVal != nil {
+		panic(panicVal)
+	}
+}
@@ -0,0 +1,141 @@
+package html
+
+// favicon.go picks the single best site icon declared by a document.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// Icon tiers in order of preference; a lower tier always wins.
+const (
+	iconTierAppleTouch = iota
+	iconTierIcon
+	iconTierShortcut
+	iconTierNone
+)
+
+// scalableIconSize ranks sizes="any" (typically SVG) above every fixed size.
+const scalableIconSize = 1 << 30
+
+// ExtractFavicon returns the URL of the best favicon declared by htmlContent.
+// Candidates are ranked by rel: apple-touch-icon first, then rel="icon", then
+// rel="shortcut icon"; within a rel, the icon with the largest sizes entry wins
+// and sizes="any" outranks any fixed size. If no icon is declared, the result
+// is /favicon.ico resolved against the base.
+//
+// Icon URLs are resolved against baseURL, falling back to Config.BaseURL and
+// then to the base detected from the document. When no base is known, the
+// href is returned as written and the fallback is the relative "/favicon.ico".
+func (p *Processor) ExtractFavicon(htmlContent, baseURL string) (string, error) {
+	return recoverString(func() (string, error) {
+		if err := p.validateInput([]byte(htmlContent)); err != nil {
+			return "", err
+		}
+
+		doc, err := stdxhtml.Parse(strings.NewReader(htmlContent))
+		if err != nil {
+			return "", newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+		}
+		if err := p.validateDepthTraversal(doc, 0); err != nil {
+			return "", err
+		}
+
+		return p.faviconURL(doc, baseURL), nil
+	})
+}
+
+// faviconURL returns the best favicon of doc, or /favicon.ico, resolved
+// against baseURL or, when it is empty, the document base.
+func (p *Processor) faviconURL(doc *stdxhtml.Node, baseURL string) string {
+	if baseURL == "" {
+		baseURL = p.documentBaseURL(doc)
+	}
+	href := bestFaviconHref(doc)
+	if href == "" {
+		href = "/favicon.ico"
+	}
+	return internal.ResolveURL(baseURL, href)
+}
+
+// ExtractFavicon returns the URL of the best favicon declared by htmlContent.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractFavicon for the ranking and fallback rules.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractFavicon(htmlContent, baseURL string, cfg ...Config) (string, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return "", err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (string, error) {
+		return p.ExtractFavicon(htmlContent, baseURL)
+	})
+}
+
+// bestFaviconHref returns the href of the highest-ranked icon <link> in doc,
+// or "" when the document declares none. Ties keep the first icon seen.
+func bestFaviconHref(doc *stdxhtml.Node) string {
+	bestHref := ""
+	bestTier, bestSize := iconTierNone, -1
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode || n.Data != "link" {
+			return true
+		}
+		rel, href := linkRelHref(n)
+		if href == "" || !internal.IsValidURL(href) {
+			return true
+		}
+		tier := iconTier(rel)
+		if tier == iconTierNone {
+			return true
+		}
+		size := largestIconSize(internal.GetAttr(n, "sizes"))
+		if tier < bestTier || (tier == bestTier && size > bestSize) {
+			bestHref, bestTier, bestSize = href, tier, size
+		}
+		return true
+	})
+	return bestHref
+}
+
+// iconTier classifies a lower-cased rel value.
+func iconTier(rel string) int {
+	switch {
+	case relHasToken(rel, "apple-touch-icon"), relHasToken(rel, "apple-touch-icon-precomposed"):
+		return iconTierAppleTouch
+	case relHasToken(rel, "shortcut") && relHasToken(rel, "icon"):
+		return iconTierShortcut
+	case relHasToken(rel, "icon"):
+		return iconTierIcon
+	}
+	return iconTierNone
+}
+
+// largestIconSize returns the largest pixel area listed in a sizes attribute
+// such as "16x16 32x32", scalableIconSize for "any", and 0 when the attribute
+// is absent or malformed.
+func largestIconSize(sizes string) int {
+	largest := 0
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			return scalableIconSize
+		}
+		w, h, ok := strings.Cut(size, "x")
+		if !ok {
+			continue
+		}
+		width, errW := strconv.Atoi(w)
+		height, errH := strconv.Atoi(h)
+		if errW != nil || errH != nil || width <= 0 || height <= 0 || width > 1<<14 || height > 1<<14 {
+			continue
+		}
+		largest = max(largest, width*height)
+	}
+	return largest
+}
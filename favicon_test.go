@@ -0,0 +1,87 @@
+package html_test
+
+import (
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractFavicon(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		baseURL string
+		want    string
+	}{
+		{
+			name: "apple touch icon preferred",
+			doc: `<head><link rel="icon" sizes="512x512" href="/big.png">
+				<link rel="apple-touch-icon" sizes="120x120" href="/touch-120.png">
+				<link rel="apple-touch-icon" sizes="180x180" href="/touch-180.png"></head>`,
+			baseURL: "https://example.com/blog/post",
+			want:    "https://example.com/touch-180.png",
+		},
+		{
+			name: "largest icon size",
+			doc: `<head><link rel="shortcut icon" href="/favicon.ico">
+				<link rel="icon" sizes="16x16 32x32" href="icons/32.png">
+				<link rel="icon" sizes="192x192" href="icons/192.png">
+				<link rel="icon" sizes="48x48" href="icons/48.png"></head>`,
+			baseURL: "https://example.com/",
+			want:    "https://example.com/icons/192.png",
+		},
+		{
+			name:    "scalable icon wins",
+			doc:     `<head><link rel="icon" sizes="256x256" href="/256.png"><link rel="icon" sizes="any" href="/icon.svg"></head>`,
+			baseURL: "https://example.com/",
+			want:    "https://example.com/icon.svg",
+		},
+		{
+			name:    "shortcut icon",
+			doc:     `<head><link rel="Shortcut Icon" href="/static/fav.ico"></head>`,
+			baseURL: "https://example.com/",
+			want:    "https://example.com/static/fav.ico",
+		},
+		{
+			name:    "fallback to favicon.ico",
+			doc:     `<head><title>No icons</title></head>`,
+			baseURL: "https://example.com/",
+			want:    "https://example.com/favicon.ico",
+		},
+		{
+			name: "base detected from document",
+			doc:  `<head><base href="https://cdn.example.org/"><link rel="icon" href="i.png"></head>`,
+			want: "https://cdn.example.org/i.png",
+		},
+		{
+			name: "no base keeps relative fallback",
+			doc:  `<head></head>`,
+			want: "/favicon.ico",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := html.ExtractFavicon(`<html>`+tt.doc+`<body></body></html>`, tt.baseURL)
+			if err != nil {
+				t.Fatalf("ExtractFavicon() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExtractFavicon() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("closed processor", func(t *testing.T) {
+		p, err := html.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = p.Close()
+		if _, err := p.ExtractFavicon("<html></html>", ""); err == nil {
+			t.Error("ExtractFavicon() on closed processor should fail")
+		}
+	})
+}
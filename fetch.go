@@ -0,0 +1,235 @@
+package html
+
+// fetch.go implements ExtractFromURL, which downloads a page over HTTP and
+// extracts it with the response's charset and final URL.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// defaultFetchTimeout bounds a whole ExtractFromURL request, including
+// redirects and reading the body, when Config.HTTPClient is nil.
+const defaultFetchTimeout = 30 * time.Second
+
+// defaultHTTPClient is shared by processors without a Config.HTTPClient, so
+// that its connection pool is reused across calls.
+var defaultHTTPClient = &http.Client{Timeout: defaultFetchTimeout}
+
+// ExtractFromURL fetches the page at rawURL with Config.HTTPClient and
+// extracts its content like Extract. Only http and https URLs are accepted,
+// and a response with a non-2xx status fails with an error matching
+// ErrFetchFailed. The body is subject to MaxInputSize and AutoDecompress as
+// in ExtractReader.
+//
+// Unless Encoding is set, the charset parameter of the Content-Type header is
+// used to decode the body; a byte order mark, or a missing or unsupported
+// charset, falls back to the usual detection from the body. When
+// ResolveRelativeURLs is enabled, relative URLs in the result are resolved
+// against BaseURL, the document's <base href>, or else the final URL of the
+// response after redirects. The same URL decides LinkInfo.IsExternal.
+func (p *Processor) ExtractFromURL(ctx context.Context, rawURL string) (*Result, error) {
+	return recoverResult(func() (*Result, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		body, finalURL, charset, err := p.fetch(ctx, rawURL)
+		if err != nil {
+			p.stats.errorCount.Add(1)
+			return nil, err
+		}
+		if charset != "" && p.config.Encoding == "" && !hasByteOrderMark(body) {
+			if converted, _, convErr := internal.DetectAndConvertToUTF8String(body, charset); convErr == nil {
+				body = []byte(converted)
+			}
+		}
+
+		// The response URL is where the document really lives, so it outranks
+		// og:url and canonical links for resolving and classifying links.
+		base := p.config.BaseURL
+		if base == "" {
+			base = fetchedBaseURL(body, finalURL)
+		}
+		// Share stats and the audit log, so fetched pages, the least trusted
+		// input, are counted and audited like any other.
+		scoped := p.transientProcessor(func(c *Config) { c.BaseURL = base })
+		scoped.stats = p.stats
+		scoped.audit = p.audit
+		scoped.auditAdapter = p.auditAdapter
+		scoped.resolveContentURLs = true
+		return scoped.ExtractWithContext(ctx, body)
+	})
+}
+
+// ExtractFromURL fetches the page at rawURL and extracts its content.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractFromURL for charset handling and URL resolution.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractFromURL(ctx context.Context, rawURL string, cfg ...Config) (*Result, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (*Result, error) {
+		return p.ExtractFromURL(ctx, rawURL)
+	})
+}
+
+// fetch performs the GET request for rawURL and returns the body, the final
+// URL after redirects, and the charset named by the Content-Type header.
+func (p *Processor) fetch(ctx context.Context, rawURL string) ([]byte, string, string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, "", "", fmt.Errorf("%w: unsupported URL scheme %q", ErrFetchFailed, u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+
+	client := p.config.HTTPClient
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %w", ErrFetchFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", "", fmt.Errorf("%w: %s returned %s", ErrFetchFailed, u.Redacted(), resp.Status)
+	}
+
+	body, err := p.readInput(resp.Body)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	finalURL := u.String()
+	if resp.Request != nil && resp.Request.URL != nil {
+		finalURL = resp.Request.URL.String()
+	}
+	return body, finalURL, contentTypeCharset(resp.Header.Get("Content-Type")), nil
+}
+
+// contentTypeCharset returns the lower-cased charset parameter of a
+// Content-Type value when it names a supported encoding, and "" otherwise.
+func contentTypeCharset(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	charset := strings.ToLower(strings.Trim(strings.TrimSpace(params["charset"]), `"'`))
+	if charset == "" || !internal.IsSupportedEncoding(charset) {
+		return ""
+	}
+	return charset
+}
+
+// hasByteOrderMark reports whether data starts with a UTF-8, UTF-16 or UTF-32
+// byte order mark, which takes precedence over the Content-Type charset. The
+// UTF-32 LE mark is covered by the UTF-16 LE prefix.
+func hasByteOrderMark(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}) ||
+		bytes.HasPrefix(data, []byte{0x00, 0x00, 0xFE, 0xFF}) ||
+		bytes.HasPrefix(data, []byte{0xFE, 0xFF}) ||
+		bytes.HasPrefix(data, []byte{0xFF, 0xFE})
+}
+
+// fetchedBaseURL returns the base URL of a fetched document: its first
+// <base href>, resolved against finalURL, or finalURL itself. Only the head is
+// tokenized, since <base> must appear before any URL it applies to.
+func fetchedBaseURL(body []byte, finalURL string) string {
+	z := stdxhtml.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		switch tt {
+		case stdxhtml.ErrorToken:
+			return finalURL
+		case stdxhtml.StartTagToken, stdxhtml.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return finalURL
+			case "base":
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					if string(key) == "href" {
+						if href := strings.TrimSpace(string(val)); href != "" {
+							return internal.ResolveURL(finalURL, href)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// resolveExtractedURLs resolves the relative URLs of the content extracted
+// into result, images and links against BaseURL in place. URLs with a scheme,
+// such as mailto:, tel: or data: URLs, and bare fragments are left unchanged.
+func (p *Processor) resolveExtractedURLs(result *Result, images []ImageInfo, links ...[]LinkInfo) {
+	base := p.config.BaseURL
+	resolve := func(s *string) {
+		if *s != "" && (*s)[0] != '#' && !hasURLScheme(*s) {
+			*s = p.resolveURLIfEnabled(base, *s)
+		}
+	}
+	for _, list := range links {
+		for i := range list {
+			resolve(&list[i].URL)
+		}
+	}
+	for i := range images {
+		resolve(&images[i].URL)
+	}
+	for i := range result.Videos {
+		v := &result.Videos[i]
+		resolve(&v.URL)
+		resolve(&v.Poster)
+		for j := range v.Tracks {
+			resolve(&v.Tracks[j].Src)
+		}
+	}
+	for i := range result.Audios {
+		resolve(&result.Audios[i].URL)
+	}
+	resolve(&result.SocialImage)
+}
+
+// hasURLScheme reports whether s starts with a URL scheme such as "https:" or
+// "mailto:" (RFC 3986 section 3.1).
+func hasURLScheme(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		case i > 0 && c == ':':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package html_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractFromURL(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/articles/new.html", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/articles/new.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=windows-1252")
+		// "café" with é encoded as the single windows-1252 byte 0xE9.
+		_, _ = w.Write([]byte("<html><head><title>Caf\xe9</title></head><body><article>" +
+			"<p>Fresh coffee and long articles about the caf\xe9 culture of the city.</p>" +
+			`<p><a href="next.html">Next</a> <a href="mailto:editor@example.com">Mail</a></p>` +
+			`<img src="/img/cup.png" alt="cup"></article></body></html>`))
+	})
+	mux.HandleFunc("/based", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><base href="https://cdn.example.com/assets/"></head><body>` +
+			`<article><p>Some text long enough to form the article body.</p><a href="a.html">A</a></article></body></html>`))
+	})
+	mux.HandleFunc("/script", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><body><article><p>Some text long enough to form the article body.</p>` +
+			`<script>alert(1)</script></article></body></html>`))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Run("redirect and header charset", func(t *testing.T) {
+		result, err := html.ExtractFromURL(context.Background(), srv.URL+"/old")
+		if err != nil {
+			t.Fatalf("ExtractFromURL() error = %v", err)
+		}
+		if result.Title != "Café" {
+			t.Errorf("Title = %q, want %q", result.Title, "Café")
+		}
+		if !strings.Contains(result.Text, "café culture") {
+			t.Errorf("Text = %q, want decoded windows-1252", result.Text)
+		}
+		urls := make(map[string]bool)
+		for _, link := range result.Links {
+			urls[link.URL] = true
+		}
+		for _, want := range []string{srv.URL + "/articles/next.html", "mailto:editor@example.com"} {
+			if !urls[want] {
+				t.Errorf("Links = %+v, want %q", result.Links, want)
+			}
+		}
+		for _, link := range result.Links {
+			if link.IsExternal {
+				t.Errorf("%s: IsExternal = true, want false for the response host", link.URL)
+			}
+		}
+		if len(result.Images) != 1 || result.Images[0].URL != srv.URL+"/img/cup.png" {
+			t.Errorf("Images = %+v, want %q", result.Images, srv.URL+"/img/cup.png")
+		}
+	})
+
+	t.Run("base href", func(t *testing.T) {
+		result, err := html.ExtractFromURL(context.Background(), srv.URL+"/based")
+		if err != nil {
+			t.Fatalf("ExtractFromURL() error = %v", err)
+		}
+		if len(result.Links) != 1 || result.Links[0].URL != "https://cdn.example.com/assets/a.html" {
+			t.Errorf("Links = %+v, want the <base href> to apply", result.Links)
+		}
+	})
+
+	t.Run("inline formats use resolved URLs", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.InlineLinkFormat = "markdown"
+		cfg.InlineImageFormat = "markdown"
+		result, err := html.ExtractFromURL(context.Background(), srv.URL+"/old", cfg)
+		if err != nil {
+			t.Fatalf("ExtractFromURL() error = %v", err)
+		}
+		for _, want := range []string{"(" + srv.URL + "/articles/next.html)", "(" + srv.URL + "/img/cup.png)"} {
+			if !strings.Contains(result.Text, want) {
+				t.Errorf("Text = %q, want %q", result.Text, want)
+			}
+		}
+	})
+
+	t.Run("resolution disabled", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ResolveRelativeURLs = false
+		result, err := html.ExtractFromURL(context.Background(), srv.URL+"/based", cfg)
+		if err != nil {
+			t.Fatalf("ExtractFromURL() error = %v", err)
+		}
+		if len(result.Links) != 1 || result.Links[0].URL != "a.html" {
+			t.Errorf("Links = %+v, want the raw href", result.Links)
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.Audit = html.DefaultAuditConfig()
+		cfg.Audit.Enabled = true
+		p, err := html.New(cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		defer p.Close()
+		if _, err := p.ExtractFromURL(context.Background(), srv.URL+"/script"); err != nil {
+			t.Fatalf("ExtractFromURL() error = %v", err)
+		}
+		found := false
+		for _, entry := range p.GetAuditLog() {
+			if entry.EventType == html.AuditEventBlockedTag && entry.Tag == "script" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("GetAuditLog() = %+v, want the blocked <script>", p.GetAuditLog())
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		_, err := html.ExtractFromURL(context.Background(), srv.URL+"/missing")
+		if !errors.Is(err, html.ErrFetchFailed) {
+			t.Errorf("error = %v, want ErrFetchFailed", err)
+		}
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := html.ExtractFromURL(context.Background(), "file:///etc/passwd")
+		if !errors.Is(err, html.ErrFetchFailed) {
+			t.Errorf("error = %v, want ErrFetchFailed", err)
+		}
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer slow.Close()
+
+		cfg := html.DefaultConfig()
+		cfg.HTTPClient = &http.Client{Timeout: 50 * time.Millisecond}
+		_, err := html.ExtractFromURL(context.Background(), slow.URL, cfg)
+		if !errors.Is(err, html.ErrFetchFailed) {
+			t.Errorf("error = %v, want ErrFetchFailed from the client timeout", err)
+		}
+	})
+}
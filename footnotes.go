@@ -0,0 +1,161 @@
+package html
+
+// footnotes.go implements PreserveFootnotes, which turns footnote references
+// into inline markers and collects their definitions into Result.Footnotes.
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// footnoteBackrefMarkers are the link texts commonly used for "return to
+// reference" links inside footnote definitions.
+var footnoteBackrefMarkers = map[string]bool{
+	"↩": true, "↩︎": true, "↑": true, "^": true, "⤴": true,
+}
+
+// extractFootnotes rewrites footnote references under contentNode to inline
+// "[label]" markers and returns the referenced definitions in order of first
+// reference. Definitions are looked up by id across the whole document, since
+// footnote sections often sit outside the detected article node. It must run
+// before CleanContentNode so footnote containers are still in the tree.
+func (p *Processor) extractFootnotes(doc, contentNode *stdxhtml.Node) []Footnote {
+	var refs []*stdxhtml.Node
+	internal.WalkNodes(contentNode, func(n *stdxhtml.Node) bool {
+		if n.Type == stdxhtml.ElementNode && n.Data == "a" && isFootnoteRef(n) {
+			refs = append(refs, n)
+			return false
+		}
+		return true
+	})
+	if len(refs) == 0 {
+		return nil
+	}
+
+	wanted := make(map[string]*stdxhtml.Node, len(refs))
+	for _, ref := range refs {
+		wanted[footnoteRefID(ref)] = nil
+	}
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type == stdxhtml.ElementNode {
+			if id := internal.GetAttr(n, "id"); id != "" {
+				if target, ok := wanted[id]; ok && target == nil {
+					wanted[id] = n
+				}
+			}
+		}
+		return true
+	})
+
+	// numbers holds the 1-based position of each definition in footnotes, or
+	// 0 for an empty definition, which is not returned.
+	footnotes := make([]Footnote, 0, len(refs))
+	numbers := make(map[string]int, len(refs))
+	for _, ref := range refs {
+		id := footnoteRefID(ref)
+		target := wanted[id]
+		if target == nil || isAncestor(target, ref) {
+			continue
+		}
+
+		number, seen := numbers[id]
+		if !seen {
+			removeFootnoteBackrefs(target)
+			if text := strings.TrimSpace(internal.GetTextContent(target)); text != "" {
+				footnotes = append(footnotes, Footnote{ID: id, Text: text})
+				number = len(footnotes)
+			}
+			numbers[id] = number
+		}
+
+		// Unlabeled references are numbered after their definition, so that
+		// repeated references to one footnote share its number.
+		label := strings.Trim(strings.TrimSpace(internal.GetTextContent(ref)), "[]()")
+		if label == "" && number > 0 {
+			label = strconv.Itoa(number)
+		}
+		if label != "" {
+			setTextContent(ref, "["+label+"]")
+		}
+	}
+	return footnotes
+}
+
+// isFootnoteRef reports whether an <a> element is a footnote reference: an
+// in-page link that is superscripted or marked with a noteref role or class.
+func isFootnoteRef(a *stdxhtml.Node) bool {
+	href := internal.GetAttr(a, "href")
+	if len(href) < 2 || href[0] != '#' || isFootnoteBackref(a) {
+		return false
+	}
+	if a.Parent != nil && a.Parent.Type == stdxhtml.ElementNode && a.Parent.Data == "sup" {
+		return true
+	}
+	for c := a.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == stdxhtml.ElementNode && c.Data == "sup" {
+			return true
+		}
+	}
+	if role := internal.GetAttr(a, "role"); strings.EqualFold(role, "doc-noteref") {
+		return true
+	}
+	class := strings.ToLower(internal.GetAttr(a, "class"))
+	return strings.Contains(class, "footnote") || strings.Contains(class, "noteref")
+}
+
+// footnoteRefID returns the target id of a footnote reference (its href without '#').
+func footnoteRefID(a *stdxhtml.Node) string {
+	return internal.GetAttr(a, "href")[1:]
+}
+
+// removeFootnoteBackrefs detaches "return to reference" links from a footnote
+// definition so they do not leak into the footnote text.
+func removeFootnoteBackrefs(target *stdxhtml.Node) {
+	var backrefs []*stdxhtml.Node
+	internal.WalkNodes(target, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode || n.Data != "a" {
+			return true
+		}
+		if isFootnoteBackref(n) {
+			backrefs = append(backrefs, n)
+		}
+		return false
+	})
+	for _, n := range backrefs {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// isFootnoteBackref reports whether an <a> element is a "return to reference"
+// link, identified by role, class, or a typical arrow-style link text.
+func isFootnoteBackref(a *stdxhtml.Node) bool {
+	class := strings.ToLower(internal.GetAttr(a, "class"))
+	return strings.EqualFold(internal.GetAttr(a, "role"), "doc-backlink") ||
+		strings.Contains(class, "backref") || strings.Contains(class, "footnote-back") ||
+		footnoteBackrefMarkers[strings.TrimSpace(internal.GetTextContent(a))]
+}
+
+// setTextContent replaces all children of n with a single text node.
+func setTextContent(n *stdxhtml.Node, text string) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		n.RemoveChild(c)
+		c = next
+	}
+	n.AppendChild(&stdxhtml.Node{Type: stdxhtml.TextNode, Data: text})
+}
+
+// isAncestor reports whether ancestor is n or one of its ancestors.
+func isAncestor(ancestor, n *stdxhtml.Node) bool {
+	for ; n != nil; n = n.Parent {
+		if n == ancestor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+const footnotePage = `<html><body><article>
+	<p>Relativity changed physics<sup><a href="#fn1" id="ref1">1</a></sup> and the quantum
+	revolution followed<sup><a href="#fn2" id="ref2">2</a></sup>. Einstein again<sup><a href="#fn1">1</a></sup>.</p>
+	<p>` + `Some more article prose to make this the main content block of the page. ` + `</p>
+	<ol class="notes">
+		<li id="fn1">Einstein, A. (1905). Annalen der Physik. <a href="#ref1">↩</a></li>
+		<li id="fn2">Planck, M. (1900). <a href="#ref2" class="footnote-backref">back</a></li>
+	</ol>
+	<p>See <a href="#section">the section</a> for details.</p>
+</article></body></html>`
+
+func TestPreserveFootnotes(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.PreserveFootnotes = true
+	result, err := html.Extract([]byte(footnotePage), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	if !strings.Contains(result.Text, "physics[1]") || !strings.Contains(result.Text, "followed[2]") {
+		t.Errorf("inline footnote markers missing: %q", result.Text)
+	}
+	if len(result.Footnotes) != 2 {
+		t.Fatalf("got %d footnotes, want 2: %+v", len(result.Footnotes), result.Footnotes)
+	}
+	if fn := result.Footnotes[0]; fn.ID != "fn1" || fn.Text != "Einstein, A. (1905). Annalen der Physik." {
+		t.Errorf("footnote 1 = %+v", fn)
+	}
+	if fn := result.Footnotes[1]; fn.ID != "fn2" || fn.Text != "Planck, M. (1900)." {
+		t.Errorf("footnote 2 = %+v", fn)
+	}
+	if strings.Contains(result.Text, "[the section]") {
+		t.Errorf("ordinary in-page link should not become a footnote marker: %q", result.Text)
+	}
+}
+
+func TestPreserveFootnotesDisabled(t *testing.T) {
+	t.Parallel()
+
+	result, err := html.Extract([]byte(footnotePage))
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(result.Footnotes) != 0 {
+		t.Errorf("Footnotes should be empty by default, got %+v", result.Footnotes)
+	}
+	if strings.Contains(result.Text, "[1]") {
+		t.Errorf("markers should not be rewritten by default: %q", result.Text)
+	}
+}
+
+func TestPreserveFootnotesUnlabeledReferences(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><body><article>
+		<p>First claim<a href="#n1" class="footnote-ref"></a>, second claim<a href="#n2" class="footnote-ref"></a>
+		and the first claim again<a href="#n1" class="footnote-ref"></a>.</p>
+		<p>Some more article prose to make this the main content block of the page.</p>
+		<ol><li id="n1">First source.</li><li id="n2">Second source.</li></ol>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.PreserveFootnotes = true
+	result, err := html.Extract([]byte(page), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"First claim[1]", "second claim[2]", "again[1]"} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want %q", result.Text, want)
+		}
+	}
+	if len(result.Footnotes) != 2 || result.Footnotes[0].ID != "n1" || result.Footnotes[1].ID != "n2" {
+		t.Errorf("Footnotes = %+v, want n1 then n2", result.Footnotes)
+	}
+}
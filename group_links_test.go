@@ -1,6 +1,7 @@
 package html_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/cybergodev/html"
@@ -90,3 +91,102 @@ func TestGroupLinksByType(t *testing.T) {
 		}
 	})
 }
+
+func TestGroupLinksByDomain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty slice returns empty map", func(t *testing.T) {
+		if result := html.GroupLinksByDomain(nil); len(result) != 0 {
+			t.Errorf("expected empty map, got %d entries", len(result))
+		}
+	})
+
+	t.Run("groups links by host", func(t *testing.T) {
+		links := []html.LinkResource{
+			{URL: "https://Example.com/a", Type: "link"},
+			{URL: "http://example.com/b?x=1", Type: "link"},
+			{URL: "//cdn.example.com:8443/app.js", Type: "js"},
+			{URL: "https://user@other.org#top", Type: "link"},
+			{URL: "/relative/path", Type: "link"},
+			{URL: "mailto:editor@example.com", Type: "link"},
+		}
+		grouped := html.GroupLinksByDomain(links)
+		want := map[string][]string{
+			"example.com":          {"https://Example.com/a", "http://example.com/b?x=1"},
+			"cdn.example.com:8443": {"//cdn.example.com:8443/app.js"},
+			"other.org":            {"https://user@other.org#top"},
+			"":                     {"/relative/path", "mailto:editor@example.com"},
+		}
+		if len(grouped) != len(want) {
+			t.Fatalf("expected %d groups, got %d: %v", len(want), len(grouped), grouped)
+		}
+		for host, urls := range want {
+			group := grouped[host]
+			if len(group) != len(urls) {
+				t.Errorf("group %q = %v, want %v", host, group, urls)
+				continue
+			}
+			for i, u := range urls {
+				if group[i].URL != u {
+					t.Errorf("group %q[%d] = %q, want %q", host, i, group[i].URL, u)
+				}
+			}
+		}
+	})
+}
+
+func TestFilterLinks(t *testing.T) {
+	t.Parallel()
+
+	links := []html.LinkResource{
+		{URL: "https://example.com/logo.png", Type: "image"},
+		{URL: "https://cdn.net/app.js", Type: "js"},
+		{URL: "https://EXAMPLE.com/style.css", Type: "css"},
+		{URL: "/about", Type: "link"},
+		{URL: "https://other.org/photo.jpg", Type: "image"},
+	}
+	urls := func(links []html.LinkResource) []string {
+		var out []string
+		for _, l := range links {
+			out = append(out, l.URL)
+		}
+		return out
+	}
+
+	if got := urls(html.FilterLinks(links, html.LinkIsImage)); !reflect.DeepEqual(got, []string{"https://example.com/logo.png", "https://other.org/photo.jpg"}) {
+		t.Errorf("LinkIsImage = %v", got)
+	}
+	if got := urls(html.FilterLinks(links, html.LinkHasType("css", "js"))); !reflect.DeepEqual(got, []string{"https://cdn.net/app.js", "https://EXAMPLE.com/style.css"}) {
+		t.Errorf("LinkHasType = %v", got)
+	}
+	// Hosts compare case-sensitively, as LinkInfo.IsExternal does by default.
+	external := html.FilterLinks(links, html.LinkIsExternal("https://example.com/"))
+	if got := urls(external); !reflect.DeepEqual(got, []string{"https://cdn.net/app.js", "https://EXAMPLE.com/style.css", "https://other.org/photo.jpg"}) {
+		t.Errorf("LinkIsExternal = %v", got)
+	}
+	if got := urls(html.FilterLinks(external, html.LinkIsImage)); !reflect.DeepEqual(got, []string{"https://other.org/photo.jpg"}) {
+		t.Errorf("combined filters = %v", got)
+	}
+	if got := html.FilterLinks(links, html.LinkIsExternal("")); len(got) != 4 {
+		t.Errorf("LinkIsExternal without base = %v, want every absolute link", urls(got))
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.BaseURL = "https://example.com/"
+	result, err := html.Extract([]byte(`<html><body><article><p>Links to
+		<a href="/about">about</a>, <a href="https://EXAMPLE.com/a">a</a>,
+		<a href="https://user@example.com/b">b</a>, <a href="https://example.com?q=1">q</a>
+		and <a href="https://cdn.net/c">c</a>.</p></article></body></html>`), cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	isExternal := html.LinkIsExternal(cfg.BaseURL)
+	for _, link := range result.Links {
+		if got := isExternal(html.LinkResource{URL: link.URL}); got != link.IsExternal {
+			t.Errorf("LinkIsExternal(%q) = %v, LinkInfo.IsExternal = %v", link.URL, got, link.IsExternal)
+		}
+	}
+	if got := html.FilterLinks(nil, html.LinkIsImage); len(got) != 0 {
+		t.Errorf("FilterLinks(nil) = %v, want none", got)
+	}
+}
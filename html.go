@@ -1,7 +1,7 @@
 // Package html provides HTML content extraction with automatic encoding detection.
 //
 // This library extracts clean text, links, images, videos, and audio from HTML documents
-// with automatic character encoding detection (supporting 15+ encodings including UTF-8,
+// with automatic character encoding detection (supporting 30+ encodings including UTF-8,
 // Windows-1252, GBK, Shift_JIS, etc.).
 //
 // Basic usage:
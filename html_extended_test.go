@@ -264,3 +264,33 @@ func TestMaxDepthBoundaryConditions(t *testing.T) {
 		}
 	})
 }
+
+// TestFoldAccentsClassMatching verifies that FoldAccents lets accented class
+// names match the built-in non-content patterns.
+func TestFoldAccentsClassMatching(t *testing.T) {
+	t.Parallel()
+
+	page := []byte(`<html><body><article><p>` + strings.Repeat("Main article prose. ", 20) + `</p>
+		<div class="commént">Spam from the comment section</div></article></body></html>`)
+
+	result, err := html.Extract(page)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if !strings.Contains(result.Text, "Spam from the comment section") {
+		t.Error("without folding, the accented class should not be removed")
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.FoldAccents = true
+	result, err = html.Extract(page, cfg)
+	if err != nil {
+		t.Fatalf("Extract() with FoldAccents failed: %v", err)
+	}
+	if strings.Contains(result.Text, "Spam from the comment section") {
+		t.Errorf("with FoldAccents, the accented comment class should be removed: %q", result.Text)
+	}
+	if !strings.Contains(result.Text, "Main article prose.") {
+		t.Errorf("article text missing: %q", result.Text)
+	}
+}
@@ -343,7 +343,7 @@ func TestInputValidation(t *testing.T) {
 
 		deepHTML := "<div>" + strings.Repeat("<div>", 10) + "content" + strings.Repeat("</div>", 10) + "</div>"
 		_, err := p.Extract([]byte(deepHTML))
-		if err != html.ErrMaxDepthExceeded {
+		if !errors.Is(err, html.ErrMaxDepthExceeded) {
 			t.Errorf("Expected ErrMaxDepthExceeded, got: %v", err)
 		}
 	})
@@ -769,6 +769,62 @@ func TestLinkExtraction(t *testing.T) {
 			t.Errorf("Got %d links, want 0", len(result.Links))
 		}
 	})
+
+	t.Run("same-domain absolute links are internal", func(t *testing.T) {
+		htmlContent := `<html><body>
+			<a href="https://example.com/about">About</a>
+			<a href="/contact">Contact</a>
+			<a href="https://other.org/">Other</a>
+		</body></html>`
+		cfg := html.DefaultConfig()
+		cfg.BaseURL = "https://example.com/blog/"
+		result, err := html.Extract([]byte(htmlContent), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		want := map[string]bool{"https://example.com/about": false, "/contact": false, "https://other.org/": true}
+		if len(result.Links) != len(want) {
+			t.Fatalf("Got %d links, want %d", len(result.Links), len(want))
+		}
+		for _, link := range result.Links {
+			if link.IsExternal != want[link.URL] {
+				t.Errorf("%s: IsExternal = %v, want %v", link.URL, link.IsExternal, want[link.URL])
+			}
+		}
+	})
+
+	t.Run("canonical link sets the document host", func(t *testing.T) {
+		htmlContent := `<html><head><link rel="canonical" href="https://example.com/post"></head><body>
+			<a href="https://example.com/about">About</a>
+			<a href="https://other.org/">Other</a>
+		</body></html>`
+		result, err := p.Extract([]byte(htmlContent))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if len(result.Links) != 2 {
+			t.Fatalf("Got %d links, want 2", len(result.Links))
+		}
+		if result.Links[0].IsExternal || !result.Links[1].IsExternal {
+			t.Errorf("IsExternal = %v, %v; want false, true", result.Links[0].IsExternal, result.Links[1].IsExternal)
+		}
+
+		cfg := html.DefaultConfig()
+		cfg.IncludeExternalLinks = false
+		cfg.IncludeCSS = false
+		cfg.IncludeIcons = false
+		links, err := html.ExtractAllLinks([]byte(htmlContent), cfg)
+		if err != nil {
+			t.Fatalf("ExtractAllLinks() failed: %v", err)
+		}
+		var urls []string
+		for _, link := range links {
+			urls = append(urls, link.URL)
+		}
+		if len(urls) != 1 || urls[0] != "https://example.com/about" {
+			t.Errorf("ExtractAllLinks() = %v, want only the same-domain link", urls)
+		}
+	})
 }
 
 // ============================================================================
@@ -1956,6 +2012,46 @@ func TestExtractAllLinks(t *testing.T) {
 		}
 	})
 
+	t.Run("original href kept alongside resolved URL", func(t *testing.T) {
+		htmlContent := `<html><body>
+			<a href="/docs/page.html">Docs</a>
+			<img src="img/a.png" alt="A">
+			<a href="https://other.example.org/x">Other</a>
+		</body></html>`
+
+		cfg := html.DefaultConfig()
+		cfg.BaseURL = "https://example.com/blog/"
+		links, err := html.ExtractAllLinks([]byte(htmlContent), cfg)
+		if err != nil {
+			t.Fatalf("ExtractAllLinks() failed: %v", err)
+		}
+		want := map[string]string{
+			"https://example.com/docs/page.html": "/docs/page.html",
+			"https://example.com/blog/img/a.png": "img/a.png",
+			"https://other.example.org/x":        "https://other.example.org/x",
+		}
+		for _, link := range links {
+			if original, ok := want[link.URL]; ok && link.Original != original {
+				t.Errorf("Original for %q = %q, want %q", link.URL, link.Original, original)
+			}
+			delete(want, link.URL)
+		}
+		if len(want) > 0 {
+			t.Errorf("missing links: %v", want)
+		}
+
+		cfg.ResolveRelativeURLs = false
+		links, err = html.ExtractAllLinks([]byte(htmlContent), cfg)
+		if err != nil {
+			t.Fatalf("ExtractAllLinks() failed: %v", err)
+		}
+		for _, link := range links {
+			if link.Original != link.URL {
+				t.Errorf("Original = %q, want URL %q with resolution disabled", link.Original, link.URL)
+			}
+		}
+	})
+
 	t.Run("relative URL resolution with manual base URL", func(t *testing.T) {
 		htmlContent := `
 			<html><body>
@@ -4050,6 +4146,65 @@ func TestBaseURLDetection(t *testing.T) {
 		}
 	})
 
+	t.Run("first base target is recorded", func(t *testing.T) {
+		htmlContent := `<html><head><base href="https://example.com/"><base target=" _blank "><base target="_top"></head><body><p>Text</p></body></html>`
+		result, err := html.Extract([]byte(htmlContent))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.BaseTarget != "_blank" {
+			t.Errorf("BaseTarget = %q, want %q", result.BaseTarget, "_blank")
+		}
+		meta, err := html.ExtractMetadataOnly(htmlContent)
+		if err != nil {
+			t.Fatalf("ExtractMetadataOnly() failed: %v", err)
+		}
+		if meta.BaseTarget != "_blank" {
+			t.Errorf("Metadata.BaseTarget = %q, want %q", meta.BaseTarget, "_blank")
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("json.Marshal() failed: %v", err)
+		}
+		if !strings.Contains(string(data), `"base_target":"_blank"`) {
+			t.Errorf("JSON = %s, want base_target", data)
+		}
+	})
+
+	t.Run("first base href wins", func(t *testing.T) {
+		tests := []struct {
+			name string
+			head string
+			want string
+		}{
+			{
+				name: "two base tags",
+				head: `<base href="https://first.example/"><base href="https://second.example/">`,
+				want: "https://first.example/sitemap.xml",
+			},
+			{
+				name: "target-only base before href base",
+				head: `<base target="_blank"><base href="https://first.example/">`,
+				want: "https://first.example/sitemap.xml",
+			},
+			{
+				name: "target-only base falls back to canonical",
+				head: `<base target="_top"><link rel="canonical" href="https://canonical.example/post">`,
+				want: "https://canonical.example/sitemap.xml",
+			},
+		}
+		for _, tt := range tests {
+			htmlContent := `<html><head>` + tt.head + `<link rel="sitemap" href="/sitemap.xml"></head><body><p>Text</p></body></html>`
+			result, err := html.Extract([]byte(htmlContent))
+			if err != nil {
+				t.Fatalf("%s: Extract() failed: %v", tt.name, err)
+			}
+			if result.SitemapURL != tt.want {
+				t.Errorf("%s: SitemapURL = %q, want %q", tt.name, result.SitemapURL, tt.want)
+			}
+		}
+	})
+
 	t.Run("extract domain from URL", func(t *testing.T) {
 		// This tests domain extraction for different link types
 		htmlContent := `
@@ -4319,6 +4474,33 @@ func TestImageExtractionEdgeCases(t *testing.T) {
 		}
 	})
 
+	t.Run("img dimensions from inline style", func(t *testing.T) {
+		p, _ := html.New()
+		defer p.Close()
+
+		htmlContent := `
+			<html><body>
+				<img src="styled.jpg" alt="Styled" style="width:640px;height:480px">
+				<img src="mixed.jpg" alt="Mixed" width="300" style="width:999px; height: 200px">
+			</body></html>
+		`
+
+		result, err := p.Extract([]byte(htmlContent))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if len(result.Images) != 2 {
+			t.Fatalf("Got %d images, want 2", len(result.Images))
+		}
+		if img := result.Images[0]; img.Width != "640" || img.Height != "480" {
+			t.Errorf("style-only image dimensions = %sx%s, want 640x480", img.Width, img.Height)
+		}
+		// The width attribute takes precedence; style fills only the missing height.
+		if img := result.Images[1]; img.Width != "300" || img.Height != "200" {
+			t.Errorf("mixed image dimensions = %sx%s, want 300x200", img.Width, img.Height)
+		}
+	})
+
 	t.Run("img with all attributes", func(t *testing.T) {
 		p, _ := html.New()
 		defer p.Close()
@@ -0,0 +1,449 @@
+package html_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestDeduplicateImages(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head><base href="https://example.com/"></head><body>
+		<img src="/logo.png">
+		<p>Gallery intro text.</p>
+		<img src="https://example.com/photos/a.jpg?w=300&amp;h=200" alt="">
+		<img src="photos/b.jpg" alt="Second photo">
+		<img src="/photos/a.jpg?w=1200&amp;quality=80#zoom" alt="First photo">
+		<img src="photos/a.jpg?v=2" alt="Other version">
+		<img src="https://example.com/logo.png" alt="Site logo">
+	</body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+
+	all, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(all.Images) != 6 {
+		t.Fatalf("Images = %d without dedup, want 6", len(all.Images))
+	}
+
+	cfg.DeduplicateImages = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []struct {
+		url      string
+		alt      string
+		position int
+	}{
+		{"/logo.png", "Site logo", 1},
+		{"https://example.com/photos/a.jpg?w=300&h=200", "First photo", 2},
+		{"photos/b.jpg", "Second photo", 3},
+		{"photos/a.jpg?v=2", "Other version", 5},
+	}
+	if len(result.Images) != len(want) {
+		t.Fatalf("Images = %+v, want %d unique", result.Images, len(want))
+	}
+	for i, w := range want {
+		img := result.Images[i]
+		if img.URL != w.url || img.Alt != w.alt || img.Position != w.position || img.IsDecorative {
+			t.Errorf("Images[%d] = %+v, want URL %q, Alt %q, Position %d", i, img, w.url, w.alt, w.position)
+		}
+	}
+}
+
+func TestSkipDecorativeImages(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<p>Intro text.</p>
+		<img src="/spacer.gif" alt="">
+		<img src="/chart.png" alt="Sales chart">
+		<img src="/divider.png">
+		<img src="/team.jpg" alt="The team">
+	</body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.InlineImageFormat = "markdown"
+	cfg.SkipDecorativeImages = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := map[string]int{"/chart.png": 2, "/team.jpg": 4}
+	if len(result.Images) != len(want) {
+		t.Fatalf("Images = %+v, want the 2 with alt text", result.Images)
+	}
+	for _, img := range result.Images {
+		if want[img.URL] != img.Position {
+			t.Errorf("image %q Position = %d, want %d", img.URL, img.Position, want[img.URL])
+		}
+	}
+	chart := strings.Index(result.Text, "![Sales chart](/chart.png)")
+	team := strings.Index(result.Text, "![The team](/team.jpg)")
+	if chart < 0 || team < chart {
+		t.Errorf("Text = %q, want the remaining images inline in order", result.Text)
+	}
+
+	cfg.InlineImageFormat = "placeholder"
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, img := range result.Images {
+		if !strings.Contains(result.Text, fmt.Sprintf("[IMAGE:%d]", img.Position)) {
+			t.Errorf("Text = %q, want a placeholder for image %d", result.Text, img.Position)
+		}
+	}
+}
+
+func TestResolveNoscriptImages(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>Intro paragraph.</p>
+		<img src="data:image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7" data-src="/real.jpg" class="lazy">
+		<noscript><img src="https://example.com/real.jpg" alt="Real photo" onerror="alert(1)"></noscript>
+		<p>Closing paragraph.</p>
+		<noscript><p>Enable JavaScript</p></noscript>
+	</article></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+
+	without, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, img := range without.Images {
+		if img.URL == "https://example.com/real.jpg" {
+			t.Fatalf("noscript image extracted with ResolveNoscriptImages disabled")
+		}
+	}
+
+	cfg.ResolveNoscriptImages = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(result.Images) != 1 {
+		t.Fatalf("Images = %+v, want only the noscript fallback", result.Images)
+	}
+	if got := result.Images[0]; got.URL != "https://example.com/real.jpg" || got.Alt != "Real photo" {
+		t.Errorf("Images[0] = %+v, want the noscript image", got)
+	}
+	if strings.Contains(result.Text, "Enable JavaScript") {
+		t.Errorf("Text = %q, noscript text should stay excluded", result.Text)
+	}
+}
+
+func TestMaxMediaLimits(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	b.WriteString("<html><body><article><p>A gallery page with many media elements.</p>")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&b, `<img src="/img/%d.png" alt="image %d">`, i, i)
+		fmt.Fprintf(&b, `<video src="/video/%d.mp4"></video>`, i)
+		fmt.Fprintf(&b, `<audio src="/audio/%d.mp3"></audio>`, i)
+	}
+	b.WriteString("</article></body></html>")
+	doc := []byte(b.String())
+
+	cfg := html.DefaultConfig()
+	cfg.MaxImages = 3
+	cfg.MaxVideos = 2
+	cfg.MaxAudios = 1
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Images) != 3 || result.Images[0].URL != "/img/0.png" || result.Images[2].URL != "/img/2.png" {
+		t.Errorf("Images = %+v, want the first 3", result.Images)
+	}
+	if len(result.Videos) != 2 || result.Videos[0].URL != "/video/0.mp4" {
+		t.Errorf("Videos = %+v, want the first 2", result.Videos)
+	}
+	if len(result.Audios) != 1 || result.Audios[0].URL != "/audio/0.mp3" {
+		t.Errorf("Audios = %+v, want the first 1", result.Audios)
+	}
+
+	unlimited, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(unlimited.Images) != 20 || len(unlimited.Videos) != 20 || len(unlimited.Audios) != 20 {
+		t.Errorf("got %d images, %d videos, %d audios; want 20 each by default",
+			len(unlimited.Images), len(unlimited.Videos), len(unlimited.Audios))
+	}
+
+	cfg = html.DefaultConfig()
+	cfg.MaxImages = 1
+	page := []byte(`<html><body><article><p>Three pictures of the harbour at dawn.</p>
+		<p><img src="/a.png" alt="first"></p><p><img src="/b.png" alt="second"></p>
+		<p><img src="/c.png" alt="third"></p><p>The end of the gallery.</p></article></body></html>`)
+	for _, format := range []string{"markdown", "html", "placeholder"} {
+		cfg.InlineImageFormat = format
+		result, err := html.Extract(page, cfg)
+		if err != nil {
+			t.Fatalf("Extract(%s) error = %v", format, err)
+		}
+		if strings.Contains(result.Text, "[IMAGE:2]") || strings.Contains(result.Text, "[IMAGE:3]") {
+			t.Errorf("%s Text = %q, want no placeholders past MaxImages", format, result.Text)
+		}
+		if !strings.Contains(result.Text, "/a.png") && !strings.Contains(result.Text, "[IMAGE:1]") {
+			t.Errorf("%s Text = %q, want the first image inline", format, result.Text)
+		}
+		if !strings.HasSuffix(result.Text, "The end of the gallery.") {
+			t.Errorf("%s Text = %q, want the closing paragraph last", format, result.Text)
+		}
+	}
+
+	cfg = html.DefaultConfig()
+	cfg.MaxImages = -1
+	if _, err := html.New(cfg); !errors.Is(err, html.ErrInvalidConfig) {
+		t.Errorf("New(MaxImages=-1) error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestIncludeImageAltInText(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article><p>Intro paragraph text that is long enough to be the article.</p>
+		<figure><img src="fox.png" alt="A red fox"><figcaption>Fox in the snow</figcaption></figure>
+		<figure><img src="owl.png" alt="Snowy owl"><figcaption>Snowy owl</figcaption></figure>
+		<p>Press <img src="gear.png" alt="the gear icon"> to open settings.
+		<img src="spacer.gif" alt="spacer" role="presentation"></p></article></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeImageAltInText = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, want := range []string{"A red fox", "Fox in the snow", "Press the gear icon to open settings."} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, want it to contain %q", result.Text, want)
+		}
+	}
+	if n := strings.Count(result.Text, "Snowy owl"); n != 1 {
+		t.Errorf("Text contains the caption-matching alt %d times, want 1: %q", n, result.Text)
+	}
+	if strings.Contains(result.Text, "spacer") {
+		t.Errorf("Text = %q, want presentational images skipped", result.Text)
+	}
+
+	plain, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if strings.Contains(plain.Text, "A red fox") {
+		t.Errorf("default Text = %q, want no alt text", plain.Text)
+	}
+}
+
+func TestMainImage(t *testing.T) {
+	t.Parallel()
+
+	const body = `<article><p>Intro paragraph text that is long enough to be the article.</p>
+		<img src="/pixel.gif" width="1" height="1" alt="">
+		<img src="/thumb.jpg" width="120" height="80" alt="thumb">
+		<img src="/hero.jpg" width="1200" height="600" alt="hero">
+		</article>`
+	tests := []struct {
+		name string
+		head string
+		body string
+		want string
+	}{
+		{"og:image wins", `<meta property="og:image" content="https://cdn.example.com/og.jpg">`, body, "https://cdn.example.com/og.jpg"},
+		{"twitter:image fallback", `<meta name="twitter:image" content="https://cdn.example.com/tw.jpg">`, body, "https://cdn.example.com/tw.jpg"},
+		{"first large content image", ``, body, "https://example.com/hero.jpg"},
+		{"first content image", ``, `<article><p>Intro paragraph text that is long enough to be the article.</p>
+			<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" alt=""><img src="/a.jpg"><img src="/b.jpg"></article>`, "https://example.com/a.jpg"},
+		{"no images", ``, `<article><p>Only text here.</p></article>`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := html.DefaultConfig()
+			cfg.BaseURL = "https://example.com/"
+			cfg.PreserveImages = false
+			doc := "<html><head>" + tt.head + "</head><body>" + tt.body + "</body></html>"
+			result, err := html.Extract([]byte(doc), cfg)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if result.MainImage != tt.want {
+				t.Errorf("MainImage = %q, want %q", result.MainImage, tt.want)
+			}
+		})
+	}
+
+	t.Run("relative og:image against base href", func(t *testing.T) {
+		doc := `<html><head><base href="https://cdn.example.com/">
+			<meta property="og:image" content="img/a.png"></head><body>` + body + `</body></html>`
+		result, err := html.Extract([]byte(doc))
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if result.MainImage != "https://cdn.example.com/img/a.png" {
+			t.Errorf("MainImage = %q, want the og:image resolved against <base href>", result.MainImage)
+		}
+		if result.SocialImage != "img/a.png" {
+			t.Errorf("SocialImage = %q, want it as written", result.SocialImage)
+		}
+	})
+}
+
+func TestIncludeInlineSVG(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>Quarterly revenue is shown in the chart below.</p>
+		<svg width="120" height="80" viewBox="0 0 120 80" onload="alert(1)">
+			<title>Revenue chart</title>
+			<script>alert(2)</script>
+			<a href="javascript:alert(3)"><rect width="10" height="10"/></a>
+			<foreignObject><div>hidden html</div></foreignObject>
+			<svg><circle r="4"/></svg>
+		</svg>
+		<svg aria-label="Menu icon"><use href="#icon-menu"/></svg>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(result.Images) != 0 {
+		t.Fatalf("Images without IncludeInlineSVG = %+v, want none", result.Images)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeInlineSVG = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(result.Images) != 2 {
+		t.Fatalf("Images = %+v, want 2 outermost SVGs", result.Images)
+	}
+
+	chart := result.Images[0]
+	if chart.Alt != "Revenue chart" || chart.Width != "120" || chart.Height != "80" || chart.MimeType != "image/svg+xml" {
+		t.Errorf("chart = %+v, want alt, size and SVG MimeType", chart)
+	}
+	const prefix = "data:image/svg+xml;base64,"
+	if !strings.HasPrefix(chart.URL, prefix) {
+		t.Fatalf("chart URL = %q, want an SVG data URL", chart.URL)
+	}
+	markup, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(chart.URL, prefix))
+	if err != nil {
+		t.Fatalf("decoding chart URL: %v", err)
+	}
+	svg := string(markup)
+	for _, want := range []string{`xmlns="http://www.w3.org/2000/svg"`, "<rect", "<circle", "<title>Revenue chart</title>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG %q missing %q", svg, want)
+		}
+	}
+	for _, unwanted := range []string{"alert", "onload", "foreignObject", "hidden html"} {
+		if strings.Contains(svg, unwanted) {
+			t.Errorf("SVG %q still contains %q", svg, unwanted)
+		}
+	}
+
+	if icon := result.Images[1]; icon.Alt != "Menu icon" || icon.Position != 2 {
+		t.Errorf("icon = %+v, want aria-label alt at position 2", icon)
+	}
+	if strings.Contains(result.Text, "hidden html") {
+		t.Errorf("Text = %q, want SVG content left out", result.Text)
+	}
+}
+
+func TestIncludeInlineSVGScrubsExternalReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		svg      string
+		want     string
+		unwanted []string
+	}{
+		{
+			name:     "style element",
+			svg:      `<style>@import url(https://evil.example/x.css); rect { fill: url(https://evil.example/p.svg#a) }</style><rect width="1" height="1"/>`,
+			want:     "<rect",
+			unwanted: []string{"evil.example", "<style"},
+		},
+		{
+			name:     "external paint url",
+			svg:      `<rect width="1" height="1" fill="url(https://evil.example/p.svg#a)" stroke="url( '#grad' )"/>`,
+			want:     `stroke="url( &#39;#grad&#39; )"`,
+			unwanted: []string{"evil.example"},
+		},
+		{
+			name:     "style attribute",
+			svg:      `<rect width="1" height="1" style="fill:url(https://evil.example/p.svg#a)"/>`,
+			want:     "<rect",
+			unwanted: []string{"evil.example", "style="},
+		},
+		{
+			name:     "set targeting href",
+			svg:      `<a href="#x"><set attributeName="href" to="javascript:alert(1)"/><rect width="1" height="1"/></a>`,
+			want:     "<rect",
+			unwanted: []string{"javascript", "<set", "attributeName"},
+		},
+		{
+			name:     "animate targeting href",
+			svg:      `<a><animate attributeName="href" values="javascript:alert(1)"/><circle r="1"/></a>`,
+			want:     "<circle",
+			unwanted: []string{"javascript", "<animate", "attributeName"},
+		},
+		{
+			name:     "external image and use",
+			svg:      `<image href="https://evil.example/i.png"/><use xlink:href="https://evil.example/s.svg#a"/><use xlink:href="#local"/>`,
+			want:     `xlink:href="#local"`,
+			unwanted: []string{"evil.example", "<image"},
+		},
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeInlineSVG = true
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := []byte(`<html><body><article><p>A chart of the quarterly figures.</p><svg width="10" height="10">` +
+				tt.svg + `</svg></article></body></html>`)
+			result, err := html.Extract(doc, cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if len(result.Images) != 1 {
+				t.Fatalf("Images = %+v, want 1", result.Images)
+			}
+			markup, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(result.Images[0].URL, "data:image/svg+xml;base64,"))
+			if err != nil {
+				t.Fatalf("decoding SVG URL: %v", err)
+			}
+			svg := string(markup)
+			if !strings.Contains(svg, tt.want) {
+				t.Errorf("SVG %q missing %q", svg, tt.want)
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(svg, unwanted) {
+					t.Errorf("SVG %q still contains %q", svg, unwanted)
+				}
+			}
+		})
+	}
+}
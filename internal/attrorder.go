@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// formattingElements are the elements whose attributes the parser sorts, to
+// compare them by value when reconstructing active formatting elements.
+var formattingElements = map[string]bool{
+	"a": true, "b": true, "big": true, "code": true, "em": true, "font": true, "i": true,
+	"nobr": true, "s": true, "small": true, "strike": true, "strong": true, "tt": true, "u": true,
+}
+
+// RestoreAttributeOrder puts the attributes of the formatting elements of doc,
+// parsed from htmlContent, back in their source order, which html.Parse
+// discards by sorting them. It tokenizes htmlContent once more and matches
+// start tags to elements by their set of attributes: elements sharing one set
+// take the orders of the matching tags in turn, and elements the parser cloned
+// reuse the last. Names stay lower-cased, as the tokenizer reports them.
+func RestoreAttributeOrder(htmlContent string, doc *html.Node) {
+	orders := make(map[string][][]html.Attribute)
+	z := html.NewTokenizer(strings.NewReader(htmlContent))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		tok := z.Token()
+		if !formattingElements[tok.Data] || len(tok.Attr) < 2 {
+			continue
+		}
+		key := attributeSetKey(tok.Attr)
+		orders[key] = append(orders[key], tok.Attr)
+	}
+	if len(orders) == 0 {
+		return
+	}
+
+	WalkNodes(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.Namespace != "" || !formattingElements[n.Data] || len(n.Attr) < 2 {
+			return true
+		}
+		key := attributeSetKey(n.Attr)
+		queue := orders[key]
+		if len(queue) == 0 {
+			return true
+		}
+		copy(n.Attr, queue[0])
+		if len(queue) > 1 {
+			orders[key] = queue[1:]
+		}
+		return true
+	})
+}
+
+// attributeSetKey identifies attrs regardless of their order.
+func attributeSetKey(attrs []html.Attribute) string {
+	sorted := slices.Clone(attrs)
+	slices.SortFunc(sorted, func(a, b html.Attribute) int {
+		if c := strings.Compare(a.Key, b.Key); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Val, b.Val)
+	})
+	var sb strings.Builder
+	for _, attr := range sorted {
+		sb.WriteString(attr.Key)
+		sb.WriteByte(0)
+		sb.WriteString(attr.Val)
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
@@ -52,6 +52,7 @@ type Cache[K comparable] struct {
 type cacheEntry[K comparable] struct {
 	prev, next *cacheEntry[K]
 	lastUsed   int64
+	storedAt   int64
 	expiresAt  int64
 	value      any
 	key        K
@@ -127,11 +128,14 @@ func (c *Cache[K]) Get(key K) any {
 }
 
 func (c *Cache[K]) Set(key K, value any) {
-	if value == nil || cacheKeyZero(key) || c.maxEntries == 0 {
+	if value == nil || cacheKeyZero(key) {
 		return
 	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.maxEntries == 0 {
+		return
+	}
 
 	now := time.Now().UnixNano()
 
@@ -139,6 +143,7 @@ func (c *Cache[K]) Set(key K, value any) {
 	if entry, exists := c.entries[key]; exists {
 		entry.value = value
 		entry.lastUsed = now
+		entry.storedAt = now
 		if c.ttl > 0 {
 			entry.expiresAt = now + c.ttl.Nanoseconds()
 		}
@@ -155,6 +160,7 @@ func (c *Cache[K]) Set(key K, value any) {
 	entry := &cacheEntry[K]{
 		value:     value,
 		lastUsed:  now,
+		storedAt:  now,
 		key:       key,
 		expiresAt: 0,
 	}
@@ -236,6 +242,65 @@ func (c *Cache[K]) Clear() {
 	c.tail.prev = c.head
 }
 
+// SetTTL changes the TTL, with the same rules as NewCache, and applies it to
+// the stored entries too, counting from when each was stored: shortening it
+// expires entries stored longer ago than ttl, and 0 makes them never expire.
+func (c *Cache[K]) SetTTL(ttl time.Duration) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	for _, entry := range c.entries {
+		entry.expiresAt = 0
+		if ttl > 0 {
+			entry.expiresAt = entry.storedAt + ttl.Nanoseconds()
+		}
+	}
+}
+
+// TTL returns the current TTL; 0 means entries never expire based on time.
+func (c *Cache[K]) TTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ttl
+}
+
+// SetMaxEntries changes the maximum number of entries, with the same rules as
+// NewCache, evicting expired and then least recently used entries until the
+// cache fits. 0 disables the cache and removes every entry.
+func (c *Cache[K]) SetMaxEntries(maxEntries int) {
+	if maxEntries < 0 {
+		maxEntries = 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxEntries = maxEntries
+	if len(c.entries) <= maxEntries {
+		return
+	}
+	now := time.Now().UnixNano()
+	for key, entry := range c.entries {
+		if entry.isExpired(now) {
+			c.removeNode(entry)
+			delete(c.entries, key)
+		}
+	}
+	for len(c.entries) > maxEntries {
+		lruEntry := c.tail.prev
+		c.removeNode(lruEntry)
+		delete(c.entries, lruEntry.key)
+	}
+}
+
+// MaxEntries returns the current maximum number of entries; 0 means the cache is disabled.
+func (c *Cache[K]) MaxEntries() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.maxEntries
+}
+
 // StartCleanup starts a background goroutine that periodically cleans up expired entries.
 // This is useful when TTL is enabled and the cache receives many one-time accesses,
 // as expired entries would otherwise only be cleaned when accessed or during eviction.
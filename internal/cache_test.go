@@ -92,6 +92,62 @@ func TestCacheNoTTL(t *testing.T) {
 	}
 }
 
+func TestCacheSetTTL(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache[string](10, 0)
+	cache.Set("old", "value")
+	time.Sleep(5 * time.Millisecond)
+
+	cache.SetTTL(time.Millisecond)
+	if cache.TTL() != time.Millisecond {
+		t.Errorf("TTL() = %v, want 1ms", cache.TTL())
+	}
+	if cache.Get("old") != nil {
+		t.Error("entry stored before a shorter TTL should expire")
+	}
+
+	cache.Set("new", "value")
+	cache.SetTTL(0)
+	time.Sleep(5 * time.Millisecond)
+	if cache.Get("new") == nil {
+		t.Error("entry should never expire after SetTTL(0)")
+	}
+
+	cache.SetTTL(-time.Second)
+	if cache.TTL() != 0 {
+		t.Errorf("TTL() = %v after a negative TTL, want 0", cache.TTL())
+	}
+}
+
+func TestCacheSetMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache[string](10, 0)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		cache.Set(key, key)
+	}
+	cache.Get("a") // most recently used
+
+	cache.SetMaxEntries(2)
+	if cache.MaxEntries() != 2 || cache.Len() != 2 {
+		t.Fatalf("MaxEntries() = %d, Len() = %d, want 2 and 2", cache.MaxEntries(), cache.Len())
+	}
+	if cache.Get("a") == nil || cache.Get("d") == nil {
+		t.Error("the most recently used entries should be kept")
+	}
+	cache.Set("e", "e")
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d after Set, want the new limit 2", cache.Len())
+	}
+
+	cache.SetMaxEntries(0)
+	cache.Set("f", "f")
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 once disabled", cache.Len())
+	}
+}
+
 func TestCacheEviction(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,64 @@
+// dataurl.go parses data: URLs and reads the dimensions of inline images.
+package internal
+
+import (
+	"encoding/base64"
+	"image"
+	_ "image/gif"  // registers GIF for DataURLImageSize
+	_ "image/jpeg" // registers JPEG for DataURLImageSize
+	_ "image/png"  // registers PNG for DataURLImageSize
+	"strings"
+)
+
+// maxDataURLHeaderBytes bounds how much encoded payload DataURLImageSize
+// reads. Image headers sit at the start of the file, except for JPEG, whose
+// frame header may follow EXIF and other metadata segments.
+const maxDataURLHeaderBytes = 64 * 1024
+
+// ParseDataURL splits a data: URL into its lower-cased MIME type, whether the
+// payload is base64 encoded, and the raw payload. ok is false when url is not
+// a well-formed data URL. An omitted MIME type is reported as "text/plain", as
+// RFC 2397 specifies.
+func ParseDataURL(url string) (mimeType string, isBase64 bool, payload string, ok bool) {
+	if len(url) < 5 || !strings.EqualFold(url[:5], "data:") {
+		return "", false, "", false
+	}
+	header, payload, ok := strings.Cut(url[5:], ",")
+	if !ok {
+		return "", false, "", false
+	}
+	params := strings.Split(header, ";")
+	mimeType = strings.ToLower(strings.TrimSpace(params[0]))
+	for _, param := range params[1:] {
+		if strings.EqualFold(strings.TrimSpace(param), "base64") {
+			isBase64 = true
+		}
+	}
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	return mimeType, isBase64, payload, true
+}
+
+// DataURLImageSize decodes the header of a base64 PNG, GIF or JPEG data URL
+// and returns its pixel dimensions. ok is false for other formats and for
+// payloads whose header cannot be read within the first 64KB.
+func DataURLImageSize(url string) (width, height int, ok bool) {
+	mimeType, isBase64, payload, valid := ParseDataURL(url)
+	if !valid || !isBase64 {
+		return 0, 0, false
+	}
+	switch mimeType {
+	case "image/png", "image/gif", "image/jpeg", "image/jpg":
+	default:
+		return 0, 0, false
+	}
+	if len(payload) > maxDataURLHeaderBytes {
+		payload = payload[:maxDataURLHeaderBytes]
+	}
+	cfg, _, err := image.DecodeConfig(base64.NewDecoder(base64.StdEncoding, strings.NewReader(payload)))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
@@ -0,0 +1,47 @@
+package internal
+
+import "testing"
+
+func TestParseDataURL(t *testing.T) {
+	tests := []struct {
+		url        string
+		wantMime   string
+		wantBase64 bool
+		wantData   string
+		wantOK     bool
+	}{
+		{"data:image/png;base64,iVBOR", "image/png", true, "iVBOR", true},
+		{"DATA:Image/SVG+XML;charset=utf-8,<svg/>", "image/svg+xml", false, "<svg/>", true},
+		{"data:,hello", "text/plain", false, "hello", true},
+		{"data:image/gif;base64", "", false, "", false},
+		{"https://example.com/a.png", "", false, "", false},
+	}
+	for _, tt := range tests {
+		mime, isBase64, data, ok := ParseDataURL(tt.url)
+		if mime != tt.wantMime || isBase64 != tt.wantBase64 || data != tt.wantData || ok != tt.wantOK {
+			t.Errorf("ParseDataURL(%q) = %q, %v, %q, %v; want %q, %v, %q, %v",
+				tt.url, mime, isBase64, data, ok, tt.wantMime, tt.wantBase64, tt.wantData, tt.wantOK)
+		}
+	}
+}
+
+func TestDataURLImageSize(t *testing.T) {
+	// 1x1 transparent GIF and a 5x5 PNG.
+	tests := []struct {
+		url           string
+		width, height int
+		ok            bool
+	}{
+		{"data:image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7", 1, 1, true},
+		{"data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAUAAAAFCAYAAACNbyblAAAAHElEQVQI12P4//8/w38GIAXDIBKE0DHxgljNBAAO9TXL0Y4OHwAAAABJRU5ErkJggg==", 5, 5, true},
+		{"data:image/png;base64,bm90IGFuIGltYWdl", 0, 0, false},
+		{"data:image/svg+xml;base64,PHN2Zy8+", 0, 0, false},
+		{"data:image/png,rawbytes", 0, 0, false},
+	}
+	for _, tt := range tests {
+		w, h, ok := DataURLImageSize(tt.url)
+		if w != tt.width || h != tt.height || ok != tt.ok {
+			t.Errorf("DataURLImageSize(%.40q) = %d, %d, %v; want %d, %d, %v", tt.url, w, h, ok, tt.width, tt.height, tt.ok)
+		}
+	}
+}
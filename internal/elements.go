@@ -82,10 +82,16 @@ var blockElements = map[string]bool{
 // non-content would cause ShouldRemove/CleanContentNode and the text extractor
 // to drop the whole page body.
 var nonContentTags = map[string]bool{
-	"script": true, "style": true, "noscript": true, "nav": true,
+	"script": true, "style": true, "noscript": true, "template": true, "nav": true,
 	"aside": true, "footer": true, "header": true,
 }
 
+// invisibleTags contains tags whose content is never rendered as visible text.
+// They are a subset of nonContentTags.
+var invisibleTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+}
+
 // knownInlineNamespacePrefixes contains namespace prefixes that are typically
 // used for inline data markers in structured documents like XBRL/SEC filings.
 var knownInlineNamespacePrefixes = map[string]bool{
@@ -119,6 +125,12 @@ func IsNonContentElement(tag string) bool {
 	return nonContentTags[tag]
 }
 
+// IsInvisibleElement returns true if the tag never renders visible text
+// (script, style, noscript, template).
+func IsInvisibleElement(tag string) bool {
+	return invisibleTags[tag]
+}
+
 // IsParagraphLevelBlockElement returns true if the element is a block element that should
 // be separated by paragraph spacing (double newlines) in the output.
 //
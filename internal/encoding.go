@@ -1,5 +1,5 @@
 // encoding.go provides character encoding detection and conversion functionality.
-// It supports 15+ encodings including Unicode variants, Western European,
+// It supports 30+ encodings including Unicode variants, Western European,
 // and East Asian character sets, with intelligent auto-detection capabilities.
 package internal
 
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"unicode/utf8"
@@ -19,6 +20,7 @@ import (
 	"golang.org/x/text/encoding/simplifiedchinese"
 	"golang.org/x/text/encoding/traditionalchinese"
 	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 )
@@ -52,6 +54,32 @@ var charsetAliases = map[string]string{
 	"1250":        "windows-1250",
 	"cp1250":      "windows-1250",
 	"windows1250": "windows-1250",
+	"1253":        "windows-1253",
+	"cp1253":      "windows-1253",
+	"windows1253": "windows-1253",
+	"1254":        "windows-1254",
+	"cp1254":      "windows-1254",
+	"windows1254": "windows-1254",
+	"1255":        "windows-1255",
+	"cp1255":      "windows-1255",
+	"windows1255": "windows-1255",
+	"1256":        "windows-1256",
+	"cp1256":      "windows-1256",
+	"windows1256": "windows-1256",
+	"1257":        "windows-1257",
+	"cp1257":      "windows-1257",
+	"windows1257": "windows-1257",
+	"1258":        "windows-1258",
+	"cp1258":      "windows-1258",
+	"windows1258": "windows-1258",
+	"874":         "windows-874",
+	"cp874":       "windows-874",
+	"windows874":  "windows-874",
+	// Thai: TIS-620 and ISO-8859-11 are subsets of windows-874
+	"tis-620":     "windows-874",
+	"tis620":      "windows-874",
+	"iso-8859-11": "windows-874",
+	"iso885911":   "windows-874",
 	// ISO-8859 variants
 	"8859-1":      "iso-8859-1",
 	"88591":       "iso-8859-1",
@@ -76,6 +104,13 @@ var charsetAliases = map[string]string{
 	"utf-16le": "utf-16le",
 	"utf16be":  "utf-16be",
 	"utf-16be": "utf-16be",
+	"utf32":    "utf-32le",
+	"utf-32":   "utf-32le",
+	"utf_32":   "utf-32le",
+	"utf32le":  "utf-32le",
+	"utf-32le": "utf-32le",
+	"utf32be":  "utf-32be",
+	"utf-32be": "utf-32be",
 	// Japanese
 	"shift_jis": "shift_jis",
 	"shift-jis": "shift_jis",
@@ -315,6 +350,15 @@ func (ed *EncodingDetector) DetectCharsetBasic(data []byte) string {
 		return "utf-8"
 	}
 
+	// Check for UTF-32 BOMs before UTF-16: the UTF-32 LE BOM starts with
+	// the UTF-16 LE one.
+	if dataLen >= 4 && data[0] == 0x00 && data[1] == 0x00 && data[2] == 0xFE && data[3] == 0xFF {
+		return "utf-32be"
+	}
+	if dataLen >= 4 && data[0] == 0xFF && data[1] == 0xFE && data[2] == 0x00 && data[3] == 0x00 {
+		return "utf-32le"
+	}
+
 	// Check for UTF-16 BE BOM
 	if dataLen >= 2 && data[0] == 0xFE && data[1] == 0xFF {
 		return "utf-16be"
@@ -437,7 +481,7 @@ func (ed *EncodingDetector) DetectCharsetSmart(data []byte) EncodingMatch {
 	}
 
 	// Step 2: Try multiple encodings and pick the best match
-	matches := ed.tryAllEncodings(data)
+	matches := ed.tryAllEncodings(data, true)
 
 	// Boost score for basicCharset if it's from meta tag
 	for i := range matches {
@@ -469,6 +513,43 @@ func (ed *EncodingDetector) DetectCharsetSmart(data []byte) EncodingMatch {
 	return bestMatch
 }
 
+// DetectCharsetCandidates scores every candidate encoding that decodes data
+// and returns the matches ordered by descending confidence, then score. The
+// charset found by DetectCharsetBasic gets the same boost as in
+// DetectCharsetSmart, and is included even when it is not a candidate.
+func (ed *EncodingDetector) DetectCharsetCandidates(data []byte) []EncodingMatch {
+	basicCharset := ed.DetectCharsetBasic(data)
+	matches := ed.tryAllEncodings(data, false)
+
+	found := false
+	for i := range matches {
+		if matches[i].Charset == basicCharset {
+			matches[i].Score += 10
+			matches[i].Confidence = min(matches[i].Confidence+5, 100)
+			found = true
+			break
+		}
+	}
+	if !found {
+		if score := ed.scoreEncodingMatch(data, basicCharset); score > 0 {
+			matches = append(matches, EncodingMatch{
+				Charset:    basicCharset,
+				Confidence: min(score+5, 100),
+				Score:      score + 10,
+				Valid:      score >= 40,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
 // ToUTF8 converts the given data from the detected charset to UTF-8
 func (ed *EncodingDetector) ToUTF8(data []byte, charset string) ([]byte, error) {
 	charset = normalizeCharset(charset)
@@ -502,10 +583,13 @@ func (ed *EncodingDetector) ToUTF8(data []byte, charset string) ([]byte, error)
 	return converted, nil
 }
 
-// DetectAndConvert detects charset and converts to UTF-8 in one step
+// DetectAndConvert detects charset and converts to UTF-8 in one step.
+// A non-empty ForcedEncoding bypasses detection entirely.
 func (ed *EncodingDetector) DetectAndConvert(data []byte) ([]byte, string, error) {
 	var charset string
-	if ed.EnableSmartDetection {
+	if ed.ForcedEncoding != "" {
+		charset = normalizeCharset(ed.ForcedEncoding)
+	} else if ed.EnableSmartDetection {
 		match := ed.DetectCharsetSmart(data)
 		charset = match.Charset
 	} else {
@@ -560,6 +644,23 @@ func normalizeCharsetSlow(charset string) string {
 	return charset
 }
 
+// IsSupportedEncoding reports whether charset names an encoding that can be
+// decoded, after alias normalization. "utf-8" is always supported.
+func IsSupportedEncoding(charset string) bool {
+	normalized := normalizeCharset(charset)
+	return normalized == "utf-8" || getEncoding(normalized) != nil
+}
+
+// NewDecodingReader wraps r so that it yields UTF-8 decoded from charset.
+// UTF-8 and unsupported charsets return r unchanged.
+func NewDecodingReader(r io.Reader, charset string) io.Reader {
+	enc := getEncoding(normalizeCharset(charset))
+	if enc == nil {
+		return r
+	}
+	return enc.NewDecoder().Reader(r)
+}
+
 // getEncoding returns the encoding for the given charset name
 func getEncoding(charset string) encoding.Encoding {
 	switch charset {
@@ -569,6 +670,20 @@ func getEncoding(charset string) encoding.Encoding {
 		return charmap.Windows1251 // Cyrillic
 	case "windows-1250":
 		return charmap.Windows1250 // Central European
+	case "windows-1253":
+		return charmap.Windows1253 // Greek
+	case "windows-1254":
+		return charmap.Windows1254 // Turkish
+	case "windows-1255":
+		return charmap.Windows1255 // Hebrew
+	case "windows-1256":
+		return charmap.Windows1256 // Arabic
+	case "windows-1257":
+		return charmap.Windows1257 // Baltic
+	case "windows-1258":
+		return charmap.Windows1258 // Vietnamese
+	case "windows-874":
+		return charmap.Windows874 // Thai
 	case "iso-8859-1":
 		return charmap.ISO8859_1
 	case "iso-8859-15":
@@ -601,6 +716,10 @@ func getEncoding(charset string) encoding.Encoding {
 		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
 	case "utf-16be":
 		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	case "utf-32le":
+		return utf32.UTF32(utf32.LittleEndian, utf32.UseBOM)
+	case "utf-32be":
+		return utf32.UTF32(utf32.BigEndian, utf32.UseBOM)
 	case "shift_jis":
 		return japanese.ShiftJIS
 	case "euc-jp":
@@ -775,8 +894,9 @@ func isPureASCII(data []byte) bool {
 }
 
 // tryAllEncodings attempts to decode the data with multiple encodings and scores each result.
+// With stopEarly, scoring stops at the first high-confidence match.
 // Optimized to avoid redundant UTF-8 validation and conversions.
-func (ed *EncodingDetector) tryAllEncodings(data []byte) []EncodingMatch {
+func (ed *EncodingDetector) tryAllEncodings(data []byte, stopEarly bool) []EncodingMatch {
 	// Common encodings to try, ordered by likelihood
 	candidateEncodings := []struct {
 		name string
@@ -795,6 +915,12 @@ func (ed *EncodingDetector) tryAllEncodings(data []byte) []EncodingMatch {
 		{"windows-1251", 40}, // Cyrillic
 		{"iso-8859-5", 38},   // Cyrillic
 		{"iso-2022-jp", 35},  // Japanese (ISO-2022-JP)
+		{"windows-1256", 34}, // Arabic
+		{"windows-874", 33},  // Thai
+		{"windows-1253", 32}, // Greek
+		{"windows-1255", 31}, // Hebrew
+		{"windows-1254", 30}, // Turkish
+		{"windows-1258", 29}, // Vietnamese
 	}
 
 	// Pre-check UTF-8 validity once (avoids redundant checks in scoreEncodingMatch)
@@ -813,7 +939,7 @@ func (ed *EncodingDetector) tryAllEncodings(data []byte) []EncodingMatch {
 				Valid:      score >= 40, // Threshold for considering it valid
 			})
 			// Early exit for high-confidence match to avoid unnecessary encoding checks
-			if confidence >= 95 && score >= 90 {
+			if stopEarly && confidence >= 95 && score >= 90 {
 				return matches
 			}
 		}
@@ -943,6 +1069,18 @@ func (ed *EncodingDetector) scoreLanguagePatterns(decoded []byte, charset string
 		}
 	}
 
+	// Check for Thai and Arabic characters
+	if hasThaiCharacters(decoded) {
+		if charset == "windows-874" {
+			bonus += 10
+		}
+	}
+	if hasArabicCharacters(decoded) {
+		if charset == "windows-1256" || charset == "iso-8859-6" {
+			bonus += 10
+		}
+	}
+
 	return bonus
 }
 
@@ -1087,30 +1225,40 @@ func calculateValidUTF8RatioFull(data []byte) float64 {
 
 // countCJKCharacters counts CJK (Chinese, Japanese, Korean) characters
 func countCJKCharacters(data []byte) int {
+	return CountCJKCharacters(string(data))
+}
+
+// CountCJKCharacters counts CJK (Chinese, Japanese, Korean) characters in s.
+func CountCJKCharacters(s string) int {
 	count := 0
-	for _, r := range string(data) {
-		// CJK Unified Ideographs block
-		if (r >= 0x4E00 && r <= 0x9FFF) ||
-			(r >= 0x3400 && r <= 0x4DBF) ||
-			(r >= 0x20000 && r <= 0x2A6DF) ||
-			(r >= 0x2A700 && r <= 0x2B73F) ||
-			(r >= 0x2B740 && r <= 0x2B81F) ||
-			(r >= 0x2B820 && r <= 0x2CEAF) ||
-			(r >= 0x2CEB0 && r <= 0x2EBEF) ||
-			// Hiragana and Katakana
-			(r >= 0x3040 && r <= 0x309F) ||
-			(r >= 0x30A0 && r <= 0x30FF) ||
-			// Hangul Syllables
-			(r >= 0xAC00 && r <= 0xD7AF) ||
-			// CJK Extensions
-			(r >= 0xF900 && r <= 0xFAFF) ||
-			(r >= 0x2F800 && r <= 0x2FA1F) {
+	for _, r := range s {
+		if IsCJKRune(r) {
 			count++
 		}
 	}
 	return count
 }
 
+// IsCJKRune reports whether r is a CJK ideograph, kana, or Hangul syllable.
+func IsCJKRune(r rune) bool {
+	// CJK Unified Ideographs block
+	return (r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x20000 && r <= 0x2A6DF) ||
+		(r >= 0x2A700 && r <= 0x2B73F) ||
+		(r >= 0x2B740 && r <= 0x2B81F) ||
+		(r >= 0x2B820 && r <= 0x2CEAF) ||
+		(r >= 0x2CEB0 && r <= 0x2EBEF) ||
+		// Hiragana and Katakana
+		(r >= 0x3040 && r <= 0x309F) ||
+		(r >= 0x30A0 && r <= 0x30FF) ||
+		// Hangul Syllables
+		(r >= 0xAC00 && r <= 0xD7AF) ||
+		// CJK Extensions
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x2F800 && r <= 0x2FA1F)
+}
+
 // hasCyrillicCharacters checks for Cyrillic alphabet characters
 func hasCyrillicCharacters(data []byte) bool {
 	for _, r := range string(data) {
@@ -1124,6 +1272,29 @@ func hasCyrillicCharacters(data []byte) bool {
 	return false
 }
 
+// hasThaiCharacters checks for Thai script characters
+func hasThaiCharacters(data []byte) bool {
+	for _, r := range string(data) {
+		if r >= 0x0E00 && r <= 0x0E7F { // Thai
+			return true
+		}
+	}
+	return false
+}
+
+// hasArabicCharacters checks for Arabic script characters
+func hasArabicCharacters(data []byte) bool {
+	for _, r := range string(data) {
+		if (r >= 0x0600 && r <= 0x06FF) || // Arabic
+			(r >= 0x0750 && r <= 0x077F) || // Arabic Supplement
+			(r >= 0xFB50 && r <= 0xFDFF) || // Arabic Presentation Forms-A
+			(r >= 0xFE70 && r <= 0xFEFF) { // Arabic Presentation Forms-B
+			return true
+		}
+	}
+	return false
+}
+
 // hasExcessiveControlChars checks if data has too many control characters
 func hasExcessiveControlChars(data []byte) bool {
 	if len(data) == 0 {
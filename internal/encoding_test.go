@@ -28,6 +28,16 @@ func TestDetectCharset(t *testing.T) {
 			data:     []byte{0xFE, 0xFF, 0x00, 0x3C},
 			expected: "utf-16be",
 		},
+		{
+			name:     "UTF-32 LE BOM",
+			data:     []byte{0xFF, 0xFE, 0x00, 0x00, 0x3C, 0x00, 0x00, 0x00},
+			expected: "utf-32le",
+		},
+		{
+			name:     "UTF-32 BE BOM",
+			data:     []byte{0x00, 0x00, 0xFE, 0xFF, 0x00, 0x00, 0x00, 0x3C},
+			expected: "utf-32be",
+		},
 		{
 			name:     "windows-1252 in meta tag",
 			data:     []byte(`<html><head><meta http-equiv="Content-Type" content="text/html; charset=windows-1252"></head></html>`),
@@ -113,6 +123,8 @@ func TestNormalizeCharset(t *testing.T) {
 		{"BIG5", "big5"},
 		{"UTF-16LE", "utf-16le"},
 		{"utf16le", "utf-16le"},
+		{"UTF-32", "utf-32le"},
+		{"utf32be", "utf-32be"},
 	}
 
 	for _, tt := range tests {
@@ -346,6 +358,48 @@ func TestForcedEncoding(t *testing.T) {
 	}
 }
 
+// TestForcedEncodingOverridesMeta verifies that ForcedEncoding wins over a wrong
+// meta charset declaration when smart detection is enabled.
+func TestForcedEncodingOverridesMeta(t *testing.T) {
+	ed := NewEncodingDetector()
+	ed.ForcedEncoding = "gb2312"
+
+	input := []byte("<meta charset=\"iso-8859-1\"><p>\xd6\xd0\xce\xc4</p>")
+	converted, charset, err := ed.DetectAndConvert(input)
+	if err != nil {
+		t.Fatalf("DetectAndConvert() error = %v", err)
+	}
+	if charset != "gbk" {
+		t.Errorf("Expected charset gbk, got %v", charset)
+	}
+	if !bytes.Contains(converted, []byte("中文")) {
+		t.Errorf("Forced GBK conversion = %q, want it to contain 中文", string(converted))
+	}
+}
+
+func TestIsSupportedEncoding(t *testing.T) {
+	tests := []struct {
+		charset string
+		want    bool
+	}{
+		{"utf-8", true},
+		{"UTF8", true},
+		{"windows-1252", true},
+		{"cp1251", true},
+		{"Shift_JIS", true},
+		{"gb2312", true},
+		{"iso-8859-7", true},
+		{"", false},
+		{"klingon", false},
+		{"windows-9999", false},
+	}
+	for _, tt := range tests {
+		if got := IsSupportedEncoding(tt.charset); got != tt.want {
+			t.Errorf("IsSupportedEncoding(%q) = %v, want %v", tt.charset, got, tt.want)
+		}
+	}
+}
+
 func BenchmarkDetectCharset(b *testing.B) {
 	data := []byte(`<html><head><meta http-equiv="Content-Type" content="text/html; charset=windows-1252"><title>Test</title></head><body>Content</body></html>`)
 
@@ -699,3 +753,89 @@ func TestScoreLanguagePatterns(t *testing.T) {
 		})
 	}
 }
+
+// TestGetEncoding_WindowsCodePages verifies the Thai, Greek, Turkish, Hebrew,
+// Arabic, Baltic, and Vietnamese Windows code pages resolve through the
+// normalization + lookup path, including their common aliases.
+func TestGetEncoding_WindowsCodePages(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"windows-874", "windows-874"},
+		{"cp874", "windows-874"},
+		{"TIS-620", "windows-874"},
+		{"iso-8859-11", "windows-874"},
+		{"windows-1253", "windows-1253"},
+		{"cp1254", "windows-1254"},
+		{"Windows-1255", "windows-1255"},
+		{"windows-1256", "windows-1256"},
+		{"windows-1257", "windows-1257"},
+		{"CP1258", "windows-1258"},
+	}
+	for _, tt := range tests {
+		normalized := normalizeCharset(tt.input)
+		if normalized != tt.expected {
+			t.Errorf("normalizeCharset(%q) = %q, want %q", tt.input, normalized, tt.expected)
+			continue
+		}
+		if getEncoding(normalized) == nil {
+			t.Errorf("getEncoding(%q) = nil, want non-nil encoding", normalized)
+		}
+	}
+}
+
+func TestToUTF8_ThaiAndArabic(t *testing.T) {
+	ed := NewEncodingDetector()
+
+	tests := []struct {
+		charset  string
+		input    []byte
+		expected string
+	}{
+		{"windows-874", []byte{0xA1, 0xD2, 0xC3}, "การ"},
+		{"windows-1256", []byte{0xE3, 0xD1, 0xCD, 0xC8, 0xC7}, "مرحبا"},
+		{"windows-1253", []byte{0xC1, 0xE8, 0xDE, 0xED, 0xE1}, "Αθήνα"},
+	}
+	for _, tt := range tests {
+		converted, err := ed.ToUTF8(tt.input, tt.charset)
+		if err != nil {
+			t.Fatalf("ToUTF8(%s) error = %v", tt.charset, err)
+		}
+		if string(converted) != tt.expected {
+			t.Errorf("ToUTF8(%s) = %q, want %q", tt.charset, converted, tt.expected)
+		}
+	}
+}
+
+func TestScoreLanguagePatterns_ThaiArabic(t *testing.T) {
+	ed := NewEncodingDetector()
+
+	thai := []byte("ภาษาไทย")
+	if ed.scoreLanguagePatterns(thai, "windows-874") <= ed.scoreLanguagePatterns(thai, "windows-1252") {
+		t.Error("Thai text should score higher for windows-874 than windows-1252")
+	}
+	arabic := []byte("مرحبا بالعالم")
+	if ed.scoreLanguagePatterns(arabic, "windows-1256") <= ed.scoreLanguagePatterns(arabic, "windows-1252") {
+		t.Error("Arabic text should score higher for windows-1256 than windows-1252")
+	}
+}
+
+func TestCountCJKCharacters(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"hello world", 0},
+		{"中文测试", 4},
+		{"ひらがなカタカナ", 8},
+		{"한국어", 3},
+		{"mixed 中文 text。", 2},
+	}
+	for _, tt := range tests {
+		if got := CountCJKCharacters(tt.input); got != tt.want {
+			t.Errorf("CountCJKCharacters(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
@@ -20,25 +20,112 @@ func writeInt(tb *table.TrackedBuilder, n int) {
 	tb.Write(strconv.AppendInt(buf[:0], int64(n), 10))
 }
 
+// TextOptions controls how ExtractStructuredText renders a node tree.
+type TextOptions struct {
+	// TableFormat is the table output format ("markdown" or "html").
+	TableFormat string
+	// IncludeBoilerplate keeps nav, aside, header and footer, skipping only
+	// elements that never render visible text.
+	IncludeBoilerplate bool
+	// PreservePreformatted writes <pre> content verbatim between
+	// PreformattedMarker bytes so CleanText keeps its line breaks and indentation.
+	PreservePreformatted bool
+	// ImageAlt writes the alt text of each <img> at its position, unless
+	// the image is hidden from assistive technology or the alt text repeats
+	// its <figcaption>.
+	ImageAlt bool
+	// LineBreak is how <br> is written outside <pre>: "space" writes a space,
+	// "paragraph" a blank line, and anything else, "newline" included, a
+	// single line break.
+	LineBreak string
+	// OnText, when set, is called with each text node as its text is written,
+	// and with each table and preserved <pre> element, which are written as a
+	// whole. text is the trimmed text of the node.
+	OnText func(n *html.Node, text string)
+	// Keep, when set, reports elements to write even though their tag is
+	// normally skipped, such as a <footer> holding article footnotes.
+	Keep func(n *html.Node) bool
+	// MaxParagraphs, when positive, stops the text after that many
+	// paragraphs: paragraph-level blocks, tables and preserved <pre> blocks
+	// that wrote text and contain no such block themselves.
+	MaxParagraphs int
+}
+
+// textState carries per-call settings through extractTextWithStructure.
+type textState struct {
+	imageCounter *int
+	linkCounter  *int
+	tableFormat  string
+	skip         func(string) bool
+	preservePre  bool
+	imageAlt     bool
+	lineBreak    string
+	onText       func(*html.Node, string)
+	keep         func(*html.Node) bool
+	// maxParagraphs and paragraphs implement TextOptions.MaxParagraphs.
+	maxParagraphs int
+	paragraphs    int
+}
+
+// done reports whether MaxParagraphs paragraphs have been written.
+func (st *textState) done() bool {
+	return st.maxParagraphs > 0 && st.paragraphs >= st.maxParagraphs
+}
+
+// skipped reports whether the element n is left out of the text.
+func (st *textState) skipped(n *html.Node) bool {
+	return st.skip(n.Data) && (st.keep == nil || !st.keep(n))
+}
+
 // ExtractTextWithStructureAndImages extracts text content from an HTML node tree
 // while preserving document structure (headings, paragraphs, lists, tables).
 func ExtractTextWithStructureAndImages(node *html.Node, sb *strings.Builder, imageCounter *int, linkCounter *int, tableFormat string) {
+	ExtractStructuredText(node, sb, imageCounter, linkCounter, TextOptions{TableFormat: tableFormat})
+}
+
+// ExtractVisibleTextWithStructure is like ExtractTextWithStructureAndImages but
+// keeps boilerplate such as nav, aside, header and footer, skipping only
+// elements that never render visible text.
+func ExtractVisibleTextWithStructure(node *html.Node, sb *strings.Builder, imageCounter *int, linkCounter *int, tableFormat string) {
+	ExtractStructuredText(node, sb, imageCounter, linkCounter, TextOptions{TableFormat: tableFormat, IncludeBoilerplate: true})
+}
+
+// ExtractStructuredText extracts text content from an HTML node tree while
+// preserving document structure, as configured by opts. Text is appended to w,
+// typically a *strings.Builder or a pooled *bytes.Buffer.
+func ExtractStructuredText(node *html.Node, w table.Writer, imageCounter *int, linkCounter *int, opts TextOptions) {
 	if node == nil {
 		return
 	}
-	if node.Type == html.ElementNode && IsNonContentElement(node.Data) {
+	skip := IsNonContentElement
+	if opts.IncludeBoilerplate {
+		skip = IsInvisibleElement
+	}
+	if node.Type == html.ElementNode && skip(node.Data) && (opts.Keep == nil || !opts.Keep(node)) {
 		return
 	}
 
-	tb := table.NewTrackedBuilder(sb)
-	extractTextWithStructure(node, tb, imageCounter, linkCounter, tableFormat, nil, 0)
+	st := &textState{
+		imageCounter: imageCounter,
+		linkCounter:  linkCounter,
+		tableFormat:  opts.TableFormat,
+		skip:         skip,
+		preservePre:  opts.PreservePreformatted,
+		imageAlt:     opts.ImageAlt,
+		lineBreak:    opts.LineBreak,
+		onText:       opts.OnText,
+		keep:         opts.Keep,
+
+		maxParagraphs: opts.MaxParagraphs,
+	}
+	extractTextWithStructure(node, table.NewTrackedBuilder(w), st, nil, 0)
 }
 
-func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCounter *int, linkCounter *int, tableFormat string, parentBlock *html.Node, depth int) {
-	if node == nil {
+func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, st *textState, parentBlock *html.Node, depth int) {
+	if node == nil || st.done() {
 		return
 	}
-	if node.Type == html.ElementNode && IsNonContentElement(node.Data) {
+	if node.Type == html.ElementNode && st.skipped(node) {
 		return
 	}
 	if node.Type == html.TextNode {
@@ -56,6 +143,9 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 			hasTrailingSpace := strings.HasSuffix(textData, " ") || strings.HasSuffix(textData, "\t")
 			content := strings.TrimSpace(textData)
 			if content != "" {
+				if st.onText != nil {
+					st.onText(node, content)
+				}
 				tb.WriteString(content)
 				// Preserve trailing space UNLESS next sibling is a namespace tag
 				// Namespace tags (ix:*, xbrl:*, etc.) should be concatenated without spaces
@@ -78,6 +168,9 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 			hasTrailingSpace := strings.HasSuffix(textData, " ") || strings.HasSuffix(textData, "\t")
 			content := strings.TrimSpace(textData)
 			if content != "" {
+				if st.onText != nil {
+					st.onText(node, content)
+				}
 				table.EnsureSpacing(tb, ' ')
 				tb.WriteString(content)
 				// Preserve trailing space from original HTML
@@ -89,32 +182,73 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 		return
 	}
 	if node.Type == html.ElementNode {
-		if node.Data == "img" && imageCounter != nil {
-			*imageCounter++
+		if node.Data == "img" && st.imageCounter != nil {
+			*st.imageCounter++
 			table.EnsureNewline(tb)
 			tb.WriteString("[IMAGE:")
-			writeInt(tb, *imageCounter)
+			writeInt(tb, *st.imageCounter)
 			tb.WriteString("]\n")
+			if st.imageAlt {
+				if alt := ImageAltText(node); alt != "" {
+					tb.WriteString(alt)
+					_ = tb.WriteByte('\n')
+				}
+			}
+			return
+		}
+		if node.Data == "img" {
+			if st.imageAlt {
+				if alt := ImageAltText(node); alt != "" {
+					table.EnsureSpacing(tb, ' ')
+					tb.WriteString(alt)
+					if node.NextSibling != nil {
+						_ = tb.WriteByte(' ')
+					}
+				}
+			}
 			return
 		}
-		if node.Data == "a" && linkCounter != nil {
-			*linkCounter++
+		if node.Data == "a" && st.linkCounter != nil {
+			*st.linkCounter++
 			tb.WriteString("[LINK:")
-			writeInt(tb, *linkCounter)
+			writeInt(tb, *st.linkCounter)
 			tb.WriteString("]")
 			// Continue processing children for link text
 		}
 		if node.Data == "br" {
-			// BR creates a single line break, not paragraph spacing
-			// Only add newline if we have content and don't already have one
-			if tb.Len() > 0 && tb.LastChar != '\n' {
-				_ = tb.WriteByte('\n')
+			// BR creates a single line break by default, not paragraph spacing.
+			// Nothing is written before any content, and a break already at
+			// the end of the output is not repeated.
+			switch st.lineBreak {
+			case "space":
+				table.EnsureSpacing(tb, ' ')
+			case "paragraph":
+				table.EnsureNewline(tb)
+				if tb.Len() > 0 {
+					_ = tb.WriteByte('\n')
+				}
+			default:
+				table.EnsureNewline(tb)
 			}
 			return
 		}
 		if node.Data == "table" {
 			// Use the table processor for table extraction
-			TableProcessor().Extract(node, tb, tableFormat)
+			startLen := tb.Len()
+			TableProcessor().Extract(node, tb, st.tableFormat)
+			st.reportElementText(node)
+			if tb.Len() > startLen {
+				st.paragraphs++
+			}
+			return
+		}
+		if node.Data == "pre" && st.preservePre {
+			startLen := tb.Len()
+			writePreformatted(node, tb, st)
+			st.reportElementText(node)
+			if tb.Len() > startLen {
+				st.paragraphs++
+			}
 			return
 		}
 		// Check if this is a paragraph-level block element that needs double newlines
@@ -148,11 +282,12 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 			}
 		}
 
+		paragraphs := st.paragraphs
 		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			extractTextWithStructure(child, tb, imageCounter, linkCounter, tableFormat, node, depth+1)
+			extractTextWithStructure(child, tb, st, node, depth+1)
 		}
 		// Add closing link tag after processing children
-		if node.Data == "a" && linkCounter != nil {
+		if node.Data == "a" && st.linkCounter != nil {
 			tb.WriteString("[/LINK]")
 		}
 		hasContent := tb.Len() > startLen
@@ -162,6 +297,9 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 			if isParagraphBlock && tb.LastChar == '\n' {
 				_ = tb.WriteByte('\n')
 			}
+			if isParagraphBlock && st.paragraphs == paragraphs {
+				st.paragraphs++
+			}
 		}
 		// Add spacing for non-root inline elements (depth > 0)
 		// This ensures proper spacing between inline elements at the same level
@@ -170,18 +308,165 @@ func extractTextWithStructure(node *html.Node, tb *table.TrackedBuilder, imageCo
 		}
 	} else {
 		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			extractTextWithStructure(child, tb, imageCounter, linkCounter, tableFormat, parentBlock, depth+1)
+			extractTextWithStructure(child, tb, st, parentBlock, depth+1)
 		}
 	}
 }
 
+// reportElementText passes the text of an element written as a whole, such as
+// a table, to the OnText callback, with its text nodes separated by spaces so
+// that adjacent cells do not run together.
+func (st *textState) reportElementText(n *html.Node) {
+	if st.onText == nil {
+		return
+	}
+	var parts []string
+	WalkNodes(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			if text := strings.TrimSpace(c.Data); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return true
+	})
+	if len(parts) > 0 {
+		st.onText(n, strings.Join(parts, " "))
+	}
+}
+
+// maxFigureDepth bounds how far ImageAltText looks up the tree for the
+// <figure> of an image, which is usually its parent or a wrapping link.
+const maxFigureDepth = 3
+
+// ImageAltText returns the whitespace-collapsed alt text of img, or "" when it
+// has none, is marked aria-hidden or with role presentation or none, or merely
+// repeats the figcaption of its enclosing <figure>.
+func ImageAltText(img *html.Node) string {
+	alt := strings.Join(strings.Fields(GetAttr(img, "alt")), " ")
+	if alt == "" || GetAttr(img, "aria-hidden") == "true" {
+		return ""
+	}
+	switch strings.ToLower(strings.TrimSpace(GetAttr(img, "role"))) {
+	case "presentation", "none":
+		return ""
+	}
+	figure := img.Parent
+	for i := 0; figure != nil && i < maxFigureDepth && !(figure.Type == html.ElementNode && figure.Data == "figure"); i++ {
+		figure = figure.Parent
+	}
+	if figure == nil || figure.Data != "figure" {
+		return alt
+	}
+	for c := figure.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "figcaption" &&
+			strings.EqualFold(strings.Join(strings.Fields(GetTextContent(c)), " "), alt) {
+			return ""
+		}
+	}
+	return alt
+}
+
+// writePreformatted writes the text of a <pre> element with its whitespace
+// intact, enclosed in PreformattedMarker bytes. Nested elements contribute
+// their text and image/link placeholders; <br> becomes a newline.
+func writePreformatted(node *html.Node, tb *table.TrackedBuilder, st *textState) {
+	if tb.Len() > 0 {
+		table.EnsureNewline(tb)
+	}
+	_ = tb.WriteByte(PreformattedMarker)
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.TextNode:
+				if text := preformattedReplacer.Replace(c.Data); text != "" {
+					tb.WriteString(text)
+				}
+			case c.Type != html.ElementNode:
+				walk(c)
+			case st.skipped(c):
+			case c.Data == "br":
+				_ = tb.WriteByte('\n')
+			case c.Data == "img":
+				if st.imageCounter != nil {
+					*st.imageCounter++
+					tb.WriteString("[IMAGE:")
+					writeInt(tb, *st.imageCounter)
+					tb.WriteString("]")
+				}
+			case c.Data == "a" && st.linkCounter != nil:
+				*st.linkCounter++
+				tb.WriteString("[LINK:")
+				writeInt(tb, *st.linkCounter)
+				tb.WriteString("]")
+				walk(c)
+				tb.WriteString("[/LINK]")
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(node)
+	_ = tb.WriteByte(PreformattedMarker)
+	_ = tb.WriteByte('\n')
+}
+
 // CleanContentNode removes non-content elements from the node tree.
 // Uses iterative traversal with explicit stack to avoid potential stack overflow
 // on deeply nested documents and improve cache locality.
 func CleanContentNode(node *html.Node) *html.Node {
+	return CleanContentNodeWithScorer(node, nil)
+}
+
+// RemoveInvisibleElements removes elements that never render visible text
+// (script, style, noscript, template) and elements hidden with the hidden
+// attribute or an inline display:none/visibility:hidden style. Unlike
+// CleanContentNode it keeps boilerplate such as nav and footer.
+func RemoveInvisibleElements(node *html.Node) *html.Node {
+	return CleanContentNodeWithScorer(node, invisibleScorer{})
+}
+
+// InvisibleScorer returns the Scorer used by RemoveInvisibleElements, whose
+// ShouldRemove matches only elements that never render visible text.
+func InvisibleScorer() Scorer {
+	return invisibleScorer{}
+}
+
+// invisibleScorer is a Scorer whose ShouldRemove matches only invisible elements.
+type invisibleScorer struct{}
+
+func (invisibleScorer) Score(node *html.Node) int { return 0 }
+
+func (invisibleScorer) ShouldRemove(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode {
+		return false
+	}
+	if IsInvisibleElement(node.Data) {
+		return true
+	}
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case "hidden":
+			return true
+		case "style":
+			style := strings.ToLower(strings.ReplaceAll(attr.Val, " ", ""))
+			if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CleanContentNodeWithScorer is like CleanContentNode but asks scorer which
+// elements to remove. A nil scorer uses the shared default scorer.
+func CleanContentNodeWithScorer(node *html.Node, scorer Scorer) *html.Node {
 	if node == nil {
 		return nil
 	}
+	if scorer == nil {
+		scorer = getDefaultScorer()
+	}
 
 	toRemove := make([]*html.Node, 0, 8)
 
@@ -198,7 +483,7 @@ func CleanContentNode(node *html.Node) *html.Node {
 		stack = stack[:len(stack)-1]
 
 		for child := n.FirstChild; child != nil; child = child.NextSibling {
-			if child.Type == html.ElementNode && ShouldRemoveElement(child) {
+			if child.Type == html.ElementNode && scorer.ShouldRemove(child) {
 				toRemove = append(toRemove, child)
 			} else {
 				stack = append(stack, child)
@@ -0,0 +1,55 @@
+// fold.go provides case- and accent-insensitive string normalization used when
+// comparing class names, ids, and hosts.
+package internal
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FoldString normalizes s for case- and/or accent-insensitive comparison.
+// Case folding uses full Unicode case folding; accent folding decomposes s and
+// drops combining marks, so "Café" folds to "cafe" with both options enabled.
+// Both sides of a comparison must be folded with the same options.
+func FoldString(s string, foldCase, foldAccents bool) string {
+	ascii := isASCIIString(s)
+	if foldAccents && !ascii {
+		s = stripAccents(s)
+		ascii = isASCIIString(s)
+	}
+	if foldCase {
+		if ascii {
+			return strings.ToLower(s)
+		}
+		// A Caser is stateful and must not be shared between goroutines.
+		return cases.Fold().String(s)
+	}
+	return s
+}
+
+// stripAccents removes combining marks (Unicode category Mn) after canonical
+// decomposition and recomposes the remainder.
+func stripAccents(s string) string {
+	decomposed := norm.NFD.String(s)
+	var b strings.Builder
+	b.Grow(len(decomposed))
+	for _, r := range decomposed {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(b.String())
+}
+
+// isASCIIString reports whether s contains only ASCII bytes.
+func isASCIIString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package internal
+
+import "testing"
+
+func TestFoldString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		foldCase    bool
+		foldAccents bool
+		want        string
+	}{
+		{"no folding", "Café", false, false, "Café"},
+		{"case only ascii", "MAIN-Content", true, false, "main-content"},
+		{"case only unicode", "CAFÉ", true, false, "café"},
+		{"accents only", "Café Crème", false, true, "Cafe Creme"},
+		{"both", "CAFÉ.Exámple.com", true, true, "cafe.example.com"},
+		{"sharp s case fold", "STRASSE straße", true, false, "strasse strasse"},
+		{"ascii unchanged by accents", "sidebar", false, true, "sidebar"},
+		{"empty", "", true, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FoldString(tt.input, tt.foldCase, tt.foldAccents); got != tt.want {
+				t.Errorf("FoldString(%q, %v, %v) = %q, want %q", tt.input, tt.foldCase, tt.foldAccents, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDifferentDomainFold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		base        string
+		target      string
+		foldCase    bool
+		foldAccents bool
+		want        bool
+	}{
+		{"case differs without folding", "https://Example.com/", "https://example.com/a", false, false, true},
+		{"case folded", "https://Example.com/", "https://example.com/a", true, false, false},
+		{"accent differs without folding", "https://café.example/", "https://cafe.example/a", false, false, true},
+		{"accent folded", "https://café.example/", "https://cafe.example/a", false, true, false},
+		{"accent and case folded", "https://CAFÉ.example/", "https://cafe.example/a", true, true, false},
+		{"different host stays different", "https://café.example/", "https://other.example/", true, true, true},
+		{"relative target", "https://example.com/", "/path", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDifferentDomainFold(tt.base, tt.target, tt.foldCase, tt.foldAccents); got != tt.want {
+				t.Errorf("IsDifferentDomainFold(%q, %q) = %v, want %v", tt.base, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultScorerFoldAccents(t *testing.T) {
+	t.Parallel()
+
+	doc, err := parseHTML(`<div class="commént-list">Comments</div>`)
+	if err != nil {
+		t.Fatalf("parseHTML failed: %v", err)
+	}
+	node := FindElementByTag(doc, "div")
+
+	if NewDefaultScorer().ShouldRemove(node) {
+		t.Error("accented class should not match without folding")
+	}
+
+	cfg := DefaultScoringConfig()
+	cfg.FoldAccents = true
+	if !NewDefaultScorerWithConfig(cfg).ShouldRemove(node) {
+		t.Error("accented class should match the comment pattern with FoldAccents")
+	}
+}
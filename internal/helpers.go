@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"golang.org/x/net/html"
@@ -235,14 +236,139 @@ var unwantedCharReplacer = strings.NewReplacer(
 	"☑", "[X]",
 )
 
+// StripRunes removes C0 and C1 control characters other than tab and newline
+// when controls is set, and emoji when emoji is set, including the variation
+// selectors, skin tone modifiers and zero-width joiners of emoji sequences.
+// PreformattedMarker bytes are kept, so StripRunes can run before CleanText,
+// which then collapses the whitespace left around removed characters. Text
+// without any such character is returned unchanged.
+func StripRunes(text string, controls, emoji bool) string {
+	strip := func(r rune) bool {
+		return controls && isStrippedControl(r) || emoji && isEmoji(r)
+	}
+	i := strings.IndexFunc(text, strip)
+	if i < 0 {
+		return text
+	}
+
+	sb := GetBuilder()
+	defer PutBuilder(sb)
+	sb.Grow(len(text))
+	sb.WriteString(text[:i])
+	prevEmoji := false
+	for _, r := range text[i:] {
+		switch {
+		case emoji && (isEmoji(r) || r == '\u200d' && prevEmoji):
+			// A zero-width joiner is only dropped inside an emoji sequence,
+			// since scripts such as Malayalam rely on it.
+			prevEmoji = true
+		case controls && isStrippedControl(r):
+		default:
+			sb.WriteRune(r)
+			prevEmoji = false
+		}
+	}
+	return sb.String()
+}
+
+// isStrippedControl reports whether r is a C0 or C1 control character removed
+// by StripRunes: anything but tab, newline and PreformattedMarker.
+func isStrippedControl(r rune) bool {
+	return (r < 0x20 && r != '\t' && r != '\n' && r != PreformattedMarker) || (r >= 0x7F && r <= 0x9F)
+}
+
+// isEmoji reports whether r is a pictographic emoji, an emoji modifier or
+// selector, or a regional indicator. The ballot boxes U+2610-U+2612 are
+// excluded, because CleanText renders them as "[ ]" and "[X]".
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Emoticons, pictographs, transport, flags, skin tones
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return r < 0x2610 || r > 0x2612
+	case r >= 0xE0020 && r <= 0xE007F: // Tag sequences of subdivision flags
+		return true
+	}
+	switch r {
+	case 0xFE0F, 0x20E3, // Emoji presentation selector, combining keycap
+		0x231A, 0x231B, 0x2328, 0x23CF, 0x23E9, 0x23EA, 0x23EB, 0x23EC, 0x23ED, 0x23EE, 0x23EF,
+		0x23F0, 0x23F1, 0x23F2, 0x23F3, 0x23F8, 0x23F9, 0x23FA,
+		0x2B05, 0x2B06, 0x2B07, 0x2B1B, 0x2B1C, 0x2B50, 0x2B55, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+// PreformattedMarker delimits verbatim <pre> text in extracted output. The HTML
+// parser never emits NUL in text nodes, so the byte cannot collide with content.
+const PreformattedMarker = '\x00'
+
+// preformattedReplacer normalizes line endings and non-breaking spaces in
+// verbatim <pre> text without touching other whitespace.
+var preformattedReplacer = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\u00a0", " ")
+
+// CleanText collapses runs of spaces and blank lines, trims every line, and
+// decodes leftover entities. Text between pairs of PreformattedMarker bytes is
+// kept verbatim apart from trimming blank leading and trailing lines, and is
+// separated from the surrounding text by a blank line.
 func CleanText(text string, whitespaceRegex *regexp.Regexp) string {
+	if strings.IndexByte(text, PreformattedMarker) < 0 {
+		return cleanText(text, whitespaceRegex)
+	}
+
+	parts := strings.Split(text, string(PreformattedMarker))
+	sb := GetBuilder()
+	defer PutBuilder(sb)
+	for i, part := range parts {
+		// Odd parts are preformatted; an unpaired trailing marker leaves the
+		// last part to regular cleaning.
+		if i%2 == 1 && i < len(parts)-1 {
+			part = strings.TrimRight(part, " \t\n")
+			for strings.HasPrefix(part, "\n") {
+				part = part[1:]
+			}
+			if strings.IndexByte(part, '&') >= 0 {
+				part = ReplaceHTMLEntities(part)
+			}
+		} else {
+			part = cleanText(part, whitespaceRegex)
+		}
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// CollapseWhitespace replaces every run of whitespace, newlines included, with
+// a single space, flattening text onto one line. Text between pairs of
+// PreformattedMarker bytes is left untouched, markers included, so that
+// CleanText still emits those blocks verbatim.
+func CollapseWhitespace(text string) string {
+	parts := strings.Split(text, string(PreformattedMarker))
+	for i, part := range parts {
+		if i%2 == 0 || i == len(parts)-1 {
+			parts[i] = strings.Join(strings.Fields(part), " ")
+		}
+	}
+	return strings.Join(parts, string(PreformattedMarker))
+}
+
+func cleanText(text string, whitespaceRegex *regexp.Regexp) string {
 	if text == "" {
 		return ""
 	}
 
-	// Fast path: check if processing is needed
+	// Fast path: check if processing is needed. Text with newlines is left
+	// as is when every line is already trimmed and blank lines come singly,
+	// which is how extracted paragraphs usually look.
 	n := len(text)
 	hasNewlines := false
+	needsReflow := false
 	hasMultipleSpaces := false
 	hasNBSP := false
 	hasUnwanted := false
@@ -254,6 +380,9 @@ func CleanText(text string, whitespaceRegex *regexp.Regexp) string {
 		switch {
 		case c == '\n':
 			hasNewlines = true
+			if i == 0 || i == n-1 || prevSpace || (i >= 2 && text[i-1] == '\n' && text[i-2] == '\n') {
+				needsReflow = true
+			}
 		case c == '\t':
 			hasMultipleSpaces = true
 		case c == ' ':
@@ -274,7 +403,10 @@ func CleanText(text string, whitespaceRegex *regexp.Regexp) string {
 		prevSpace = false
 	}
 
-	if !hasNewlines && !hasMultipleSpaces && !hasNBSP && !hasUnwanted {
+	if hasNewlines && text[n-1] == ' ' {
+		needsReflow = true
+	}
+	if !needsReflow && !hasMultipleSpaces && !hasNBSP && !hasUnwanted {
 		if hasAmpersand {
 			return ReplaceHTMLEntities(text)
 		}
@@ -464,6 +596,16 @@ func WalkNodesWithTruncation(node *html.Node, fn func(*html.Node) bool) (truncat
 	return false, visitedCount
 }
 
+// GetAttr returns the value of the attribute key on n, or "" when absent.
+func GetAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
 func FindElementByTag(doc *html.Node, tagName string) *html.Node {
 	var result *html.Node
 	WalkNodes(doc, func(n *html.Node) bool {
@@ -601,6 +743,47 @@ func GetTextLength(node *html.Node) int {
 	return length
 }
 
+// CountWords returns the number of words in text. Runs of non-space characters
+// count as one word, except CJK ideographs and kana, which are not space-delimited
+// and count as one word each. Non-ASCII whitespace and punctuation (such as the
+// ideographic full stop) separate words.
+func CountWords(text string) int {
+	count := 0
+	inWord := false
+	for i := 0; i < len(text); {
+		c := text[i]
+		if c < utf8.RuneSelf {
+			i++
+			if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+				inWord = false
+			} else if !inWord {
+				inWord = true
+				count++
+			}
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch {
+		case isCJKWordRune(r):
+			count++
+			inWord = false
+		case unicode.IsSpace(r) || unicode.IsPunct(r):
+			inWord = false
+		case !inWord:
+			inWord = true
+			count++
+		}
+	}
+	return count
+}
+
+// isCJKWordRune reports whether r is written without spaces between words.
+// Hangul is excluded because Korean separates words with spaces.
+func isCJKWordRune(r rune) bool {
+	return IsCJKRune(r) && (r < 0xAC00 || r > 0xD7AF)
+}
+
 func GetLinkDensity(node *html.Node) float64 {
 	if node == nil {
 		return 0.0
@@ -998,11 +1181,12 @@ func decodeEntityFallback(entity string) string {
 // This is a centralized URL validation function with size limits for security.
 func IsValidURL(url string) bool {
 	urlLen := len(url)
-	if urlLen == 0 || urlLen > MaxURLLength {
+	if urlLen == 0 {
 		return false
 	}
 
-	// Special handling for data URLs - stricter validation with size limit
+	// Special handling for data URLs - stricter validation with their own size
+	// limit, since inline images routinely exceed MaxURLLength
 	if strings.HasPrefix(url, "data:") {
 		if urlLen > MaxDataURILength {
 			return false
@@ -1016,6 +1200,10 @@ func IsValidURL(url string) bool {
 		return true
 	}
 
+	if urlLen > MaxURLLength {
+		return false
+	}
+
 	// Validate non-data URLs: check for dangerous characters
 	for i := 0; i < urlLen; i++ {
 		b := url[i]
@@ -1074,6 +1262,27 @@ func IsValidURL(url string) bool {
 	return false
 }
 
+// CloneNode returns a deep copy of n and its descendants, detached from any parent.
+func CloneNode(n *html.Node) *html.Node {
+	if n == nil {
+		return nil
+	}
+	clone := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+	}
+	if len(n.Attr) > 0 {
+		clone.Attr = make([]html.Attribute, len(n.Attr))
+		copy(clone.Attr, n.Attr)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		clone.AppendChild(CloneNode(c))
+	}
+	return clone
+}
+
 func SelectBestCandidate(candidates map[*html.Node]int) *html.Node {
 	var bestNode *html.Node
 	bestScore := -1
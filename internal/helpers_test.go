@@ -659,6 +659,67 @@ func TestCalculateContentDensityWithNbsp(t *testing.T) {
 	}
 }
 
+// TestCleanTextMultilineFastPath pins the output of multi-line inputs near
+// the boundary between text returned as is and text that is rebuilt line by line.
+func TestCleanTextMultilineFastPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"a\nb", "a\nb"},
+		{"a\n\nb", "a\n\nb"},
+		{"a\n\n\nb", "a\n\nb"},
+		{"\na", "a"},
+		{"a\n", "a"},
+		{"a\n\n", "a"},
+		{"a \nb", "a\nb"},
+		{"a\n b", "a\n b"},
+		{"a\n \nb", "a\n\nb"},
+		{" a\nb", " a\nb"},
+		{"a\nb ", "a\nb"},
+		{"a\n\nb &amp; c", "a\n\nb & c"},
+		{"x\n\u2612y", "x\n[X]y"},
+		{"a\u00a0b\nc", "a\u00a0b\nc"},
+		{"\n", ""},
+		{"\n\n", ""},
+		{" \n", ""},
+	}
+	for _, tt := range tests {
+		if got := CleanText(tt.input, nil); got != tt.want {
+			t.Errorf("CleanText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestStripRunes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		input           string
+		controls, emoji bool
+		want            string
+	}{
+		{"nothing to strip", "plain text\twith tab\n", true, true, "plain text\twith tab\n"},
+		{"c0 and c1 controls", "a\x01b\x1bc\x7fd\u0085e\u009ff\r\n", true, false, "abcdef\n"},
+		{"preformatted marker kept", "\x00pre\x00", true, false, "\x00pre\x00"},
+		{"controls kept without option", "a\x01b", false, true, "a\x01b"},
+		{"emoji", "Hi 👋 there 🎉!", false, true, "Hi  there !"},
+		{"zwj sequence and skin tone", "family 👨\u200d👩\u200d👧 and 👍🏽 done", false, true, "family  and  done"},
+		{"flag and keycap", "🇫🇷 1\ufe0f\u20e3 ☀\ufe0f", false, true, " 1 "},
+		{"zwj outside emoji kept", "ന്\u200dറ", false, true, "ന്\u200dറ"},
+		{"ballot boxes kept", "☐ todo ☒ done", false, true, "☐ todo ☒ done"},
+		{"emoji kept without option", "ok 👍", true, false, "ok 👍"},
+	}
+	for _, tt := range tests {
+		if got := StripRunes(tt.input, tt.controls, tt.emoji); got != tt.want {
+			t.Errorf("%s: StripRunes(%q) = %q, want %q", tt.name, tt.input, got, tt.want)
+		}
+	}
+}
+
 func BenchmarkGetTextContent(b *testing.B) {
 	doc, _ := html.Parse(strings.NewReader(`<html><body><p>Hello World</p><p>More text</p></body></html>`))
 
@@ -685,6 +746,29 @@ func BenchmarkCleanText(b *testing.B) {
 	})
 }
 
+// BenchmarkCleanTextLarge measures CleanText on article-sized ASCII text,
+// once already normalized as extraction usually produces it and once with
+// whitespace runs that have to be collapsed.
+func BenchmarkCleanTextLarge(b *testing.B) {
+	paragraph := "The quick brown fox jumps over the lazy dog, again and again, until the end of the line."
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"Normalized", strings.Repeat(paragraph+"\n\n", 500) + paragraph},
+		{"WithRuns", strings.Repeat(paragraph+"   \n\n\n\t", 500) + paragraph},
+	}
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			b.SetBytes(int64(len(tt.text)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CleanText(tt.text, nil)
+			}
+		})
+	}
+}
+
 // Benchmarks for performance optimizations
 
 func BenchmarkNormalizeNonBreakingSpaces(b *testing.B) {
@@ -963,3 +1047,70 @@ func TestReplaceEntityAt(t *testing.T) {
 		})
 	}
 }
+
+func TestCountWords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"   ", 0},
+		{"hello world", 2},
+		{"one\ttwo\nthree\r\nfour", 4},
+		{"café au lait", 3},
+		{"中文测试", 4},
+		{"这是一个测试。还有一句。", 10},
+		{"ひらがな", 4},
+		{"한국어 문장", 2},
+		{"Go 语言 is fun", 5},
+		{"English中文mixed", 4},
+		{"word　word", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := CountWords(tt.input); got != tt.want {
+				t.Errorf("CountWords(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanTextPreformatted(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"verbatim block", "intro   text\n\x00\n  a  b\n\n\n    c\n\x00\nouter  text", "intro text\n\n  a  b\n\n\n    c\n\nouter text"},
+		{"block only", "\x00x = 1\n  y = 2\n\x00", "x = 1\n  y = 2"},
+		{"empty block", "a\x00  \n\x00b", "a\n\nb"},
+		{"unpaired marker", "a\x00b  c", "a\n\nb c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanText(tt.input, nil); got != tt.want {
+				t.Errorf("CleanText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"a\n\n  b\tc\n", "a b c"},
+		{"a \n\x00  x\n  y\x00\n b", "a\x00  x\n  y\x00b"},
+		{"a\x00 b  c", "a\x00b c"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CollapseWhitespace(tt.input); got != tt.want {
+			t.Errorf("CollapseWhitespace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
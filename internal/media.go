@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"net/url"
 	"strings"
 )
 
@@ -25,6 +26,10 @@ const (
 	mimeWMA   = "audio/x-ms-wma"
 	mimeOpus  = "audio/opus"
 	mimeEmbed = "embed"
+
+	// Streaming manifest types, reported in place of a MIME type
+	typeHLS  = "hls"
+	typeDASH = "dash"
 )
 
 var (
@@ -33,7 +38,17 @@ var (
 		".mp4": mimeMP4, ".m4v": mimeMP4, ".webm": mimeWebM,
 		".ogg": mimeOGGVideo, ".mov": mimeQuicktime, ".avi": mimeAVI,
 		".wmv": mimeWMV, ".flv": mimeFLV, ".mkv": mimeMKV,
-		".3gp": mime3GP,
+		".3gp": mime3GP, ".m3u8": typeHLS, ".mpd": typeDASH,
+	}
+
+	// streamManifestMIMETypes maps the MIME types of HLS and DASH manifests,
+	// as declared by <source type>, to their stream type.
+	streamManifestMIMETypes = map[string]string{
+		"application/vnd.apple.mpegurl": typeHLS,
+		"application/x-mpegurl":         typeHLS,
+		"audio/mpegurl":                 typeHLS,
+		"audio/x-mpegurl":               typeHLS,
+		"application/dash+xml":          typeDASH,
 	}
 
 	// Audio extensions for audio-specific detection
@@ -107,6 +122,26 @@ func DetectAudioType(url string) string {
 	return detectAudioType(lowerURL)
 }
 
+// StreamManifestType returns "hls" or "dash" when mimeType is the MIME type of
+// an HLS or DASH manifest, already "hls" or "dash", or else when url names a
+// .m3u8 or .mpd file, and "" otherwise. MIME parameters are ignored.
+func StreamManifestType(url, mimeType string) string {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if i := strings.IndexByte(mimeType, ';'); i >= 0 {
+		mimeType = strings.TrimSpace(mimeType[:i])
+	}
+	if kind, ok := streamManifestMIMETypes[mimeType]; ok {
+		return kind
+	}
+	if mimeType == typeHLS || mimeType == typeDASH {
+		return mimeType
+	}
+	if kind := detectVideoType(strings.ToLower(url)); kind == typeHLS || kind == typeDASH {
+		return kind
+	}
+	return ""
+}
+
 // detectVideoType performs lookup for video extensions.
 // Handles URLs with query parameters and fragments by stripping them first.
 func detectVideoType(url string) string {
@@ -155,6 +190,88 @@ func hasEmbedPattern(url string) bool {
 	return false
 }
 
+// ParseVideoEmbed identifies the hosting platform and video ID of a YouTube,
+// Vimeo, or Dailymotion URL in any of its common shapes (embed, short link,
+// watch page) and returns the platform's canonical watch URL. All results are
+// empty when rawURL is not a recognized video URL.
+func ParseVideoEmbed(rawURL string) (platform, id, canonical string) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return "", "", ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		platform = "youtube"
+		switch {
+		case segments[0] == "watch":
+			id = u.Query().Get("v")
+		case len(segments) >= 2 && (segments[0] == "embed" || segments[0] == "shorts" || segments[0] == "v" || segments[0] == "live"):
+			id = segments[1]
+		}
+	case "youtu.be":
+		platform = "youtube"
+		id = segments[0]
+	case "player.vimeo.com":
+		platform = "vimeo"
+		if len(segments) >= 2 && segments[0] == "video" {
+			id = segments[1]
+		}
+	case "vimeo.com":
+		platform = "vimeo"
+		id = segments[0]
+	case "dailymotion.com":
+		platform = "dailymotion"
+		switch {
+		case len(segments) >= 3 && segments[0] == "embed" && segments[1] == "video":
+			id = segments[2]
+		case len(segments) >= 2 && segments[0] == "video":
+			id = segments[1]
+		}
+	}
+
+	if !isValidVideoID(id) || (platform == "vimeo" && !isAllDigits(id)) {
+		return "", "", ""
+	}
+
+	switch platform {
+	case "youtube":
+		canonical = "https://www.youtube.com/watch?v=" + id
+	case "vimeo":
+		canonical = "https://vimeo.com/" + id
+	case "dailymotion":
+		canonical = "https://www.dailymotion.com/video/" + id
+	}
+	return platform, id, canonical
+}
+
+// isValidVideoID reports whether id looks like a platform video ID: 1-64
+// characters drawn from letters, digits, '-', and '_'.
+func isValidVideoID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAllDigits reports whether s consists solely of ASCII digits.
+func isAllDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
 // HasMediaReference reports whether content contains a byte sequence that could
 // form a media URL: a recognized media file extension (".mp4", ".mp3", ...) or a
 // known embed-host pattern ("youtube.com/embed/", ...). The scan is allocation-free
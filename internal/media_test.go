@@ -164,6 +164,16 @@ func TestDetectVideoType(t *testing.T) {
 			url:  "https://example.com/video.3gp",
 			want: "video/3gpp",
 		},
+		{
+			name: "HLS manifest",
+			url:  "https://example.com/live/master.M3U8?token=1",
+			want: "hls",
+		},
+		{
+			name: "DASH manifest",
+			url:  "https://example.com/live/manifest.mpd",
+			want: "dash",
+		},
 		{
 			name: "YouTube embed",
 			url:  "https://www.youtube.com/embed/123456",
@@ -270,6 +280,34 @@ func TestDetectAudioType(t *testing.T) {
 	}
 }
 
+// TestStreamManifestType tests HLS and DASH manifest detection
+func TestStreamManifestType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		url      string
+		mimeType string
+		want     string
+	}{
+		{name: "HLS extension", url: "https://example.com/live/index.m3u8", want: "hls"},
+		{name: "DASH extension", url: "https://example.com/live/stream.mpd#t=10", want: "dash"},
+		{name: "HLS MIME type", url: "https://example.com/live/master", mimeType: "application/vnd.apple.mpegurl", want: "hls"},
+		{name: "legacy HLS MIME type", url: "https://example.com/live/master", mimeType: "Application/X-MpegURL; charset=utf-8", want: "hls"},
+		{name: "DASH MIME type", url: "https://example.com/live/master", mimeType: "application/dash+xml", want: "dash"},
+		{name: "media file", url: "https://example.com/video.mp4", mimeType: "video/mp4", want: ""},
+		{name: "no URL", mimeType: "video/webm", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StreamManifestType(tt.url, tt.mimeType); got != tt.want {
+				t.Errorf("StreamManifestType(%q, %q) = %q, want %q", tt.url, tt.mimeType, got, tt.want)
+			}
+		})
+	}
+}
+
 // BenchmarkIsVideoURL benchmarks video URL detection
 func BenchmarkIsVideoURL(b *testing.B) {
 	urls := []string{
@@ -357,3 +395,37 @@ func BenchmarkHasMediaReference(b *testing.B) {
 		_ = HasMediaReference(content)
 	}
 }
+
+func TestParseVideoEmbed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url          string
+		wantPlatform string
+		wantID       string
+		wantURL      string
+	}{
+		{"https://www.youtube.com/embed/dQw4w9WgXcQ", "youtube", "dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0", "youtube", "dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://youtu.be/dQw4w9WgXcQ?t=42", "youtube", "dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?v=dQw4w9WgXcQ&list=x", "youtube", "dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"//www.youtube.com/shorts/abc_DEF-123", "youtube", "abc_DEF-123", "https://www.youtube.com/watch?v=abc_DEF-123"},
+		{"https://player.vimeo.com/video/123456?h=abc", "vimeo", "123456", "https://vimeo.com/123456"},
+		{"https://vimeo.com/123456", "vimeo", "123456", "https://vimeo.com/123456"},
+		{"https://www.dailymotion.com/embed/video/x7tgad0", "dailymotion", "x7tgad0", "https://www.dailymotion.com/video/x7tgad0"},
+		{"https://vimeo.com/channels", "", "", ""},
+		{"https://www.youtube.com/embed/", "", "", ""},
+		{"https://example.com/video.mp4", "", "", ""},
+		{"not a url", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			platform, id, canonical := ParseVideoEmbed(tt.url)
+			if platform != tt.wantPlatform || id != tt.wantID || canonical != tt.wantURL {
+				t.Errorf("ParseVideoEmbed(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tt.url, platform, id, canonical, tt.wantPlatform, tt.wantID, tt.wantURL)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ var dangerousAttributes = map[string]bool{
 	// Other dangerous attributes
 	"formaction": true, // Can override form action
 	"autofocus":  true, // Can be used for phishing
+	"srcdoc":     true, // Inline iframe document, which is not sanitized
 }
 
 // dangerousCSSPatterns are stripped from style attribute values during sanitization.
@@ -84,6 +85,43 @@ var uriAttributes = map[string]bool{
 	"xlink:href": true,
 }
 
+// alwaysRemovedTags are removed under every Policy, since their content is
+// executable or rendered unescaped. The SVG animation elements can set an
+// href to a javascript: URL once a policy keeps <svg>.
+var alwaysRemovedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true,
+	"set": true, "animate": true, "animatemotion": true, "animatetransform": true, "animatecolor": true,
+}
+
+// Policy adjusts the default sanitization rules. A nil *Policy applies the
+// defaults unchanged. All names must be lower-case.
+type Policy struct {
+	// RemoveTags lists elements removed with their content in addition to the defaults.
+	RemoveTags map[string]bool
+	// KeepTags lists default-removed elements to keep; script, style and noscript are always removed.
+	KeepTags map[string]bool
+	// RemoveAttrs lists attributes stripped from every element in addition to the defaults.
+	RemoveAttrs map[string]bool
+	// PreserveAttributeOrder restores the source order of attributes with RestoreAttributeOrder.
+	PreserveAttributeOrder bool
+}
+
+// removesTag reports whether tag is removed under the policy.
+func (pol *Policy) removesTag(tag string) bool {
+	if pol == nil {
+		return tagsToRemoveMap[tag]
+	}
+	if pol.RemoveTags[tag] || alwaysRemovedTags[tag] {
+		return true
+	}
+	return tagsToRemoveMap[tag] && !pol.KeepTags[tag]
+}
+
+// removesAttr reports whether the policy strips the lower-cased attribute key.
+func (pol *Policy) removesAttr(key string) bool {
+	return pol != nil && pol.RemoveAttrs[key]
+}
+
 func SanitizeHTML(htmlContent string) string {
 	return SanitizeHTMLWithAudit(htmlContent, NoOpAuditRecorder{})
 }
@@ -95,12 +133,18 @@ func SanitizeDOM(doc *html.Node, audit AuditRecorder) {
 	if doc == nil {
 		return
 	}
-	sanitizeNodeWithAudit(doc, audit)
+	sanitizeNodeWithAudit(doc, audit, nil)
 }
 
 // SanitizeHTMLWithAudit sanitizes HTML content and records security events.
 // The audit recorder receives events for blocked tags, attributes, and URLs.
 func SanitizeHTMLWithAudit(htmlContent string, audit AuditRecorder) string {
+	return SanitizeHTMLWithPolicy(htmlContent, audit, nil)
+}
+
+// SanitizeHTMLWithPolicy is like SanitizeHTMLWithAudit but applies pol on top
+// of the default rules.
+func SanitizeHTMLWithPolicy(htmlContent string, audit AuditRecorder, pol *Policy) string {
 	if htmlContent == "" {
 		return ""
 	}
@@ -109,8 +153,11 @@ func SanitizeHTMLWithAudit(htmlContent string, audit AuditRecorder) string {
 	if err != nil {
 		return ""
 	}
+	if pol != nil && pol.PreserveAttributeOrder {
+		RestoreAttributeOrder(htmlContent, doc)
+	}
 
-	sanitizeNodeWithAudit(doc, audit)
+	sanitizeNodeWithAudit(doc, audit, pol)
 
 	// Find body element and extract its content properly
 	body := findBodyElement(doc)
@@ -141,23 +188,35 @@ func SanitizeHTMLWithAudit(htmlContent string, audit AuditRecorder) string {
 	return buf.String()
 }
 
-// findBodyElement locates the body element in the parsed HTML document
+// findBodyElement locates the body element in the parsed HTML document, either
+// directly under the document node or under its <html> element.
 func findBodyElement(doc *html.Node) *html.Node {
 	if doc.Type != html.DocumentNode {
 		return nil
 	}
 	for child := doc.FirstChild; child != nil; child = child.NextSibling {
-		if child.Type == html.ElementNode && child.Data == "body" {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		if child.Data == "body" {
 			return child
 		}
+		// html.Parse always nests <body> under <html>.
+		if child.Data == "html" {
+			for c := child.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && c.Data == "body" {
+					return c
+				}
+			}
+		}
 	}
 	return nil
 }
 
-func sanitizeNodeWithAudit(n *html.Node, audit AuditRecorder) {
+func sanitizeNodeWithAudit(n *html.Node, audit AuditRecorder, pol *Policy) {
 	if n.Type == html.ElementNode {
 		tagName := strings.ToLower(n.Data)
-		if tagsToRemoveMap[tagName] {
+		if pol.removesTag(tagName) {
 			audit.RecordBlockedTag(n.Data)
 			removeNode(n)
 			return
@@ -178,7 +237,7 @@ func sanitizeNodeWithAudit(n *html.Node, audit AuditRecorder) {
 					modified = true
 					continue
 				}
-				if dangerousAttributes[attrKey] {
+				if dangerousAttributes[attrKey] || pol.removesAttr(attrKey) {
 					audit.RecordBlockedAttr(attr.Key, attr.Val)
 					modified = true
 					continue
@@ -219,7 +278,7 @@ func sanitizeNodeWithAudit(n *html.Node, audit AuditRecorder) {
 	child := n.FirstChild
 	for child != nil {
 		next := child.NextSibling
-		sanitizeNodeWithAudit(child, audit)
+		sanitizeNodeWithAudit(child, audit, pol)
 		child = next
 	}
 }
@@ -401,7 +460,7 @@ func isSafeURIWithAudit(uri string, audit AuditRecorder) bool {
 	normalized := normalizeURIForSecurity(uri)
 
 	trimmed := strings.TrimSpace(normalized)
-	lowerURI := strings.ToLower(trimmed)
+	lowerURI := strings.ToLower(stripURLWhitespace(trimmed))
 
 	// SECURITY: Check for dangerous schemes with multiple Unicode attack vectors
 
@@ -425,8 +484,8 @@ func isSafeURIWithAudit(uri string, audit AuditRecorder) bool {
 
 	// Check for dangerous protocol-relative URL patterns
 	// Block //javascript:, //vbscript:, etc. with potential whitespace bypass
-	if strings.HasPrefix(trimmed, "//") {
-		restLower := strings.ToLower(strings.TrimLeft(trimmed[2:], " \t\n\r"))
+	if strings.HasPrefix(lowerURI, "//") {
+		restLower := strings.TrimLeft(lowerURI[2:], " ")
 		if isDangerousScheme(restLower, "javascript:") ||
 			isDangerousScheme(restLower, "vbscript:") ||
 			isDangerousScheme(restLower, "data:") ||
@@ -451,6 +510,23 @@ func isSafeURIWithAudit(uri string, audit AuditRecorder) bool {
 	return true
 }
 
+// stripURLWhitespace removes what browsers ignore when parsing a URL: the C0
+// control characters and spaces around it, and ASCII tab, line feed and
+// carriage return anywhere in it, so that "java\tscript:" is recognized as
+// the javascript: scheme it runs as.
+func stripURLWhitespace(uri string) string {
+	uri = strings.TrimFunc(uri, func(r rune) bool { return r <= ' ' })
+	if !strings.ContainsAny(uri, "\t\n\r") {
+		return uri
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, uri)
+}
+
 // normalizeURIForSecurity applies security-focused normalization to URIs.
 // This helps prevent Unicode-based bypass attacks.
 func normalizeURIForSecurity(uri string) string {
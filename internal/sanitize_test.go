@@ -835,10 +835,8 @@ func TestFindBodyElement(t *testing.T) {
 
 	t.Run("document with direct body child returns body node", func(t *testing.T) {
 		t.Parallel()
-		// findBodyElement only walks the DocumentNode's *direct* children, so the
-		// success branch is only reachable when <body> is a top-level child. A full
-		// document parsed via html.Parse nests <body> under <html> (covered by the
-		// no-body case below), so construct this structure directly.
+		// A <body> that is a direct child of the DocumentNode is found as well as
+		// the <html>-nested one html.Parse produces; construct the former directly.
 		doc := &html.Node{Type: html.DocumentNode}
 		body := &html.Node{Type: html.ElementNode, Data: "body"}
 		doc.AppendChild(body)
@@ -875,3 +873,19 @@ func TestFindBodyElement(t *testing.T) {
 		}
 	})
 }
+
+func TestFindBodyElementParsedDocument(t *testing.T) {
+	t.Parallel()
+
+	doc, err := html.Parse(strings.NewReader(`<html><head><title>T</title></head><body><p>x</p></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := findBodyElement(doc)
+	if body == nil || body.Data != "body" {
+		t.Fatalf("findBodyElement = %v, want the <body> nested under <html>", body)
+	}
+	if got := SanitizeHTML(`<html><head><title>T</title></head><body><p>x</p></body></html>`); got != "<p>x</p>" {
+		t.Errorf("SanitizeHTML() = %q, want only the body contents", got)
+	}
+}
@@ -43,6 +43,14 @@ type ScoringConfig struct {
 	SubstringRemovePatterns map[string]bool
 	// TagScores maps tag names to their base scores.
 	TagScores map[string]int
+	// MinTextLength is the text length in bytes below which a node is penalized
+	// as too short. 0 uses the default of 50.
+	MinTextLength int
+	// FoldCase applies Unicode case folding to class/id values before matching.
+	FoldCase bool
+	// FoldAccents strips accents from class/id values before matching, so a
+	// class such as "commént" matches the "comment" pattern.
+	FoldAccents bool
 }
 
 // DefaultScoringConfig returns the default scoring configuration.
@@ -189,7 +197,7 @@ func (s *DefaultScorer) Score(node *html.Node) int {
 	score := s.getTagScore(node.Data) + s.scoreAttributes(node)
 
 	// Collect all metrics in a single traversal
-	metrics := collectContentMetrics(node)
+	metrics := collectContentMetrics(node, false)
 
 	// Score based on paragraph count
 	if metrics.paragraphCount >= minParagraphsForBonus {
@@ -205,15 +213,19 @@ func (s *DefaultScorer) Score(node *html.Node) int {
 
 	// Score based on text length
 	textLength := metrics.textLength
+	minTextLength := shortTextThreshold
+	if s != nil && s.config != nil && s.config.MinTextLength > 0 {
+		minTextLength = s.config.MinTextLength
+	}
 	switch {
+	case textLength < minTextLength:
+		score += shortTextPenalty
 	case textLength > veryLongTextThreshold:
 		score += veryLongTextThreshold + (textLength-veryLongTextThreshold)/veryLongTextBonusMultiplier
 	case textLength > longTextThreshold:
 		score += textLength / longTextBonusDivider
 	case textLength > mediumTextThreshold:
 		score += textLength / mediumTextBonusDivider
-	case textLength < shortTextThreshold:
-		score += shortTextPenalty
 	}
 
 	// Apply content density multiplier
@@ -271,7 +283,7 @@ func (s *DefaultScorer) ShouldRemove(node *html.Node) bool {
 			if primaryContent {
 				continue
 			}
-			lowerVal := strings.ToLower(attr.Val)
+			lowerVal := s.normalizeAttrValue(attr.Val)
 			for pattern := range s.config.RemovePatterns {
 				if hasWordBoundary(lowerVal, pattern, boundaryStandard) {
 					return true
@@ -346,7 +358,7 @@ func (s *DefaultScorer) scoreAttributes(n *html.Node) int {
 	for _, attr := range n.Attr {
 		switch attr.Key {
 		case "class", "id":
-			lowerVal := strings.ToLower(attr.Val)
+			lowerVal := s.normalizeAttrValue(attr.Val)
 			score += s.calculatePatternScore(lowerVal, s.config.PositiveStrongPatterns)
 			score += s.calculatePatternScore(lowerVal, s.config.PositiveMediumPatterns)
 			score += s.calculatePatternScore(lowerVal, s.config.NegativeStrongPatterns)
@@ -365,6 +377,15 @@ func (s *DefaultScorer) scoreAttributes(n *html.Node) int {
 	return score
 }
 
+// normalizeAttrValue lower-cases a class/id value for pattern matching,
+// applying the configured case and accent folding.
+func (s *DefaultScorer) normalizeAttrValue(val string) string {
+	if s.config.FoldCase || s.config.FoldAccents {
+		val = FoldString(val, s.config.FoldCase, s.config.FoldAccents)
+	}
+	return strings.ToLower(val)
+}
+
 // calculatePatternScore calculates score based on pattern matching.
 // Optimized with sparse character tracking instead of fixed array iteration,
 // and prefix filtering to only check patterns whose first character appears in value.
@@ -34,12 +34,18 @@ type contentMetrics struct {
 	totalTextLength int
 	tagCount        int
 	commaCount      int
+	// wordCount and linkWordCount are only collected on request, and skip text
+	// inside non-content elements (script, style, ...) of the subtree,
+	// matching what text extraction emits.
+	wordCount     int
+	linkWordCount int
 }
 
 // collectContentMetrics collects all scoring metrics in a single DOM traversal.
 // This is more efficient than calling separate functions for each metric.
 // Optimized with inline NBSP handling to avoid function call overhead.
-func collectContentMetrics(node *html.Node) contentMetrics {
+// The word counts, which scoring does not need, are collected when words is set.
+func collectContentMetrics(node *html.Node, words bool) contentMetrics {
 	var metrics contentMetrics
 
 	WalkNodes(node, func(n *html.Node) bool {
@@ -80,13 +86,35 @@ func collectContentMetrics(node *html.Node) contentMetrics {
 				metrics.totalTextLength += len(text)
 				metrics.commaCount += strings.Count(text, ",") + strings.Count(text, "，")
 
-				// Check if this text is inside a link
+				// Check if this text is inside a link anywhere above it, and
+				// inside a link or a non-content element within node.
+				inLink, linkInside, hidden, inside := false, false, false, true
 				for parent := n.Parent; parent != nil; parent = parent.Parent {
-					if parent.Type == html.ElementNode && parent.Data == "a" {
-						metrics.linkTextLength += len(text)
+					if parent.Type == html.ElementNode {
+						if parent.Data == "a" {
+							inLink = true
+							linkInside = linkInside || inside
+						} else if words && inside && IsNonContentElement(parent.Data) {
+							hidden = true
+						}
+					}
+					if parent == node {
+						inside = false
+					}
+					if inLink && (hidden || !inside || !words) {
 						break
 					}
 				}
+				if inLink {
+					metrics.linkTextLength += len(text)
+				}
+				if words && !hidden {
+					count := CountWords(text)
+					metrics.wordCount += count
+					if linkInside {
+						metrics.linkWordCount += count
+					}
+				}
 			}
 		}
 		return true
@@ -118,6 +146,20 @@ func calculateLinkDensityFromMetrics(m contentMetrics) float64 {
 	return float64(m.linkTextLength) / float64(m.totalTextLength)
 }
 
+// CalculateLinkWordDensity returns the ratio of words inside <a> elements to all
+// words under n. Text inside non-content elements (script, style, ...) is ignored,
+// matching what text extraction emits.
+func CalculateLinkWordDensity(n *html.Node) float64 {
+	if n == nil {
+		return 0
+	}
+	m := collectContentMetrics(n, true)
+	if m.wordCount == 0 {
+		return 0
+	}
+	return float64(m.linkWordCount) / float64(m.wordCount)
+}
+
 // MatchesPattern checks if value contains any pattern from the map with word boundaries.
 // This is exported for testing purposes.
 func MatchesPattern(value string, patterns map[string]bool) bool {
@@ -135,7 +177,7 @@ func CalculateContentDensity(n *html.Node) float64 {
 	if n == nil {
 		return 0
 	}
-	metrics := collectContentMetrics(n)
+	metrics := collectContentMetrics(n, false)
 	return calculateDensityFromMetrics(metrics)
 }
 
@@ -983,6 +983,24 @@ func TestDefaultScorerScore(t *testing.T) {
 			t.Errorf("Score(article with content) = %d, want > 0", score)
 		}
 	})
+
+	t.Run("MinTextLength moves the short text penalty", func(t *testing.T) {
+		scoreWith := func(minTextLength int, text string) int {
+			config := DefaultScoringConfig()
+			config.MinTextLength = minTextLength
+			doc, _ := html.Parse(strings.NewReader(`<div><p>` + text + `</p></div>`))
+			return NewDefaultScorerWithConfig(config).Score(FindElementByTag(doc, "div"))
+		}
+
+		short := "A terse product blurb."
+		if def, lowered := scoreWith(0, short), scoreWith(10, short); lowered <= def {
+			t.Errorf("Score with MinTextLength 10 = %d, want above default %d", lowered, def)
+		}
+		medium := strings.Repeat("Some words. ", 10)
+		if def, raised := scoreWith(0, medium), scoreWith(200, medium); raised >= def {
+			t.Errorf("Score with MinTextLength 200 = %d, want below default %d", raised, def)
+		}
+	})
 }
 
 func TestScoringConfigDefaults(t *testing.T) {
@@ -1036,3 +1054,32 @@ func TestSharedDefaultScorer(t *testing.T) {
 		t.Fatal("NewDefaultScorer() returned nil")
 	}
 }
+
+func TestCalculateLinkWordDensity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		html string
+		want float64
+	}{
+		{"all links", `<div><a href="#">one two</a> <a href="#">three four</a></div>`, 1},
+		{"half links", `<div><p>one two</p><a href="#">three four</a></div>`, 0.5},
+		{"no links", `<p>one two three</p>`, 0},
+		{"script ignored", `<div><a href="#">one</a><script>var a = 1; var b = 2;</script></div>`, 1},
+		{"empty", `<div></div>`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := html.Parse(strings.NewReader(tt.html))
+			if got := CalculateLinkWordDensity(doc); got != tt.want {
+				t.Errorf("CalculateLinkWordDensity() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+
+	if got := CalculateLinkWordDensity(nil); got != 0 {
+		t.Errorf("CalculateLinkWordDensity(nil) = %f, want 0", got)
+	}
+}
@@ -2,7 +2,7 @@
 package table
 
 import (
-	"strings"
+	"io"
 )
 
 // CellAlignment represents the text alignment of a table cell.
@@ -46,29 +46,59 @@ type AlignCount struct {
 	Left, Center, Right, Justify, DefaultCount int
 }
 
-// TrackedBuilder is a strings.Builder that tracks the last written character.
+// Writer is the destination of a TrackedBuilder. Both *strings.Builder and
+// *bytes.Buffer satisfy it.
+type Writer interface {
+	io.Writer
+	io.ByteWriter
+	io.StringWriter
+	Len() int
+	String() string
+}
+
+// TrackedBuilder is a Writer that tracks the last written character.
 type TrackedBuilder struct {
-	*strings.Builder
+	w        Writer
 	LastChar byte
 }
 
-// NewTrackedBuilder creates a new TrackedBuilder wrapping the provided strings.Builder.
-func NewTrackedBuilder(sb *strings.Builder) *TrackedBuilder {
-	return &TrackedBuilder{
-		Builder:  sb,
+// NewTrackedBuilder creates a new TrackedBuilder wrapping the provided Writer.
+func NewTrackedBuilder(w Writer) *TrackedBuilder {
+	tb := &TrackedBuilder{
+		w:        w,
 		LastChar: 0,
 	}
+	// Resume tracking when appending to a builder that already has content.
+	// String copies the contents of a *bytes.Buffer, so read its bytes instead.
+	if n := w.Len(); n > 0 {
+		if b, ok := w.(interface{ Bytes() []byte }); ok {
+			tb.LastChar = b.Bytes()[n-1]
+		} else {
+			tb.LastChar = w.String()[n-1]
+		}
+	}
+	return tb
+}
+
+// Len returns the number of bytes written to the underlying Writer.
+func (tb *TrackedBuilder) Len() int {
+	return tb.w.Len()
+}
+
+// Write writes p without updating the last character tracker.
+func (tb *TrackedBuilder) Write(p []byte) (int, error) {
+	return tb.w.Write(p)
 }
 
 // WriteByte writes a single byte and updates the last character tracker.
 func (tb *TrackedBuilder) WriteByte(c byte) error {
 	tb.LastChar = c
-	return tb.Builder.WriteByte(c)
+	return tb.w.WriteByte(c)
 }
 
 // WriteString writes a string and updates the last character tracker.
 func (tb *TrackedBuilder) WriteString(s string) (int, error) {
-	n, err := tb.Builder.WriteString(s)
+	n, err := tb.w.WriteString(s)
 	if n > 0 && err == nil {
 		tb.LastChar = s[len(s)-1]
 	}
@@ -144,6 +144,81 @@ func ResolveURL(baseURL, relativeURL string) string {
 	return baseURL + relativeURL
 }
 
+// IsSchemelessDomain reports whether ref looks like a host name written
+// without a scheme, such as "www.example.com/page" or "example.com/x", rather
+// than a relative path. The host must be made of dot-separated labels of
+// letters, digits and hyphens ending in an alphabetic top-level domain, and
+// either start with "www." or be followed by a path, so that a relative file
+// reference like "page.html" or "folder/x" does not qualify. A top-level
+// domain that is a common file extension, as in "index.php/x", also needs the
+// "www." prefix. A port is allowed.
+func IsSchemelessDomain(ref string) bool {
+	end := strings.IndexAny(ref, "/?#")
+	if end < 0 {
+		end = len(ref)
+	}
+	host := ref[:end]
+	www := len(host) >= 4 && strings.EqualFold(host[:4], "www.")
+	if !www && (end == len(ref) || ref[end] != '/') {
+		return false
+	}
+	if h, port, found := strings.Cut(host, ":"); found {
+		if port == "" || strings.Trim(port, "0123456789") != "" {
+			return false
+		}
+		host = h
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	tld := labels[len(labels)-1]
+	if len(tld) < 2 {
+		return false
+	}
+	for i := 0; i < len(tld); i++ {
+		if c := tld[i]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return www || !fileExtensionTLDs[strings.ToLower(tld)]
+}
+
+// fileExtensionTLDs are file extensions seen in relative references such as
+// "index.php/x" or "report.html/print", some of which are also real
+// country-code domains (.pl, .sh, .py).
+var fileExtensionTLDs = map[string]bool{
+	"php": true, "php3": true, "php4": true, "php5": true, "phtml": true,
+	"html": true, "htm": true, "xhtml": true, "shtml": true,
+	"asp": true, "aspx": true, "ashx": true, "jsp": true, "jspx": true, "do": true, "action": true,
+	"cgi": true, "pl": true, "py": true, "rb": true, "sh": true,
+	"js": true, "mjs": true, "css": true, "json": true, "xml": true, "txt": true, "md": true,
+	"pdf": true, "png": true, "jpg": true, "jpeg": true, "gif": true, "svg": true, "webp": true,
+	"zip": true, "gz": true,
+}
+
+// WithBaseScheme prefixes the scheme-less host reference ref with the scheme
+// of baseURL, "https://" for an https base and "http://" otherwise, the way
+// ResolveURL treats protocol-relative URLs.
+func WithBaseScheme(baseURL, ref string) string {
+	if strings.HasPrefix(baseURL, "https:") {
+		return "https://" + ref
+	}
+	return "http://" + ref
+}
+
 // asDirectoryBase ensures baseURL is suitable for appending a relative path to.
 // If it already ends in '/', it is returned unchanged. Otherwise the last path
 // segment is dropped (file-style base → its directory). For an authority with
@@ -166,12 +241,22 @@ func asDirectoryBase(baseURL string) string {
 // IsDifferentDomain checks if two URLs have different domains.
 // Returns false if either URL is not external.
 func IsDifferentDomain(baseURL, targetURL string) bool {
+	return IsDifferentDomainFold(baseURL, targetURL, false, false)
+}
+
+// IsDifferentDomainFold is like IsDifferentDomain but folds both hosts with
+// FoldString before comparing them.
+func IsDifferentDomainFold(baseURL, targetURL string, foldCase, foldAccents bool) bool {
 	if !IsExternalURL(baseURL) || !IsExternalURL(targetURL) {
 		return false
 	}
 
 	baseDomain := ExtractDomain(baseURL)
 	targetDomain := ExtractDomain(targetURL)
+	if foldCase || foldAccents {
+		baseDomain = FoldString(baseDomain, foldCase, foldAccents)
+		targetDomain = FoldString(targetDomain, foldCase, foldAccents)
+	}
 
 	return baseDomain != targetDomain
 }
@@ -496,3 +496,41 @@ func TestIsDifferentDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSchemelessDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"example.com/x", true},
+		{"www.example.com", true},
+		{"WWW.Example.COM?q=1", true},
+		{"www.example.com/page", true},
+		{"sub.example.co.uk/a/b", true},
+		{"example.com:8080/x", true},
+		{"folder/x", false},
+		{"x", false},
+		{"page.html", false},
+		{"index.php/x", false},
+		{"report.html/print", false},
+		{"app.JS/main", false},
+		{"www.example.pl/x", true},
+		{"example.com", false},
+		{"./example.com/x", false},
+		{"../x", false},
+		{"/example.com/x", false},
+		{"v1.2/notes", false},
+		{"example.com:http/x", false},
+		{"-bad.com/x", false},
+		{"mailto:user@example.com", false},
+		{"https://example.com/x", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsSchemelessDomain(tt.ref); got != tt.want {
+			t.Errorf("IsSchemelessDomain(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
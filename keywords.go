@@ -0,0 +1,194 @@
+package html
+
+// keywords.go counts the most frequent terms of the extracted text for
+// ComputeKeywords, and its words other than stop words for
+// CountSignificantWords.
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxKeywords is how many terms Result.Keywords holds at most.
+const maxKeywords = 20
+
+// minKeywordLength is the minimum length in runes of a counted term.
+const minKeywordLength = 3
+
+// englishStopWords are common English words that carry no topic.
+var englishStopWords = map[string]bool{
+	"a": true, "about": true, "after": true, "all": true, "also": true, "an": true,
+	"and": true, "any": true, "are": true, "as": true, "at": true, "be": true,
+	"because": true, "been": true, "before": true, "being": true, "between": true, "both": true,
+	"but": true, "by": true, "can": true, "could": true, "did": true, "do": true,
+	"does": true, "each": true, "for": true, "from": true, "had": true, "has": true,
+	"have": true, "he": true, "her": true, "here": true, "his": true, "how": true,
+	"if": true, "in": true, "into": true, "is": true, "it": true, "its": true,
+	"just": true, "more": true, "most": true, "no": true, "not": true, "now": true,
+	"of": true, "on": true, "only": true, "or": true, "other": true, "our": true,
+	"out": true, "over": true, "she": true, "should": true, "so": true, "some": true,
+	"such": true, "than": true, "that": true, "the": true, "their": true, "them": true,
+	"then": true, "there": true, "these": true, "they": true, "this": true, "those": true,
+	"through": true, "to": true, "too": true, "under": true, "up": true, "very": true,
+	"was": true, "we": true, "were": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "who": true, "why": true, "will": true, "with": true,
+	"would": true, "you": true, "your": true,
+}
+
+// defaultStopWords holds the built-in stop words by primary language subtag.
+var defaultStopWords = map[string]map[string]bool{
+	"en": englishStopWords,
+	"de": {
+		"aber": true, "als": true, "am": true, "auch": true, "auf": true, "aus": true,
+		"bei": true, "bis": true, "da": true, "das": true, "dass": true, "dem": true,
+		"den": true, "der": true, "des": true, "die": true, "doch": true, "du": true,
+		"durch": true, "ein": true, "eine": true, "einem": true, "einen": true, "einer": true,
+		"eines": true, "er": true, "es": true, "für": true, "hat": true, "haben": true,
+		"ich": true, "ihr": true, "im": true, "in": true, "ist": true, "mit": true,
+		"nach": true, "nicht": true, "noch": true, "nur": true, "oder": true, "sich": true,
+		"sie": true, "sind": true, "so": true, "über": true, "um": true, "und": true,
+		"unter": true, "vom": true, "von": true, "vor": true, "war": true, "wie": true,
+		"wir": true, "wird": true, "werden": true, "zu": true, "zum": true, "zur": true,
+	},
+	"es": {
+		"al": true, "como": true, "con": true, "de": true, "del": true, "el": true,
+		"en": true, "es": true, "esta": true, "este": true, "fue": true, "ha": true,
+		"han": true, "la": true, "las": true, "le": true, "lo": true, "los": true,
+		"más": true, "no": true, "o": true, "para": true, "pero": true, "por": true,
+		"que": true, "se": true, "ser": true, "sin": true, "son": true, "su": true,
+		"sus": true, "también": true, "un": true, "una": true, "unas": true, "unos": true,
+		"y": true, "ya": true,
+	},
+	"fr": {
+		"au": true, "aux": true, "avec": true, "ce": true, "ces": true, "cette": true,
+		"dans": true, "de": true, "des": true, "du": true, "elle": true, "elles": true,
+		"en": true, "est": true, "et": true, "été": true, "être": true, "il": true,
+		"ils": true, "la": true, "le": true, "les": true, "leur": true, "mais": true,
+		"ne": true, "nous": true, "ont": true, "ou": true, "par": true, "pas": true,
+		"plus": true, "pour": true, "qui": true, "que": true, "sa": true, "se": true,
+		"ses": true, "son": true, "sont": true, "sur": true, "un": true, "une": true,
+		"vous": true,
+	},
+}
+
+// DefaultStopWords returns a copy of the built-in stop words for lang, a
+// language tag such as "de" or "fr-CA" matched on its primary subtag, so that
+// they can be extended into Config.StopWords. English, French, German and
+// Spanish are built in; other and empty tags get the English list.
+func DefaultStopWords(lang string) map[string]bool {
+	words := stopWordsFor(lang)
+	copied := make(map[string]bool, len(words))
+	for word := range words {
+		copied[word] = true
+	}
+	return copied
+}
+
+// stopWordsFor returns the built-in stop words for lang, not to be modified.
+func stopWordsFor(lang string) map[string]bool {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(lang)), "-")
+	if words, ok := defaultStopWords[primary]; ok {
+		return words
+	}
+	return englishStopWords
+}
+
+// stopWordSet returns the lower-cased words set to true in words, or nil when
+// words is nil so that the built-in lists apply. An empty, non-nil result
+// disables stop words.
+func stopWordSet(words map[string]bool) map[string]bool {
+	if words == nil {
+		return nil
+	}
+	set := make(map[string]bool, len(words))
+	for word, stop := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); stop && word != "" {
+			set[word] = true
+		}
+	}
+	return set
+}
+
+// documentStopWords returns Config.StopWords when set, and otherwise the
+// built-in stop words for the language declared on the <html> element of doc.
+func (p *Processor) documentStopWords(doc *stdxhtml.Node) map[string]bool {
+	if p.stopWords != nil {
+		return p.stopWords
+	}
+	var lang string
+	if root := internal.FindElementByTag(doc, "html"); root != nil {
+		lang, _ = declaredLang(root)
+	}
+	return stopWordsFor(lang)
+}
+
+// computeKeywords returns the maxKeywords most frequent terms of text, most
+// frequent first and alphabetically among equal counts. Terms are runs of
+// letters and digits, lower-cased; stopWords, numbers and terms shorter than
+// minKeywordLength are skipped.
+func computeKeywords(text string, stopWords map[string]bool) []KeywordCount {
+	counts := make(map[string]int)
+	for _, term := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(term) < minKeywordLength || stopWords[term] || isNumeric(term) {
+			continue
+		}
+		counts[term]++
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	keywords := make([]KeywordCount, 0, len(counts))
+	for term, count := range counts {
+		keywords = append(keywords, KeywordCount{Term: term, Count: count})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Count != keywords[j].Count {
+			return keywords[i].Count > keywords[j].Count
+		}
+		return keywords[i].Term < keywords[j].Term
+	})
+	if len(keywords) > maxKeywords {
+		keywords = keywords[:maxKeywords]
+	}
+	return keywords
+}
+
+// significantWordCount counts the words of text that are not in stopWords.
+// Words are whitespace-separated and compared lower-cased without their
+// surrounding punctuation; punctuation-only words are not counted. Text with
+// CJK characters is counted as in WordCount, one word per ideograph or kana,
+// without stop words.
+func significantWordCount(text string, stopWords map[string]bool) int {
+	count := 0
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, internal.IsCJKRune) >= 0 {
+			count += internal.CountWords(field)
+			continue
+		}
+		word := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if word != "" && !stopWords[strings.ToLower(word)] {
+			count++
+		}
+	}
+	return count
+}
+
+// isNumeric reports whether term consists of digits only.
+func isNumeric(term string) bool {
+	for _, r := range term {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
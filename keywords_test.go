@@ -0,0 +1,115 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestComputeKeywords(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<h1>Parsing HTML in Go</h1>
+		<p>The parser reads HTML and the parser builds a tree. Parsing HTML is fast.</p>
+		<p>In 2024 the Parser gained streaming; a tree is still built for each document.</p>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.Keywords != nil {
+		t.Errorf("Keywords without ComputeKeywords = %v, want nil", result.Keywords)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ComputeKeywords = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := []html.KeywordCount{
+		{Term: "html", Count: 3},
+		{Term: "parser", Count: 3},
+		{Term: "parsing", Count: 2},
+		{Term: "tree", Count: 2},
+	}
+	if len(result.Keywords) < len(want) || !reflect.DeepEqual(result.Keywords[:len(want)], want) {
+		t.Fatalf("Keywords = %v, want prefix %v", result.Keywords, want)
+	}
+	for _, kw := range result.Keywords {
+		switch kw.Term {
+		case "the", "and", "2024", "go", "in", "is":
+			t.Errorf("Keywords contains %q, want stop words, numbers and short terms skipped", kw.Term)
+		}
+	}
+}
+
+func TestStopWords(t *testing.T) {
+	t.Parallel()
+
+	doc := func(lang string) []byte {
+		return []byte(`<html lang="` + lang + `"><body><article>
+			<p>Die Katze und der Hund schlafen, und die Katze träumt von dem Garten.</p>
+		</article></body></html>`)
+	}
+	terms := func(keywords []html.KeywordCount) map[string]int {
+		got := make(map[string]int, len(keywords))
+		for _, kw := range keywords {
+			got[kw.Term] = kw.Count
+		}
+		return got
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ComputeKeywords = true
+	cfg.CountSignificantWords = true
+
+	result, err := html.Extract(doc("de-AT"), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	got := terms(result.Keywords)
+	if got["katze"] != 2 || got["die"] != 0 || got["und"] != 0 || got["dem"] != 0 {
+		t.Errorf("Keywords = %v, want German stop words skipped", result.Keywords)
+	}
+	// Katze, Hund, schlafen, Katze, träumt, Garten.
+	if result.SignificantWordCount != 6 || result.WordCount != 13 {
+		t.Errorf("SignificantWordCount = %d, WordCount = %d, want 6 and 13", result.SignificantWordCount, result.WordCount)
+	}
+
+	result, err = html.Extract(doc("en"), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if got := terms(result.Keywords); got["die"] != 2 || got["und"] != 2 {
+		t.Errorf("Keywords = %v, want German words kept with English stop words", result.Keywords)
+	}
+
+	cfg.StopWords = html.DefaultStopWords("de")
+	cfg.StopWords["KATZE"] = true
+	result, err = html.Extract(doc("en"), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if got := terms(result.Keywords); got["katze"] != 0 || got["die"] != 0 || got["hund"] != 1 {
+		t.Errorf("Keywords = %v, want custom stop words skipped", result.Keywords)
+	}
+	if result.SignificantWordCount != 4 {
+		t.Errorf("SignificantWordCount = %d, want 4", result.SignificantWordCount)
+	}
+	if html.DefaultStopWords("de")["katze"] {
+		t.Error("DefaultStopWords() returned the shared built-in list")
+	}
+
+	cfg.StopWords = map[string]bool{}
+	result, err = html.Extract(doc("de"), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.SignificantWordCount != result.WordCount {
+		t.Errorf("SignificantWordCount = %d, want WordCount %d with stop words disabled", result.SignificantWordCount, result.WordCount)
+	}
+}
@@ -4,6 +4,7 @@ package html
 
 import (
 	"context"
+	"errors"
 	"runtime"
 	"strings"
 	"sync"
@@ -414,6 +415,52 @@ func TestProcessorDoubleClose(t *testing.T) {
 	}
 }
 
+// TestProcessorReset tests that Reset clears the cache and statistics and
+// reopens a closed processor.
+func TestProcessorReset(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CacheCleanup = 10 * time.Millisecond
+	processor, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create processor: %v", err)
+	}
+	defer processor.Close()
+
+	doc := []byte("<html><body><p>Reset me</p></body></html>")
+	if _, err := processor.Extract(doc); err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if processor.cache.Len() == 0 {
+		t.Fatal("expected a cached result before Reset")
+	}
+
+	processor.Reset()
+	if n := processor.cache.Len(); n != 0 {
+		t.Errorf("cache has %d entries after Reset, want 0", n)
+	}
+	if stats := processor.GetStatistics(); stats != (Statistics{}) {
+		t.Errorf("GetStatistics() after Reset = %+v, want zero", stats)
+	}
+
+	if err := processor.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	if _, err := processor.Extract(doc); !errors.Is(err, ErrProcessorClosed) {
+		t.Fatalf("Extract() after Close error = %v, want ErrProcessorClosed", err)
+	}
+	processor.Reset()
+	result, err := processor.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() after Reset failed: %v", err)
+	}
+	if result.Text != "Reset me" {
+		t.Errorf("Text = %q, want %q", result.Text, "Reset me")
+	}
+	if stats := processor.GetStatistics(); stats.TotalProcessed != 1 {
+		t.Errorf("TotalProcessed = %d, want 1", stats.TotalProcessed)
+	}
+}
+
 // TestConcurrentCloseAndExtract tests safety of concurrent Close() and Extract() calls.
 func TestConcurrentCloseAndExtract(t *testing.T) {
 	processor, err := New()
@@ -0,0 +1,89 @@
+package html
+
+// linkpreview.go assembles the fields of a social or chat link card from the
+// metadata-only fast path.
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+)
+
+// LinkPreview holds what is needed to render a link card for a page.
+type LinkPreview struct {
+	// Title is the og:title, falling back to the document title as in Metadata.Title.
+	Title string `json:"title"`
+	// Description is the meta description, falling back to og:description.
+	Description string `json:"description,omitempty"`
+	// Image is the og:image, falling back to twitter:image and then to the main image of the
+	// body as in Result.MainImage, resolved against the page URL.
+	Image string `json:"image,omitempty"`
+	// SiteName is the og:site_name, falling back to the host of the page URL.
+	SiteName string `json:"site_name,omitempty"`
+	// Favicon is the best icon declared by the page, or /favicon.ico, as in ExtractFavicon.
+	Favicon string `json:"favicon,omitempty"`
+}
+
+// ExtractLinkPreview returns the link card fields of htmlContent, the page
+// fetched from pageURL. URLs are resolved against the document's <base href>,
+// itself resolved against pageURL, or else pageURL; without a pageURL, against
+// Config.BaseURL and then the base detected from the document. Like
+// ExtractMetadataOnly, only the <head> and the start of the body are parsed,
+// so the main image fallback only considers images near the top of the page.
+func (p *Processor) ExtractLinkPreview(htmlContent, pageURL string) (LinkPreview, error) {
+	return recoverPanic(func() (LinkPreview, error) {
+		if p == nil || p.closed.Load() {
+			return LinkPreview{}, ErrProcessorClosed
+		}
+		scoped, base := p, ""
+		if pageURL = strings.TrimSpace(pageURL); pageURL != "" {
+			base = fetchedBaseURL([]byte(htmlContent), pageURL)
+			scoped = p.transientProcessor(func(c *Config) { c.BaseURL = base })
+			scoped.stats = p.stats
+		}
+
+		meta, doc, err := scoped.extractMetadataOnly(htmlContent)
+		if err != nil || doc == nil {
+			return LinkPreview{}, err
+		}
+
+		preview := LinkPreview{
+			Title:       meta.Title,
+			Description: meta.Description,
+			SiteName:    strings.TrimSpace(meta.OpenGraph["og:site_name"]),
+			Favicon:     scoped.faviconURL(doc, base),
+		}
+		if title := strings.TrimSpace(meta.OpenGraph["og:title"]); title != "" {
+			preview.Title = title
+		}
+		docBase, docOrigin := scoped.documentBase(doc)
+		preview.Image = scoped.mainImage(docBase, internal.FindElementByTag(doc, "body"), meta.SocialImage, nil)
+		if preview.SiteName == "" {
+			origin := pageURL
+			if origin == "" {
+				origin = docOrigin
+			}
+			if u, err := url.Parse(origin); err == nil {
+				preview.SiteName = u.Hostname()
+			}
+		}
+		return preview, nil
+	})
+}
+
+// ExtractLinkPreview returns the link card fields of htmlContent, fetched from pageURL.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractLinkPreview for where each field comes from.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractLinkPreview(htmlContent, pageURL string, cfg ...Config) (LinkPreview, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return LinkPreview{}, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (LinkPreview, error) {
+		return p.ExtractLinkPreview(htmlContent, pageURL)
+	})
+}
@@ -0,0 +1,88 @@
+package html_test
+
+import (
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractLinkPreview(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<title>Fallback title | Example</title>
+		<meta property="og:title" content="Launch announcement">
+		<meta property="og:site_name" content="Example Blog">
+		<meta name="description" content="What we shipped this week.">
+		<meta property="og:image" content="/images/card.png">
+		<link rel="icon" href="/static/icon.svg" sizes="any">
+	</head><body><p>Body text.</p></body></html>`
+
+	preview, err := html.ExtractLinkPreview(doc, "https://example.com/blog/launch")
+	if err != nil {
+		t.Fatalf("ExtractLinkPreview() failed: %v", err)
+	}
+	want := html.LinkPreview{
+		Title:       "Launch announcement",
+		Description: "What we shipped this week.",
+		Image:       "https://example.com/images/card.png",
+		SiteName:    "Example Blog",
+		Favicon:     "https://example.com/static/icon.svg",
+	}
+	if preview != want {
+		t.Errorf("ExtractLinkPreview() = %+v, want %+v", preview, want)
+	}
+
+	t.Run("fallbacks", func(t *testing.T) {
+		doc := `<html><head><title>Plain page</title></head><body>
+			<img src="/tiny.png" width="16" height="16">
+			<img src="/photo.jpg" alt="Photo">
+		</body></html>`
+		preview, err := html.ExtractLinkPreview(doc, "https://www.example.org/page")
+		if err != nil {
+			t.Fatalf("ExtractLinkPreview() failed: %v", err)
+		}
+		want := html.LinkPreview{
+			Title:    "Plain page",
+			Image:    "https://www.example.org/photo.jpg",
+			SiteName: "www.example.org",
+			Favicon:  "https://www.example.org/favicon.ico",
+		}
+		if preview != want {
+			t.Errorf("ExtractLinkPreview() = %+v, want %+v", preview, want)
+		}
+	})
+	t.Run("base href", func(t *testing.T) {
+		doc := `<html><head><base href="https://cdn.example.com/assets/">
+			<meta property="og:image" content="img/a.png">
+			<link rel="icon" href="icon.png">
+		</head><body><p>Body text.</p></body></html>`
+		preview, err := html.ExtractLinkPreview(doc, "https://site.example/blog/post")
+		if err != nil {
+			t.Fatalf("ExtractLinkPreview() failed: %v", err)
+		}
+		if preview.Image != "https://cdn.example.com/assets/img/a.png" {
+			t.Errorf("Image = %q, want it resolved against <base href>", preview.Image)
+		}
+		if preview.Favicon != "https://cdn.example.com/assets/icon.png" {
+			t.Errorf("Favicon = %q, want it resolved against <base href>", preview.Favicon)
+		}
+		if preview.SiteName != "site.example" {
+			t.Errorf("SiteName = %q, want the page host", preview.SiteName)
+		}
+	})
+
+	t.Run("statistics", func(t *testing.T) {
+		p, err := html.New()
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		defer p.Close()
+		if _, err := p.ExtractLinkPreview(doc, "https://example.com/blog/launch"); err != nil {
+			t.Fatalf("ExtractLinkPreview() failed: %v", err)
+		}
+		if stats := p.GetStatistics(); stats.TotalProcessed != 1 {
+			t.Errorf("TotalProcessed = %d, want 1", stats.TotalProcessed)
+		}
+	})
+}
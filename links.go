@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -21,6 +22,9 @@ import (
 // (EnableSanitization has no effect here) so that resource links living inside
 // tags sanitization would otherwise strip — such as <script src>, <iframe>,
 // <link>, and <embed> — are still enumerated.
+//
+// When MaxLinks is set and the document has more unique links, extraction stops
+// and the first MaxLinks links are returned together with ErrMaxLinksExceeded.
 func (p *Processor) ExtractAllLinks(htmlBytes []byte) ([]LinkResource, error) {
 	return recoverLinks(func() ([]LinkResource, error) {
 		// Validate input
@@ -47,7 +51,8 @@ func (p *Processor) ExtractAllLinks(htmlBytes []byte) ([]LinkResource, error) {
 		var links []LinkResource
 		links, err = p.extractLinksRespectingDeadline(context.Background(), utf8String)
 
-		if err != nil {
+		// ErrMaxLinksExceeded carries a valid partial result.
+		if err != nil && !errors.Is(err, ErrMaxLinksExceeded) {
 			p.stats.errorCount.Add(1)
 			return nil, err
 		}
@@ -56,7 +61,7 @@ func (p *Processor) ExtractAllLinks(htmlBytes []byte) ([]LinkResource, error) {
 		p.stats.totalProcessTime.Add(int64(processingTime))
 		p.stats.totalProcessed.Add(1)
 
-		return links, nil
+		return links, err
 	})
 }
 
@@ -131,7 +136,8 @@ func (p *Processor) ExtractAllLinksWithContext(ctx context.Context, htmlBytes []
 		var links []LinkResource
 		links, err = p.extractLinksRespectingDeadline(ctx, utf8String)
 
-		if err != nil {
+		// ErrMaxLinksExceeded carries a valid partial result.
+		if err != nil && !errors.Is(err, ErrMaxLinksExceeded) {
 			p.stats.errorCount.Add(1)
 			return nil, err
 		}
@@ -140,7 +146,7 @@ func (p *Processor) ExtractAllLinksWithContext(ctx context.Context, htmlBytes []
 		p.stats.totalProcessTime.Add(int64(processingTime))
 		p.stats.totalProcessed.Add(1)
 
-		return links, nil
+		return links, err
 	})
 }
 
@@ -278,7 +284,7 @@ func (p *Processor) extractAllLinksFromContent(ctx context.Context, htmlContent
 
 	doc, err := stdxhtml.Parse(strings.NewReader(htmlContent))
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrInvalidHTML, err)
+		return nil, newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
 	}
 
 	// Honor cancellation between the parse (which may have consumed the bulk of
@@ -301,38 +307,95 @@ func (p *Processor) extractAllLinksFromContent(ctx context.Context, htmlContent
 	default:
 	}
 
-	baseURL := p.config.BaseURL
-	if p.config.ResolveRelativeURLs && baseURL == "" {
-		baseURL = p.detectBaseURL(doc)
+	baseURL, originURL := p.documentBase(doc)
+	if !p.config.ResolveRelativeURLs {
+		baseURL = p.config.BaseURL
+	}
+
+	// The base is detected from the whole document, but with a selector only
+	// the matching subtrees are scanned for links.
+	roots := []*stdxhtml.Node{doc}
+	if p.selector != nil {
+		if roots = p.selector.selectNodes(doc); len(roots) == 0 {
+			return nil, ErrSelectorNotMatched
+		}
 	}
 
 	linkMap := make(map[string]LinkResource, linkMapCap)
-	p.extractLinksFromDocument(doc, baseURL, linkMap)
+	truncated := false
+	for _, root := range roots {
+		if truncated = p.extractLinksFromDocument(root, baseURL, originURL, linkMap); truncated {
+			break
+		}
+	}
 
 	// Collect into a deterministic order. Map iteration order is randomized in
 	// Go, so draining the map directly yielded a different slice order on every
 	// call — making results and downstream caches non-reproducible. Sorting by
 	// URL fixes that without changing deduplication or title-selection semantics
 	// (the map already resolved each URL to its final LinkResource).
-	links := make([]LinkResource, 0, len(linkMap))
-	for _, link := range linkMap {
-		links = append(links, link)
+	if truncated {
+		return sortedLinks(linkMap), ErrMaxLinksExceeded
 	}
-	sort.Slice(links, func(i, j int) bool {
-		return links[i].URL < links[j].URL
-	})
+	return sortedLinks(linkMap), nil
+}
 
-	return links, nil
+// documentBaseURL returns Config.BaseURL when set, skipping detection and its
+// document walks entirely, and otherwise the base detected from doc.
+func (p *Processor) documentBaseURL(doc *stdxhtml.Node) string {
+	base, _ := p.documentBase(doc)
+	return base
 }
 
-// detectBaseURL attempts to detect base URL from HTML document.
-func (p *Processor) detectBaseURL(doc *stdxhtml.Node) string {
-	if baseNode := internal.FindElementByTag(doc, "base"); baseNode != nil {
-		for _, attr := range baseNode.Attr {
-			if attr.Key == "href" && attr.Val != "" {
-				return internal.NormalizeBaseURL(attr.Val)
-			}
-		}
+// documentOrigin returns the address the document is known to live at:
+// Config.BaseURL, or a declared <base href>, og:url or canonical link. Unlike
+// documentBaseURL it never guesses from the first absolute href or src, which
+// may well point to another site, so it is what links are classified against.
+func (p *Processor) documentOrigin(doc *stdxhtml.Node) string {
+	_, origin := p.documentBase(doc)
+	return origin
+}
+
+// documentBase returns both documentBaseURL and documentOrigin from a single
+// detection walk.
+func (p *Processor) documentBase(doc *stdxhtml.Node) (base, origin string) {
+	if p.config.BaseURL != "" {
+		return p.config.BaseURL, p.config.BaseURL
+	}
+	declared, guessed := p.detectBaseURLs(doc)
+	if declared != "" {
+		return declared, declared
+	}
+	return guessed, ""
+}
+
+// isExternalLink reports whether href targets a different host than the
+// document at originURL. Relative hrefs are resolved first, so they are never
+// external, and absolute links to the document's own host are not either.
+// Without an absolute origin, every absolute http(s) URL counts as external.
+func (p *Processor) isExternalLink(originURL, href string) bool {
+	return isExternalFrom(p.config, originURL, href)
+}
+
+// isExternalFrom implements isExternalLink for the FixSchemelessDomains,
+// FoldCase and FoldAccents options of cfg.
+func isExternalFrom(cfg *Config, originURL, href string) bool {
+	if cfg.FixSchemelessDomains && internal.IsSchemelessDomain(href) {
+		href = internal.WithBaseScheme(originURL, href)
+	}
+	if !internal.IsExternalURL(originURL) {
+		return internal.IsExternalURL(href)
+	}
+	return internal.IsDifferentDomainFold(originURL, internal.ResolveURL(originURL, href), cfg.FoldCase, cfg.FoldAccents)
+}
+
+// detectBaseURLs attempts to detect the base URL from an HTML document. The
+// declared base is a <base href>, then og:url, then <link rel="canonical">;
+// when none is present, guessed is the origin of the first absolute href or
+// src. Callers should prefer documentBase, which honors Config.BaseURL.
+func (p *Processor) detectBaseURLs(doc *stdxhtml.Node) (declared, guessed string) {
+	if href := firstBaseHref(doc); href != "" {
+		return internal.NormalizeBaseURL(href), ""
 	}
 
 	var canonicalURL, canonicalLink, firstAbsoluteURL string
@@ -384,58 +447,165 @@ func (p *Processor) detectBaseURL(doc *stdxhtml.Node) string {
 				}
 			}
 		}
-		return canonicalURL == "" || canonicalLink == "" || firstAbsoluteURL == ""
+		// og:url outranks the other candidates, so the walk can stop once it is found.
+		return canonicalURL == ""
 	})
 
 	if canonicalURL != "" {
-		return internal.NormalizeBaseURL(canonicalURL)
+		return internal.NormalizeBaseURL(canonicalURL), ""
 	}
 	if canonicalLink != "" {
-		return internal.NormalizeBaseURL(canonicalLink)
+		return internal.NormalizeBaseURL(canonicalLink), ""
 	}
-	return firstAbsoluteURL
+	return "", firstAbsoluteURL
+}
+
+// firstBaseHref returns the trimmed href of the first <base> element in doc
+// that has one. As in browsers, later <base href> elements are ignored, and a
+// <base> carrying only a target does not stop the search.
+func firstBaseHref(doc *stdxhtml.Node) string {
+	var href string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if href != "" {
+			return false
+		}
+		if n.Type == stdxhtml.ElementNode && n.Data == "base" {
+			href = strings.TrimSpace(internal.GetAttr(n, "href"))
+		}
+		return href == ""
+	})
+	return href
 }
 
-func (p *Processor) extractLinksFromDocument(doc *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
+// extractLinksFromDocument adds the links of every element under doc to linkMap.
+// It reports whether a new link was dropped because linkMap already held
+// MaxLinks entries; once that happens the rest of the document is skipped.
+// Links already in linkMap are still updated at the cap, so a full map does not
+// by itself count as truncation. The cap holds for every URL, including the
+// several an element can add through srcset or CSS references: of the new URLs
+// of the element crossing it, those first in URL order are kept.
+func (p *Processor) extractLinksFromDocument(doc *stdxhtml.Node, baseURL, originURL string, linkMap map[string]LinkResource) (truncated bool) {
+	maxLinks := p.config.MaxLinks
+	var pending map[string]LinkResource
+	var added []string
 	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if truncated {
+			return false
+		}
 		if n.Type != stdxhtml.ElementNode {
 			return true
 		}
+		if maxLinks <= 0 {
+			p.extractElementLinks(n, baseURL, originURL, linkMap)
+			return true
+		}
 
-		switch n.Data {
-		case "a":
-			if p.config.IncludeContentLinks || p.config.IncludeExternalLinks {
-				p.extractContentLinks(n, baseURL, linkMap)
-			}
-		case "img":
-			if p.config.IncludeImages {
-				p.extractImageLinks(n, baseURL, linkMap)
-			}
-		case "video":
-			if p.config.IncludeVideos {
-				p.extractMediaLink(n, baseURL, linkMap, "video")
-			}
-		case "audio":
-			if p.config.IncludeAudios {
-				p.extractMediaLink(n, baseURL, linkMap, "audio")
-			}
-		case "source":
-			if p.config.IncludeVideos || p.config.IncludeAudios {
-				p.extractSourceLinks(n, baseURL, linkMap)
+		// Only an element that may cross the cap needs the URLs it would add
+		// found first, so that those past the cap can be taken out again.
+		// Extracting into linkMap itself keeps the deduplication against links
+		// seen earlier exact.
+		if len(linkMap)+p.maxElementLinks(n) <= maxLinks {
+			p.extractElementLinks(n, baseURL, originURL, linkMap)
+			return true
+		}
+		if pending == nil {
+			pending = make(map[string]LinkResource, 1)
+		}
+		p.extractElementLinks(n, baseURL, originURL, pending)
+		if len(linkMap)+len(pending) <= maxLinks {
+			clear(pending)
+			p.extractElementLinks(n, baseURL, originURL, linkMap)
+			return true
+		}
+		added = added[:0]
+		for url := range pending {
+			if _, ok := linkMap[url]; !ok {
+				added = append(added, url)
 			}
-		case "link":
-			p.extractLinkTagLinks(n, baseURL, linkMap)
-		case "script":
-			if p.config.IncludeJS {
-				p.extractScriptLinks(n, baseURL, linkMap)
+		}
+		clear(pending)
+		room := maxLinks - len(linkMap)
+		p.extractElementLinks(n, baseURL, originURL, linkMap)
+		if len(linkMap) <= maxLinks {
+			return true
+		}
+		sort.Strings(added)
+		for _, url := range added {
+			if _, ok := linkMap[url]; !ok {
+				continue
 			}
-		case "iframe", "embed", "object":
-			if p.config.IncludeVideos {
-				p.extractEmbedLinks(n, baseURL, linkMap)
+			if room > 0 {
+				room--
+				continue
 			}
+			delete(linkMap, url)
 		}
-		return true
+		truncated = true
+		return false
 	})
+	return truncated
+}
+
+// extractElementLinks adds the links of the single element n to linkMap
+// according to the Include* settings. URLs are resolved against baseURL, and
+// <a> links are classified as external against originURL.
+func (p *Processor) extractElementLinks(n *stdxhtml.Node, baseURL, originURL string, linkMap map[string]LinkResource) {
+	switch n.Data {
+	case "a":
+		if p.config.IncludeContentLinks || p.config.IncludeExternalLinks {
+			p.extractContentLinks(n, baseURL, originURL, linkMap)
+		}
+	case "img":
+		if p.config.IncludeImages {
+			p.extractImageLinks(n, baseURL, linkMap)
+		}
+	case "video":
+		if p.config.IncludeVideos {
+			p.extractMediaLink(n, baseURL, linkMap, "video")
+		}
+	case "audio":
+		if p.config.IncludeAudios {
+			p.extractMediaLink(n, baseURL, linkMap, "audio")
+		}
+	case "source":
+		if p.config.IncludeVideos || p.config.IncludeAudios {
+			p.extractSourceLinks(n, baseURL, linkMap)
+		}
+	case "link":
+		p.extractLinkTagLinks(n, baseURL, linkMap)
+	case "script":
+		if p.config.IncludeJS {
+			p.extractScriptLinks(n, baseURL, linkMap)
+		}
+	case "iframe", "embed", "object":
+		if p.config.IncludeVideos {
+			p.extractEmbedLinks(n, baseURL, linkMap)
+		}
+	}
+	if p.config.IncludeCSSResources {
+		p.extractCSSLinks(n, baseURL, linkMap)
+	}
+}
+
+// maxElementLinks returns an upper bound on the number of URLs that
+// extractElementLinks adds for n: one for the element itself, plus one for
+// each '(' or '@' of its CSS, which every url() and @import reference needs.
+func (p *Processor) maxElementLinks(n *stdxhtml.Node) int {
+	count := 1
+	if !p.config.IncludeCSSResources {
+		return count
+	}
+	if n.Data == "style" {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == stdxhtml.TextNode {
+				count += strings.Count(c.Data, "(") + strings.Count(c.Data, "@")
+			}
+		}
+	}
+	if style := internal.GetAttr(n, "style"); style != "" {
+		count += strings.Count(style, "(") + strings.Count(style, "@")
+	}
+	return count
 }
 
 // resolveURLIfEnabled resolves raw against baseURL when relative-URL resolution
@@ -443,14 +613,20 @@ func (p *Processor) extractLinksFromDocument(doc *stdxhtml.Node, baseURL string,
 // ResolveRelativeURLs contract uniform across every link type: previously only
 // content (a[href]) links honored the flag, while image/media/source/script/
 // embed/link tags resolved whenever baseURL was non-empty, silently ignoring it.
+//
+// With FixSchemelessDomains, a raw URL such as "www.example.com/page" gets the
+// scheme of baseURL instead, as if it were protocol-relative.
 func (p *Processor) resolveURLIfEnabled(baseURL, raw string) string {
 	if p.config.ResolveRelativeURLs && baseURL != "" {
+		if p.config.FixSchemelessDomains && internal.IsSchemelessDomain(raw) {
+			return internal.WithBaseScheme(baseURL, raw)
+		}
 		return internal.ResolveURL(baseURL, raw)
 	}
 	return raw
 }
 
-func (p *Processor) extractContentLinks(n *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
+func (p *Processor) extractContentLinks(n *stdxhtml.Node, baseURL, originURL string, linkMap map[string]LinkResource) {
 	var href, title string
 	for _, attr := range n.Attr {
 		switch attr.Key {
@@ -465,14 +641,19 @@ func (p *Processor) extractContentLinks(n *stdxhtml.Node, baseURL string, linkMa
 		return
 	}
 
-	isExternalOriginal := internal.IsExternalURL(href)
-
 	resolvedURL := p.resolveURLIfEnabled(baseURL, href)
-
-	isExternal := isExternalOriginal
-	if !isExternalOriginal && baseURL != "" {
-		isExternal = internal.IsDifferentDomain(baseURL, resolvedURL)
+	if p.config.IgnoreFragments {
+		var hasFragment bool
+		resolvedURL, hasFragment = stripFragment(resolvedURL)
+		if resolvedURL == "" {
+			return
+		}
+		// Keep the title of the link without a fragment, or else of the first one seen.
+		if existing, ok := linkMap[resolvedURL]; ok && (hasFragment || !strings.Contains(existing.Original, "#")) {
+			return
+		}
 	}
+	isExternal := p.isExternalLink(originURL, href)
 
 	if isExternal && !p.config.IncludeExternalLinks {
 		return
@@ -489,10 +670,53 @@ func (p *Processor) extractContentLinks(n *stdxhtml.Node, baseURL string, linkMa
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: title,
-		Type:  "link",
+		URL:      resolvedURL,
+		Title:    title,
+		Type:     "link",
+		Original: href,
+		FileType: linkFileType(resolvedURL),
+	}
+}
+
+// stripFragment returns rawURL without its #fragment, and whether it had one.
+func stripFragment(rawURL string) (string, bool) {
+	i := strings.IndexByte(rawURL, '#')
+	if i < 0 {
+		return rawURL, false
 	}
+	if u, err := url.Parse(rawURL); err == nil {
+		u.Fragment, u.RawFragment = "", ""
+		return u.String(), true
+	}
+	return rawURL[:i], true
+}
+
+// downloadFileTypes are the extensions that LinkResource.FileType reports:
+// documents, data files, archives and installers.
+var downloadFileTypes = map[string]bool{
+	"pdf": true, "doc": true, "docx": true, "xls": true, "xlsx": true, "ppt": true, "pptx": true,
+	"odt": true, "ods": true, "odp": true, "rtf": true, "txt": true, "csv": true, "epub": true,
+	"zip": true, "rar": true, "7z": true, "tar": true, "gz": true, "tgz": true, "bz2": true, "xz": true,
+	"dmg": true, "exe": true, "msi": true, "apk": true, "iso": true, "deb": true, "rpm": true,
+}
+
+// linkFileType returns the extension of the path of rawURL when it names a
+// downloadable file type, and "" otherwise. Query strings and fragments are
+// ignored, so "report.pdf?download=1" is still a "pdf".
+func linkFileType(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Opaque != "" {
+		return ""
+	}
+	name := u.Path[strings.LastIndexByte(u.Path, '/')+1:]
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 {
+		return ""
+	}
+	if ext := strings.ToLower(name[dot+1:]); downloadFileTypes[ext] {
+		return ext
+	}
+	return ""
 }
 
 func (p *Processor) extractImageLinks(n *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
@@ -508,7 +732,7 @@ func (p *Processor) extractImageLinks(n *stdxhtml.Node, baseURL string, linkMap
 		}
 	}
 
-	if src == "" || !internal.IsValidURL(src) {
+	if src == "" || !internal.IsValidURL(src) || !p.allowDataURL(src) {
 		return
 	}
 
@@ -527,9 +751,10 @@ func (p *Processor) extractImageLinks(n *stdxhtml.Node, baseURL string, linkMap
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: displayName,
-		Type:  "image",
+		URL:      resolvedURL,
+		Title:    displayName,
+		Type:     "image",
+		Original: src,
 	}
 }
 
@@ -565,9 +790,10 @@ func (p *Processor) extractMediaLink(n *stdxhtml.Node, baseURL string, linkMap m
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: displayName,
-		Type:  mediaType,
+		URL:      resolvedURL,
+		Title:    displayName,
+		Type:     mediaType,
+		Original: src,
 	}
 }
 
@@ -593,10 +819,12 @@ func (p *Processor) extractSourceLinks(n *stdxhtml.Node, baseURL string, linkMap
 		resourceType = "video"
 	} else if strings.HasPrefix(mediaType, "audio/") {
 		resourceType = "audio"
+	} else if internal.StreamManifestType("", mediaType) != "" {
+		resourceType = "video"
 	} else {
-		if internal.DetectVideoType(resolvedURL) != "" {
+		if p.mediaExtensions.videoType(resolvedURL) != "" {
 			resourceType = "video"
-		} else if internal.DetectAudioType(resolvedURL) != "" {
+		} else if p.mediaExtensions.audioType(resolvedURL) != "" {
 			resourceType = "audio"
 		}
 	}
@@ -607,14 +835,15 @@ func (p *Processor) extractSourceLinks(n *stdxhtml.Node, baseURL string, linkMap
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: title,
-		Type:  resourceType,
+		URL:      resolvedURL,
+		Title:    title,
+		Type:     resourceType,
+		Original: src,
 	}
 }
 
 func (p *Processor) extractLinkTagLinks(n *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
-	var href, rel, linkType, title string
+	var href, rel, linkType, title, crossOrigin string
 	for _, attr := range n.Attr {
 		switch attr.Key {
 		case "href":
@@ -625,6 +854,8 @@ func (p *Processor) extractLinkTagLinks(n *stdxhtml.Node, baseURL string, linkMa
 			linkType = attr.Val
 		case "title":
 			title = attr.Val
+		case "crossorigin":
+			crossOrigin = corsMode(attr.Val)
 		}
 	}
 
@@ -675,6 +906,15 @@ func (p *Processor) extractLinkTagLinks(n *stdxhtml.Node, baseURL string, linkMa
 						resourceType = "audio"
 						include = true
 					}
+				case "font":
+					if p.config.IncludeFonts {
+						resourceType = "font"
+						include = true
+					}
+				case "fetch", "document":
+					if p.config.IncludeContentLinks {
+						include = true
+					}
 				}
 				break
 			}
@@ -705,10 +945,22 @@ func (p *Processor) extractLinkTagLinks(n *stdxhtml.Node, baseURL string, linkMa
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: title,
-		Type:  resourceType,
+		URL:         resolvedURL,
+		Title:       title,
+		Type:        resourceType,
+		Original:    href,
+		MIMEType:    strings.TrimSpace(linkType),
+		CrossOrigin: crossOrigin,
+	}
+}
+
+// corsMode returns the CORS mode named by a crossorigin attribute value. An
+// empty or unknown value means "anonymous", as in the HTML specification.
+func corsMode(val string) string {
+	if strings.EqualFold(strings.TrimSpace(val), "use-credentials") {
+		return "use-credentials"
 	}
+	return "anonymous"
 }
 
 func (p *Processor) extractScriptLinks(n *stdxhtml.Node, baseURL string, linkMap map[string]LinkResource) {
@@ -735,9 +987,10 @@ func (p *Processor) extractScriptLinks(n *stdxhtml.Node, baseURL string, linkMap
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: title,
-		Type:  "js",
+		URL:      resolvedURL,
+		Title:    title,
+		Type:     "js",
+		Original: src,
 	}
 }
 
@@ -757,7 +1010,7 @@ func (p *Processor) extractEmbedLinks(n *stdxhtml.Node, baseURL string, linkMap
 	}
 
 	// Only include if it's a video URL (includes embed patterns)
-	if !internal.IsVideoURL(src) {
+	if !p.mediaExtensions.isVideoURL(src) {
 		return
 	}
 
@@ -777,9 +1030,10 @@ func (p *Processor) extractEmbedLinks(n *stdxhtml.Node, baseURL string, linkMap
 	}
 
 	linkMap[resolvedURL] = LinkResource{
-		URL:   resolvedURL,
-		Title: title,
-		Type:  "video",
+		URL:      resolvedURL,
+		Title:    title,
+		Type:     "video",
+		Original: src,
 	}
 }
 
@@ -801,6 +1055,76 @@ func GroupLinksByType(links []LinkResource) map[string][]LinkResource {
 	return grouped
 }
 
+// GroupLinksByDomain groups links by the lower-cased host of their URL,
+// including any port, for example "example.com" or "cdn.example.com:8443".
+// Links without a host, such as unresolved relative URLs and mailto: or data:
+// URLs, are grouped under the empty key. Links keep their order within a group.
+func GroupLinksByDomain(links []LinkResource) map[string][]LinkResource {
+	grouped := make(map[string][]LinkResource)
+	for _, link := range links {
+		host := linkHost(link.URL)
+		grouped[host] = append(grouped[host], link)
+	}
+	return grouped
+}
+
+// FilterLinks returns the links for which pred reports true, in their
+// original order. Predicates such as LinkIsImage, LinkHasType and
+// LinkIsExternal can be combined by filtering repeatedly.
+func FilterLinks(links []LinkResource, pred func(LinkResource) bool) []LinkResource {
+	var filtered []LinkResource
+	for _, link := range links {
+		if pred(link) {
+			filtered = append(filtered, link)
+		}
+	}
+	return filtered
+}
+
+// LinkIsImage reports whether link is an image resource.
+func LinkIsImage(link LinkResource) bool {
+	return link.Type == "image"
+}
+
+// LinkHasType returns a predicate reporting whether a link has one of types,
+// such as "css" and "js".
+func LinkHasType(types ...string) func(LinkResource) bool {
+	return func(link LinkResource) bool {
+		for _, t := range types {
+			if link.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// LinkIsExternal returns a predicate reporting whether a link targets a
+// different host than baseURL, as LinkInfo.IsExternal does under
+// DefaultConfig(): relative links are resolved against baseURL first, so they
+// are never external, and hosts are compared as written, case-sensitively.
+// When baseURL is not an absolute http(s) URL, every absolute link is external.
+func LinkIsExternal(baseURL string) func(LinkResource) bool {
+	cfg := DefaultConfig()
+	return func(link LinkResource) bool {
+		return isExternalFrom(&cfg, baseURL, link.URL)
+	}
+}
+
+// linkHost returns the lower-cased host of an absolute or protocol-relative
+// http(s) URL, and "" for any other URL.
+func linkHost(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if !internal.IsExternalURL(strings.ToLower(rawURL)) {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
 // lastPathSegment returns the substring after the final '/' in url, or "" if
 // url contains no '/'. It centralizes the filename extraction shared by the
 // link/image/media/script title fallbacks below.
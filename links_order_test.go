@@ -1,6 +1,9 @@
 package html
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 // TestExtractAllLinksOrderDeterministic guards against the previous
 // map-iteration-based ordering of ExtractAllLinks, which returned links in a
@@ -48,3 +51,220 @@ func TestExtractAllLinksOrderDeterministic(t *testing.T) {
 		}
 	}
 }
+
+// TestExtractLinksPreSanitization checks that Result.Links can cover the whole
+// document, matching ExtractAllLinks, while Text stays limited to the article.
+func TestExtractLinksPreSanitization(t *testing.T) {
+	t.Parallel()
+
+	htmlContent := []byte(`<html><body>
+		<nav><a href="https://example.com/home">Home</a><a href="https://example.com/blog">Blog</a></nav>
+		<article><h1>Story</h1><p>The article body is long enough to be chosen as the main content,
+		and it links to <a href="https://example.com/source">the source</a> of the story.</p></article>
+	</body></html>`)
+
+	result, err := Extract(htmlContent)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if len(result.Links) != 1 || result.Links[0].URL != "https://example.com/source" {
+		t.Fatalf("default Links = %+v, want only the article link", result.Links)
+	}
+
+	cfg := DefaultConfig()
+	cfg.ExtractLinksPreSanitization = true
+	result, err = Extract(htmlContent, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := []string{"https://example.com/home", "https://example.com/blog", "https://example.com/source"}
+	if len(result.Links) != len(want) {
+		t.Fatalf("Links = %+v, want %v", result.Links, want)
+	}
+	for i, link := range result.Links {
+		if link.URL != want[i] || link.Position != i+1 {
+			t.Errorf("Links[%d] = %s at %d, want %s at %d", i, link.URL, link.Position, want[i], i+1)
+		}
+	}
+	if strings.Contains(result.Text, "Home") {
+		t.Errorf("Text = %q, want the navigation left out", result.Text)
+	}
+
+	all, err := ExtractAllLinks(htmlContent)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	if len(all) != len(want) {
+		t.Errorf("ExtractAllLinks() returned %d links, want %d", len(all), len(want))
+	}
+}
+
+func TestLinkFileType(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<a href="/files/Report.PDF">Annual report</a>
+		<a href="/downloads/archive.tar.gz?token=abc#part">Archive</a>
+		<a href="https://example.com/slides.pptx">Slides</a>
+		<a href="/about.html">About</a>
+		<a href="/blog/v1.2/">Release</a>
+		<img src="/img/scan.pdf.png">
+	</body></html>`)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = "https://example.com/"
+	links, err := ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	want := map[string]string{
+		"https://example.com/files/Report.PDF":                        "pdf",
+		"https://example.com/downloads/archive.tar.gz?token=abc#part": "gz",
+		"https://example.com/slides.pptx":                             "pptx",
+		"https://example.com/about.html":                              "",
+		"https://example.com/blog/v1.2/":                              "",
+		"https://example.com/img/scan.pdf.png":                        "",
+	}
+	if len(links) != len(want) {
+		t.Fatalf("links = %+v, want %d", links, len(want))
+	}
+	for _, link := range links {
+		fileType, ok := want[link.URL]
+		if !ok {
+			t.Errorf("unexpected link %q", link.URL)
+			continue
+		}
+		if link.FileType != fileType {
+			t.Errorf("FileType of %q = %q, want %q", link.URL, link.FileType, fileType)
+		}
+		if fileType != "" && link.Type != "link" {
+			t.Errorf("Type of %q = %q, want link", link.URL, link.Type)
+		}
+	}
+}
+
+func TestIgnoreFragments(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<a href="/guide#install">Installing</a>
+		<a href="/guide">The guide</a>
+		<a href="/guide#usage">Usage</a>
+		<a href="/faq#one">First question</a>
+		<a href="/faq#two">Second question</a>
+	</body></html>`)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = "https://example.com/docs"
+	links, err := ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	if len(links) != 5 {
+		t.Errorf("links = %+v, want 5 without IgnoreFragments", links)
+	}
+
+	cfg.IgnoreFragments = true
+	links, err = ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	want := map[string]string{
+		"https://example.com/guide": "The guide",
+		"https://example.com/faq":   "First question",
+	}
+	if len(links) != len(want) {
+		t.Fatalf("links = %+v, want %d", links, len(want))
+	}
+	for _, link := range links {
+		if title, ok := want[link.URL]; !ok || link.Title != title {
+			t.Errorf("link %q titled %q, want %q", link.URL, link.Title, title)
+		}
+	}
+}
+
+func TestFixSchemelessDomains(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<a href="www.partner.org/page">Partner</a>
+		<a href="example.com/x">Same site</a>
+		<a href="folder/x">Folder</a>
+	</body></html>`)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = "https://example.com/docs/"
+	urls := func() map[string]bool {
+		links, err := ExtractAllLinks(doc, cfg)
+		if err != nil {
+			t.Fatalf("ExtractAllLinks() failed: %v", err)
+		}
+		got := make(map[string]bool, len(links))
+		for _, link := range links {
+			got[link.URL] = true
+		}
+		return got
+	}
+
+	got := urls()
+	for _, want := range []string{"https://example.com/docs/www.partner.org/page", "https://example.com/docs/example.com/x"} {
+		if !got[want] {
+			t.Errorf("links = %v, want %q without FixSchemelessDomains", got, want)
+		}
+	}
+
+	cfg.FixSchemelessDomains = true
+	got = urls()
+	for _, want := range []string{"https://www.partner.org/page", "https://example.com/x", "https://example.com/docs/folder/x"} {
+		if !got[want] {
+			t.Errorf("links = %v, want %q", got, want)
+		}
+	}
+
+	cfg.IncludeExternalLinks = false
+	got = urls()
+	if got["https://www.partner.org/page"] || !got["https://example.com/x"] {
+		t.Errorf("links = %v, want www.partner.org classified as external", got)
+	}
+}
+
+func TestPreloadLinks(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head>
+		<link rel="preload" as="font" href="/fonts/body.woff2" type="font/woff2" crossorigin>
+		<link rel="preload" as="fetch" href="/api/data.json" crossorigin="use-credentials">
+		<link rel="preload" as="document" href="/next.html">
+	</head><body></body></html>`)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = "https://example.com/"
+	links, err := ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	want := map[string]LinkResource{
+		"https://example.com/fonts/body.woff2": {Type: "font", MIMEType: "font/woff2", CrossOrigin: "anonymous"},
+		"https://example.com/api/data.json":    {Type: "link", CrossOrigin: "use-credentials"},
+		"https://example.com/next.html":        {Type: "link"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("links = %+v, want %d", links, len(want))
+	}
+	for _, link := range links {
+		w, ok := want[link.URL]
+		if !ok || link.Type != w.Type || link.MIMEType != w.MIMEType || link.CrossOrigin != w.CrossOrigin {
+			t.Errorf("link = %+v, want %+v", link, w)
+		}
+	}
+
+	cfg.IncludeFonts = false
+	cfg.IncludeContentLinks = false
+	links, err = ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	if len(links) != 0 {
+		t.Errorf("links = %+v, want none without IncludeFonts and IncludeContentLinks", links)
+	}
+}
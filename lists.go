@@ -0,0 +1,97 @@
+package html
+
+// lists.go collects the <ul> and <ol> lists of the cleaned content node as
+// structured data.
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// listIndent prefixes the items of a nested list once per nesting level.
+const listIndent = "  "
+
+// extractLists returns the outermost lists of contentNode in document order.
+// A list nested inside another contributes its items to the outer list, right
+// after the item containing it and indented by listIndent per level. Items
+// without text are skipped, and so are lists left without any item.
+func (p *Processor) extractLists(contentNode *stdxhtml.Node) []ListInfo {
+	if contentNode == nil {
+		return nil
+	}
+
+	buf := getTextBuffer()
+	defer putTextBuffer(buf)
+
+	var lists []ListInfo
+	internal.WalkNodes(contentNode, func(n *stdxhtml.Node) bool {
+		if !isListElement(n) {
+			return true
+		}
+		list := ListInfo{Ordered: n.Data == "ol"}
+		p.appendListItems(&list, n, 0, buf)
+		if len(list.Items) > 0 {
+			lists = append(lists, list)
+		}
+		return false
+	})
+	return lists
+}
+
+// appendListItems appends the text of each <li> child of n to list.Items,
+// followed by the items of the lists nested in it.
+func (p *Processor) appendListItems(list *ListInfo, n *stdxhtml.Node, depth int, buf *bytes.Buffer) {
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != stdxhtml.ElementNode || li.Data != "li" {
+			continue
+		}
+		buf.Reset()
+		var nested []*stdxhtml.Node
+		p.writeListItemText(li, buf, &nested)
+		if text := strings.Join(strings.Fields(buf.String()), " "); text != "" {
+			list.Items = append(list.Items, strings.Repeat(listIndent, depth)+text)
+		}
+		for _, sub := range nested {
+			p.appendListItems(list, sub, depth+1, buf)
+		}
+	}
+}
+
+// writeListItemText writes the text of n to buf, leaving out nested lists,
+// which are collected into nested instead.
+func (p *Processor) writeListItemText(n *stdxhtml.Node, buf *bytes.Buffer, nested *[]*stdxhtml.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case isListElement(c):
+			*nested = append(*nested, c)
+		case c.Type == stdxhtml.ElementNode && containsList(c):
+			p.writeListItemText(c, buf, nested)
+		default:
+			p.writeStructuredText(c, buf, nil, nil)
+		}
+	}
+}
+
+// isListElement reports whether n is a <ul> or <ol> element.
+func isListElement(n *stdxhtml.Node) bool {
+	return n.Type == stdxhtml.ElementNode && (n.Data == "ul" || n.Data == "ol")
+}
+
+// containsList reports whether any descendant of n is a list element.
+func containsList(n *stdxhtml.Node) bool {
+	found := false
+	internal.WalkNodes(n, func(node *stdxhtml.Node) bool {
+		if found {
+			return false
+		}
+		if node != n && isListElement(node) {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,53 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractLists(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body><article>
+		<h2>Ingredients</h2>
+		<ul>
+			<li>2 cups <b>flour</b></li>
+			<li>1 egg</li>
+			<li></li>
+			<li>Spices
+				<ul><li>salt</li><li>pepper<ol><li>black</li></ol></li></ul>
+			</li>
+		</ul>
+		<h2>Steps</h2>
+		<ol><li>Mix everything.</li><li><p>Bake for <a href="/timer">20 minutes</a> at 180C.</p></li></ol>
+		<ul><li> </li></ul>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.ExtractLists = true
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []html.ListInfo{
+		{Ordered: false, Items: []string{"2 cups flour", "1 egg", "Spices", "  salt", "  pepper", "    black"}},
+		{Ordered: true, Items: []string{"Mix everything.", "Bake for 20 minutes at 180C."}},
+	}
+	if !reflect.DeepEqual(result.Lists, want) {
+		t.Errorf("Lists =\n%#v\nwant\n%#v", result.Lists, want)
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result, err := html.Extract([]byte(doc))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if len(result.Lists) != 0 {
+			t.Errorf("Lists = %#v, want none", result.Lists)
+		}
+	})
+}
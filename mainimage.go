@@ -0,0 +1,54 @@
+package html
+
+// mainimage.go picks the representative image of a page for Result.MainImage.
+
+import (
+	"strings"
+
+	stdxhtml "golang.org/x/net/html"
+)
+
+const (
+	// largeImageSize is the width, or the height when no width is given, in
+	// pixels from which a content image counts as a lead image.
+	largeImageSize = 300
+	// minMainImageSize excludes icons, spacers and tracking pixels whose
+	// declared width or height is below it.
+	minMainImageSize = 50
+)
+
+// mainImage returns the representative image of the page: socialImage (the
+// og:image or twitter:image) when set, otherwise the first large image among
+// images, otherwise the first image not declared tiny. images are the content
+// images in document order; when nil, contentNode is walked for them. Data
+// URLs are skipped, being mostly lazy-loading placeholders. Either way the
+// chosen URL is resolved against baseURL with resolveDocumentURL.
+func (p *Processor) mainImage(baseURL string, contentNode *stdxhtml.Node, socialImage string, images []ImageInfo) string {
+	if socialImage != "" {
+		return p.resolveDocumentURL(baseURL, socialImage)
+	}
+	if images == nil && contentNode != nil {
+		images = p.extractImagesWithPosition(contentNode)
+	}
+	var first string
+	for _, img := range images {
+		if img.MimeType != "" || strings.HasPrefix(img.URL, "data:") {
+			continue
+		}
+		width, height := parseMetaDimension(img.Width), parseMetaDimension(img.Height)
+		if (width > 0 && width < minMainImageSize) || (height > 0 && height < minMainImageSize) {
+			continue
+		}
+		if width >= largeImageSize || (width == 0 && height >= largeImageSize) {
+			first = img.URL
+			break
+		}
+		if first == "" {
+			first = img.URL
+		}
+	}
+	if first == "" {
+		return ""
+	}
+	return p.resolveDocumentURL(baseURL, first)
+}
@@ -1,6 +1,7 @@
 package html
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/cybergodev/html/internal"
@@ -10,19 +11,68 @@ import (
 // appendUniqueVideoURLs appends each url that is a valid, not-yet-seen video URL
 // to videos, recording it in seen. It centralizes the validate-and-deduplicate
 // logic shared by the iframe, embed, and object raw-HTML extraction paths.
-func appendUniqueVideoURLs(urls []string, seen map[string]bool, videos []VideoInfo) []VideoInfo {
+func (p *Processor) appendUniqueVideoURLs(urls []string, seen map[string]bool, videos []VideoInfo) []VideoInfo {
 	for _, url := range urls {
-		if internal.IsValidURL(url) && internal.IsVideoURL(url) && !seen[url] {
-			seen[url] = true
-			videos = append(videos, VideoInfo{
+		if internal.IsValidURL(url) && p.mediaExtensions.isVideoURL(url) {
+			videos = appendUniqueVideo(withEmbedIdentity(VideoInfo{
 				URL:  url,
-				Type: internal.DetectVideoType(url),
-			})
+				Type: p.mediaExtensions.videoType(url),
+			}), seen, videos)
 		}
 	}
 	return videos
 }
 
+// appendUniqueVideo appends video unless its URL, or the canonical URL of the
+// video it embeds, was already seen. Keying on CanonicalURL collapses the same
+// YouTube/Vimeo video embedded through different URL forms.
+func appendUniqueVideo(video VideoInfo, seen map[string]bool, videos []VideoInfo) []VideoInfo {
+	if video.URL == "" || seen[video.URL] || (video.CanonicalURL != "" && seen[video.CanonicalURL]) {
+		return videos
+	}
+	video = withStreamManifest(video)
+	seen[video.URL] = true
+	if video.CanonicalURL != "" {
+		seen[video.CanonicalURL] = true
+	}
+	return append(videos, video)
+}
+
+// withStreamManifest sets Type to "hls" or "dash" and StreamManifest when
+// video is a streaming manifest, judging by its Type and then by its URL.
+func withStreamManifest(video VideoInfo) VideoInfo {
+	if kind := internal.StreamManifestType(video.URL, video.Type); kind != "" {
+		video.Type, video.StreamManifest = kind, true
+	}
+	return video
+}
+
+// withEmbedIdentity fills Platform, VideoID, and CanonicalURL when video.URL
+// is a recognized YouTube, Vimeo, or Dailymotion URL, and Poster, unless the
+// tag set one, when the platform serves thumbnails at a URL derived from the ID.
+func withEmbedIdentity(video VideoInfo) VideoInfo {
+	video.Platform, video.VideoID, video.CanonicalURL = internal.ParseVideoEmbed(video.URL)
+	if video.Poster == "" {
+		video.Poster = embedThumbnailURL(video.Platform, video.VideoID)
+	}
+	return video
+}
+
+// embedThumbnailURL returns the thumbnail of a YouTube or Dailymotion video.
+// Vimeo thumbnails can only be looked up through its API, so none is derived.
+func embedThumbnailURL(platform, id string) string {
+	if id == "" {
+		return ""
+	}
+	switch platform {
+	case "youtube":
+		return "https://img.youtube.com/vi/" + url.PathEscape(id) + "/hqdefault.jpg"
+	case "dailymotion":
+		return "https://www.dailymotion.com/thumbnail/video/" + url.PathEscape(id)
+	}
+	return ""
+}
+
 func (p *Processor) extractVideos(node *stdxhtml.Node, htmlContent string, canContainMedia bool) []VideoInfo {
 	videos := make([]VideoInfo, 0, initialSliceCap)
 	seen := make(map[string]bool, initialMapCap)
@@ -38,53 +88,56 @@ func (p *Processor) extractVideos(node *stdxhtml.Node, htmlContent string, canCo
 	// First, extract from the HTML content directly for iframe/embed/object tags
 	// These may be removed by sanitization, so we parse them from raw HTML first.
 	// All three share identical validate/dedup logic (appendUniqueVideoURLs).
+	// Each stage below is skipped once MaxVideos is reached, and the result is
+	// trimmed to the cap at the end.
+	maxVideos := p.config.MaxVideos
+	full := func() bool { return maxVideos > 0 && len(videos) >= maxVideos }
 	if canContainMedia {
-		videos = appendUniqueVideoURLs(
+		videos = p.appendUniqueVideoURLs(
 			p.extractTagAttributes(htmlContent, "iframe", "src"), seen, videos)
-		videos = appendUniqueVideoURLs(
+		videos = p.appendUniqueVideoURLs(
 			p.extractTagAttributes(htmlContent, "embed", "src", "data"), seen, videos)
-		videos = appendUniqueVideoURLs(
+		videos = p.appendUniqueVideoURLs(
 			p.extractTagAttributes(htmlContent, "object", "data"), seen, videos)
 	}
 
 	// Then extract from the DOM tree (for video tags and any iframe/embed/object that survived sanitization)
 	internal.WalkNodes(node, func(n *stdxhtml.Node) bool {
+		if full() {
+			return false
+		}
 		if n.Type != stdxhtml.ElementNode {
 			return true
 		}
 
 		switch n.Data {
 		case "video":
-			if video := p.parseVideoNode(n); video.URL != "" && !seen[video.URL] {
-				seen[video.URL] = true
-				videos = append(videos, video)
-			}
-
+			videos = appendUniqueVideo(p.parseVideoNode(n), seen, videos)
 		case "iframe":
-			if video := p.parseIframeNode(n); video.URL != "" && !seen[video.URL] {
-				seen[video.URL] = true
-				videos = append(videos, video)
-			}
-
+			videos = appendUniqueVideo(p.parseIframeNode(n), seen, videos)
 		case "embed", "object":
-			if video := p.parseEmbedNode(n); video.URL != "" && !seen[video.URL] {
-				seen[video.URL] = true
-				videos = append(videos, video)
-			}
+			videos = appendUniqueVideo(p.parseEmbedNode(n), seen, videos)
 		}
 		return true
 	})
+	if full() {
+		videos = videos[:maxVideos]
+	}
+	p.resolveTrackURLs(node, videos)
 
 	// Finally, use regex to find any video URLs in the HTML content
-	if canContainMedia {
-		matches := videoRegex.FindAllString(htmlContent, maxRegexMatches)
+	if canContainMedia && !p.config.DisableMediaRegexScan && !full() {
+		matches := p.mediaExtensions.videoURLRegex().FindAllString(htmlContent, maxRegexMatches)
 		for _, url := range matches {
+			if full() {
+				break
+			}
 			if internal.IsValidURL(url) && !seen[url] {
 				seen[url] = true
-				videos = append(videos, VideoInfo{
+				videos = append(videos, withStreamManifest(VideoInfo{
 					URL:  url,
-					Type: internal.DetectVideoType(url),
-				})
+					Type: p.mediaExtensions.videoType(url),
+				}))
 			}
 		}
 	}
@@ -120,12 +173,57 @@ func (p *Processor) parseVideoNode(n *stdxhtml.Node) VideoInfo {
 		return VideoInfo{}
 	}
 
+	video.Tracks = parseTrackNodes(n)
 	return video
 }
 
+// parseTrackNodes returns the <track> children of a <video> element, with src
+// left unresolved. Tracks without a valid src are skipped.
+func parseTrackNodes(n *stdxhtml.Node) []TrackInfo {
+	var tracks []TrackInfo
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != stdxhtml.ElementNode || c.Data != "track" {
+			continue
+		}
+		track := TrackInfo{Kind: "subtitles"}
+		for _, attr := range c.Attr {
+			switch attr.Key {
+			case "kind":
+				if kind := strings.ToLower(strings.TrimSpace(attr.Val)); kind != "" {
+					track.Kind = kind
+				}
+			case "srclang":
+				track.Language = strings.TrimSpace(attr.Val)
+			case "src":
+				track.Src = strings.TrimSpace(attr.Val)
+			case "label":
+				track.Label = attr.Val
+			}
+		}
+		if track.Src != "" && internal.IsValidURL(track.Src) {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// resolveTrackURLs resolves the track URLs of videos against BaseURL, or the
+// base detected from doc, which is only looked up when some video has tracks.
+func (p *Processor) resolveTrackURLs(doc *stdxhtml.Node, videos []VideoInfo) {
+	baseURL, detected := "", false
+	for i := range videos {
+		for j := range videos[i].Tracks {
+			if !detected {
+				baseURL, detected = p.documentBaseURL(doc), true
+			}
+			videos[i].Tracks[j].Src = p.resolveURLIfEnabled(baseURL, videos[i].Tracks[j].Src)
+		}
+	}
+}
+
 func (p *Processor) parseIframeNode(n *stdxhtml.Node) VideoInfo {
 	for _, attr := range n.Attr {
-		if attr.Key == "src" && internal.IsValidURL(attr.Val) && internal.IsVideoURL(attr.Val) {
+		if attr.Key == "src" && internal.IsValidURL(attr.Val) && p.mediaExtensions.isVideoURL(attr.Val) {
 			video := VideoInfo{URL: attr.Val, Type: "embed"}
 			for _, a := range n.Attr {
 				switch a.Key {
@@ -135,7 +233,7 @@ func (p *Processor) parseIframeNode(n *stdxhtml.Node) VideoInfo {
 					video.Height = a.Val
 				}
 			}
-			return video
+			return withEmbedIdentity(video)
 		}
 	}
 	return VideoInfo{}
@@ -143,7 +241,7 @@ func (p *Processor) parseIframeNode(n *stdxhtml.Node) VideoInfo {
 
 func (p *Processor) parseEmbedNode(n *stdxhtml.Node) VideoInfo {
 	for _, attr := range n.Attr {
-		if (attr.Key == "src" || attr.Key == "data") && internal.IsValidURL(attr.Val) && internal.IsVideoURL(attr.Val) {
+		if (attr.Key == "src" || attr.Key == "data") && internal.IsValidURL(attr.Val) && p.mediaExtensions.isVideoURL(attr.Val) {
 			video := VideoInfo{URL: attr.Val}
 			for _, a := range n.Attr {
 				switch a.Key {
@@ -155,7 +253,7 @@ func (p *Processor) parseEmbedNode(n *stdxhtml.Node) VideoInfo {
 					video.Height = a.Val
 				}
 			}
-			return video
+			return withEmbedIdentity(video)
 		}
 	}
 	return VideoInfo{}
@@ -164,8 +262,13 @@ func (p *Processor) parseEmbedNode(n *stdxhtml.Node) VideoInfo {
 func (p *Processor) extractAudios(node *stdxhtml.Node, htmlContent string, canContainMedia bool) []AudioInfo {
 	audios := make([]AudioInfo, 0, initialSliceCap)
 	seen := make(map[string]bool, initialMapCap)
+	maxAudios := p.config.MaxAudios
+	full := func() bool { return maxAudios > 0 && len(audios) >= maxAudios }
 
 	internal.WalkNodes(node, func(n *stdxhtml.Node) bool {
+		if full() {
+			return false
+		}
 		if n.Type == stdxhtml.ElementNode && n.Data == "audio" {
 			if audio := p.parseAudioNode(n); audio.URL != "" && !seen[audio.URL] {
 				seen[audio.URL] = true
@@ -179,14 +282,17 @@ func (p *Processor) extractAudios(node *stdxhtml.Node, htmlContent string, canCo
 	// extension; skip it when it provably does not. The DOM walk above still finds
 	// <audio>/<source> elements regardless of their URL extension.
 	// canContainMedia is computed once by the caller and shared with extractVideos.
-	if canContainMedia {
-		matches := audioRegex.FindAllString(htmlContent, maxRegexMatches)
+	if canContainMedia && !p.config.DisableMediaRegexScan && !full() {
+		matches := p.mediaExtensions.audioURLRegex().FindAllString(htmlContent, maxRegexMatches)
 		for _, url := range matches {
+			if full() {
+				break
+			}
 			if internal.IsValidURL(url) && !seen[url] {
 				seen[url] = true
 				audios = append(audios, AudioInfo{
 					URL:  url,
-					Type: internal.DetectAudioType(url),
+					Type: p.mediaExtensions.audioType(url),
 				})
 			}
 		}
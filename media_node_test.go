@@ -1,6 +1,7 @@
 package html_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -386,3 +387,229 @@ func TestParseEmbedNodeDOMPath(t *testing.T) {
 		}
 	})
 }
+
+func TestEmbedVideoIdentity(t *testing.T) {
+	t.Parallel()
+
+	htmlContent := buildPaddedHTML(
+		`<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ"></iframe>` +
+			`<iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?rel=0"></iframe>` +
+			`<iframe src="https://player.vimeo.com/video/76979871"></iframe>`,
+	)
+	result, err := html.Extract([]byte(htmlContent))
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	byCanonical := make(map[string]html.VideoInfo)
+	for _, v := range result.Videos {
+		if v.CanonicalURL == "" {
+			continue
+		}
+		if _, dup := byCanonical[v.CanonicalURL]; dup {
+			t.Errorf("duplicate video for %s: %+v", v.CanonicalURL, result.Videos)
+		}
+		byCanonical[v.CanonicalURL] = v
+	}
+
+	yt, ok := byCanonical["https://www.youtube.com/watch?v=dQw4w9WgXcQ"]
+	if !ok {
+		t.Fatalf("youtube video missing: %+v", result.Videos)
+	}
+	if yt.Platform != "youtube" || yt.VideoID != "dQw4w9WgXcQ" {
+		t.Errorf("youtube identity = (%q, %q)", yt.Platform, yt.VideoID)
+	}
+	if yt.URL != "https://www.youtube.com/embed/dQw4w9WgXcQ" {
+		t.Errorf("URL should keep the first embed form, got %q", yt.URL)
+	}
+	if yt.Poster != "https://img.youtube.com/vi/dQw4w9WgXcQ/hqdefault.jpg" {
+		t.Errorf("youtube Poster = %q, want the derived thumbnail", yt.Poster)
+	}
+
+	vimeo, ok := byCanonical["https://vimeo.com/76979871"]
+	if !ok {
+		t.Fatalf("vimeo video missing: %+v", result.Videos)
+	}
+	if vimeo.Platform != "vimeo" || vimeo.VideoID != "76979871" {
+		t.Errorf("vimeo identity = (%q, %q)", vimeo.Platform, vimeo.VideoID)
+	}
+	if vimeo.Poster != "" {
+		t.Errorf("vimeo Poster = %q, want none without an API lookup", vimeo.Poster)
+	}
+}
+
+func TestVideoTracks(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head><base href="https://media.example.com/"></head><body><article>
+		<p>Watch the talk below.</p>
+		<video src="/talks/keynote.mp4" controls>
+			<track kind="subtitles" srclang="en" src="subs/en.vtt" label="English" default>
+			<track kind="Captions" srclang="de" src="https://cdn.example.org/de.vtt" label="Deutsch (CC)">
+			<track srclang="fr" src="subs/fr.vtt">
+			<track kind="chapters" label="No source">
+		</video>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	var video *html.VideoInfo
+	for i := range result.Videos {
+		if strings.HasSuffix(result.Videos[i].URL, "keynote.mp4") {
+			video = &result.Videos[i]
+		}
+	}
+	if video == nil {
+		t.Fatalf("Videos = %+v, want keynote.mp4", result.Videos)
+	}
+
+	want := []html.TrackInfo{
+		{Kind: "subtitles", Language: "en", Src: "https://media.example.com/subs/en.vtt", Label: "English"},
+		{Kind: "captions", Language: "de", Src: "https://cdn.example.org/de.vtt", Label: "Deutsch (CC)"},
+		{Kind: "subtitles", Language: "fr", Src: "https://media.example.com/subs/fr.vtt"},
+	}
+	if len(video.Tracks) != len(want) {
+		t.Fatalf("Tracks = %+v, want %+v", video.Tracks, want)
+	}
+	for i := range want {
+		if video.Tracks[i] != want[i] {
+			t.Errorf("Tracks[%d] = %+v, want %+v", i, video.Tracks[i], want[i])
+		}
+	}
+}
+
+func TestDisableMediaRegexScan(t *testing.T) {
+	t.Parallel()
+
+	htmlContent := []byte(`<html><body><article><p>Player page with a configured clip.</p>
+		<video src="/tagged.mp4"></video><audio src="/tagged.mp3"></audio>
+		<script>var player = {src: "https://cdn.example.com/clip.mp4", track: "https://cdn.example.com/song.mp3"};</script>
+		</article></body></html>`)
+
+	result, err := html.Extract(htmlContent)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Videos) != 2 || len(result.Audios) != 2 {
+		t.Fatalf("default: got %d videos and %d audios, want the script URLs too: %+v %+v",
+			len(result.Videos), len(result.Audios), result.Videos, result.Audios)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.DisableMediaRegexScan = true
+	result, err = html.Extract(htmlContent, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Videos) != 1 || result.Videos[0].URL != "/tagged.mp4" {
+		t.Errorf("Videos = %+v, want only the <video> source", result.Videos)
+	}
+	if len(result.Audios) != 1 || result.Audios[0].URL != "/tagged.mp3" {
+		t.Errorf("Audios = %+v, want only the <audio> source", result.Audios)
+	}
+}
+
+func TestAdditionalMediaExtensions(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>Segments are at https://cdn.example.com/live/seg1.TS and the soundtrack at
+		https://cdn.example.com/audio/score.mka for download.</p>
+		<video><source src="https://cdn.example.com/live/seg2.ts"></video>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, v := range result.Videos {
+		if v.Type != "" && strings.HasPrefix(v.Type, "video/ts") {
+			t.Errorf("video %+v typed without AdditionalVideoExtensions", v)
+		}
+	}
+	if len(result.Audios) != 0 {
+		t.Errorf("Audios = %+v, want none without AdditionalAudioExtensions", result.Audios)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.AdditionalVideoExtensions = []string{".TS"}
+	cfg.AdditionalAudioExtensions = []string{"mka"}
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	videos := map[string]string{}
+	for _, v := range result.Videos {
+		videos[v.URL] = v.Type
+	}
+	if videos["https://cdn.example.com/live/seg1.TS"] != "video/ts" {
+		t.Errorf("Videos = %+v, want seg1.TS from the URL scan typed video/ts", result.Videos)
+	}
+	if _, ok := videos["https://cdn.example.com/live/seg2.ts"]; !ok {
+		t.Errorf("Videos = %+v, want seg2.ts from the <video> element", result.Videos)
+	}
+	if len(result.Audios) != 1 || result.Audios[0].Type != "audio/mka" {
+		t.Errorf("Audios = %+v, want score.mka typed audio/mka", result.Audios)
+	}
+
+	links, err := html.ExtractAllLinks(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	for _, link := range links {
+		if strings.HasSuffix(link.URL, "seg2.ts") && link.Type != "video" {
+			t.Errorf("link %+v, want Type video", link)
+		}
+	}
+
+	cfg.AdditionalVideoExtensions = []string{"m3u8|.*"}
+	if _, err := html.New(cfg); !errors.Is(err, html.ErrInvalidConfig) {
+		t.Errorf("New(AdditionalVideoExtensions=%q) error = %v, want ErrInvalidConfig", cfg.AdditionalVideoExtensions, err)
+	}
+}
+
+func TestStreamManifests(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>Watch live at https://cdn.example.com/live/master.m3u8 or in the DASH player.</p>
+		<video><source src="https://cdn.example.com/vod/stream" type="application/vnd.apple.mpegurl"></video>
+		<video src="https://cdn.example.com/vod/manifest.mpd"></video>
+		<video src="https://cdn.example.com/vod/clip.mp4"></video>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	want := map[string]string{
+		"https://cdn.example.com/live/master.m3u8": "hls",
+		"https://cdn.example.com/vod/stream":       "hls",
+		"https://cdn.example.com/vod/manifest.mpd": "dash",
+	}
+	found := 0
+	for _, v := range result.Videos {
+		kind, manifest := want[v.URL]
+		if v.StreamManifest != manifest || (manifest && v.Type != kind) {
+			t.Errorf("video %q: Type %q, StreamManifest %v; want manifest %v of type %q", v.URL, v.Type, v.StreamManifest, manifest, kind)
+		}
+		if manifest {
+			found++
+		}
+	}
+	if found != len(want) {
+		t.Errorf("Videos = %+v, want all %d manifests", result.Videos, len(want))
+	}
+
+	links, err := html.ExtractAllLinks(doc)
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+	for _, link := range links {
+		if link.URL == "https://cdn.example.com/vod/stream" && link.Type != "video" {
+			t.Errorf("link %+v, want Type video from the manifest MIME type", link)
+		}
+	}
+}
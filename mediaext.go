@@ -0,0 +1,156 @@
+package html
+
+// mediaext.go adds Config.AdditionalVideoExtensions and
+// AdditionalAudioExtensions to the built-in media type detection.
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+)
+
+// Extensions matched by videoRegex and audioRegex, as regexp alternations.
+const (
+	videoRegexExtensions = "mp4|webm|ogg|mov|avi|wmv|flv|mkv|m4v|3gp|m3u8|mpd"
+	audioRegexExtensions = "mp3|wav|ogg|m4a|aac|flac|wma|opus|oga"
+)
+
+// mediaURLRegex compiles the pattern of videoRegex and audioRegex for the
+// extension alternation exts.
+func mediaURLRegex(exts string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)https?://[^\s<>"',;)}\]]{1,500}\.(?:` + exts + `)`)
+}
+
+// mediaExtensions is the compiled form of the additional media extensions. A
+// nil *mediaExtensions detects the built-in extensions only.
+type mediaExtensions struct {
+	video, audio           []string // lower-cased, without the dot
+	videoRegex, audioRegex *regexp.Regexp
+}
+
+// newMediaExtensions compiles the additional extensions, returning nil when
+// there are none. Extensions are expected to have passed Config.Validate.
+func newMediaExtensions(video, audio []string) *mediaExtensions {
+	video, audio = normalizeExtensions(video), normalizeExtensions(audio)
+	if len(video) == 0 && len(audio) == 0 {
+		return nil
+	}
+	m := &mediaExtensions{video: video, audio: audio, videoRegex: videoRegex, audioRegex: audioRegex}
+	if len(video) > 0 {
+		m.videoRegex = mediaURLRegex(videoRegexExtensions + "|" + strings.Join(video, "|"))
+	}
+	if len(audio) > 0 {
+		m.audioRegex = mediaURLRegex(audioRegexExtensions + "|" + strings.Join(audio, "|"))
+	}
+	return m
+}
+
+// normalizeExtensions returns exts trimmed, lower-cased and without a leading
+// dot, dropping empty entries.
+func normalizeExtensions(exts []string) []string {
+	var out []string
+	for _, ext := range exts {
+		if ext = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), ".")); ext != "" {
+			out = append(out, ext)
+		}
+	}
+	return out
+}
+
+// validExtension reports whether ext, with an optional leading dot, consists
+// of ASCII letters and digits only, so that it is safe in a regexp.
+func validExtension(ext string) bool {
+	ext = strings.TrimPrefix(strings.TrimSpace(ext), ".")
+	if ext == "" {
+		return false
+	}
+	for i := 0; i < len(ext); i++ {
+		c := ext[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// videoURLRegex returns the regexp used to scan raw HTML for video URLs.
+func (m *mediaExtensions) videoURLRegex() *regexp.Regexp {
+	if m == nil {
+		return videoRegex
+	}
+	return m.videoRegex
+}
+
+// audioURLRegex returns the regexp used to scan raw HTML for audio URLs.
+func (m *mediaExtensions) audioURLRegex() *regexp.Regexp {
+	if m == nil {
+		return audioRegex
+	}
+	return m.audioRegex
+}
+
+// videoType is internal.DetectVideoType, falling back to "video/<ext>" for an
+// additional video extension.
+func (m *mediaExtensions) videoType(url string) string {
+	if mimeType := internal.DetectVideoType(url); mimeType != "" || m == nil {
+		return mimeType
+	}
+	if ext := matchExtension(url, m.video); ext != "" {
+		return "video/" + ext
+	}
+	return ""
+}
+
+// audioType is internal.DetectAudioType, falling back to "audio/<ext>" for an
+// additional audio extension.
+func (m *mediaExtensions) audioType(url string) string {
+	if mimeType := internal.DetectAudioType(url); mimeType != "" || m == nil {
+		return mimeType
+	}
+	if ext := matchExtension(url, m.audio); ext != "" {
+		return "audio/" + ext
+	}
+	return ""
+}
+
+// isVideoURL is internal.IsVideoURL, also accepting additional video extensions.
+func (m *mediaExtensions) isVideoURL(url string) bool {
+	return internal.IsVideoURL(url) || (m != nil && matchExtension(url, m.video) != "")
+}
+
+// mayReference reports whether htmlContent mentions any additional extension,
+// so that the raw-HTML media scans are not skipped for pages referencing only
+// those.
+func (m *mediaExtensions) mayReference(htmlContent string) bool {
+	if m == nil {
+		return false
+	}
+	for _, exts := range [...][]string{m.video, m.audio} {
+		for _, ext := range exts {
+			if containsASCIIFold(htmlContent, "."+ext) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchExtension returns the entry of exts that the path of url ends with,
+// ignoring case, the query string and the fragment, or "" when none does.
+func matchExtension(url string, exts []string) string {
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	dot := strings.LastIndexByte(url, '.')
+	if dot < 0 || strings.IndexByte(url[dot:], '/') >= 0 {
+		return ""
+	}
+	suffix := strings.ToLower(url[dot+1:])
+	for _, ext := range exts {
+		if suffix == ext {
+			return ext
+		}
+	}
+	return ""
+}
@@ -0,0 +1,343 @@
+package html
+
+// metadata.go extracts document-level metadata such as Open Graph tags and
+// link relations from <meta> and <link> elements in the document head.
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// documentSignals holds the metadata that must be gathered before sanitization
+// removes the JSON-LD scripts and attributes it is read from.
+type documentSignals struct {
+	dates       documentDates
+	author      string
+	microdata   []MicrodataItem
+	breadcrumbs []string
+}
+
+// collectSignals gathers dates, the author, microdata and breadcrumbs from the
+// unsanitized document.
+func collectSignals(doc *stdxhtml.Node) documentSignals {
+	return documentSignals{
+		dates:       collectDates(doc),
+		author:      collectAuthor(doc),
+		microdata:   collectMicrodata(doc),
+		breadcrumbs: collectBreadcrumbs(doc),
+	}
+}
+
+// apply populates the result fields derived from the collected signals.
+func (s documentSignals) apply(result *Result) {
+	s.dates.apply(result)
+	result.Author = s.author
+	result.Microdata = s.microdata
+	result.Breadcrumbs = s.breadcrumbs
+}
+
+// extractMetadata populates the document-level metadata fields of result and
+// returns the meta description, falling back to og:description, from which the
+// excerpt is built once the text is known.
+// It must run on the full document before CleanContentNode, because metadata
+// lives in <head> and is never part of the article node. The walk stops at
+// <body> since metadata elements outside the head are invalid HTML.
+func (p *Processor) extractMetadata(doc *stdxhtml.Node, baseURL string, result *Result) (description string) {
+	if doc == nil {
+		return ""
+	}
+	var twitterImage, sitemapHref, ogDescription string
+	var canonicalHref, ogURL, refreshHref string
+	var ogImageWidth, ogImageHeight int
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		switch n.Data {
+		case "body":
+			return false
+		case "meta":
+			if result.DeclaredCharset == "" {
+				result.DeclaredCharset = declaredCharset(n)
+			}
+			key, content := metaKeyContent(n)
+			if content == "" {
+				return true
+			}
+			switch strings.ToLower(strings.TrimSpace(internal.GetAttr(n, "http-equiv"))) {
+			case "x-robots-tag":
+				key = "robots"
+			case "refresh":
+				key = "http-equiv:refresh"
+			}
+			switch key {
+			case "robots":
+				parseRobotsDirectives(content, &result.Robots)
+			case "og:image", "og:image:url", "og:image:secure_url":
+				if result.SocialImage == "" && internal.IsValidURL(content) {
+					result.SocialImage = content
+				}
+			case "og:image:width":
+				if ogImageWidth == 0 {
+					ogImageWidth = parseMetaDimension(content)
+				}
+			case "og:image:height":
+				if ogImageHeight == 0 {
+					ogImageHeight = parseMetaDimension(content)
+				}
+			case "twitter:image", "twitter:image:src":
+				if twitterImage == "" && internal.IsValidURL(content) {
+					twitterImage = content
+				}
+			case "description":
+				if description == "" {
+					description = content
+				}
+			case "og:description":
+				if ogDescription == "" {
+					ogDescription = content
+				}
+			case "og:url":
+				if ogURL == "" && internal.IsExternalURL(content) {
+					ogURL = content
+				}
+			case "sitemap":
+				if sitemapHref == "" && internal.IsValidURL(content) {
+					sitemapHref = content
+				}
+			case "http-equiv:refresh":
+				if refreshHref == "" {
+					refreshHref = parseMetaRefresh(content)
+				}
+			}
+		case "base":
+			if result.BaseTarget == "" {
+				result.BaseTarget = strings.TrimSpace(internal.GetAttr(n, "target"))
+			}
+		case "link":
+			rel, href := linkRelHref(n)
+			if href == "" || !internal.IsValidURL(href) {
+				return true
+			}
+			if sitemapHref == "" && relHasToken(rel, "sitemap") {
+				sitemapHref = href
+			}
+			if canonicalHref == "" && relHasToken(rel, "canonical") {
+				canonicalHref = href
+			}
+		}
+		return true
+	})
+	// The og:image dimensions do not describe a twitter:image fallback.
+	if result.SocialImage != "" {
+		result.SocialImageWidth, result.SocialImageHeight = ogImageWidth, ogImageHeight
+	} else {
+		result.SocialImage = twitterImage
+	}
+	if sitemapHref != "" {
+		result.SitemapURL = p.resolveDocumentURL(baseURL, sitemapHref)
+	}
+	if refreshHref != "" {
+		result.MetaRefresh = p.resolveDocumentURL(baseURL, refreshHref)
+	}
+	if canonicalHref != "" {
+		result.CanonicalURL = p.resolveDocumentURL(baseURL, canonicalHref)
+	} else {
+		result.CanonicalURL = ogURL
+	}
+	if description == "" {
+		description = ogDescription
+	}
+	return description
+}
+
+// extractFeeds returns the RSS and Atom feeds declared by <link rel="alternate">
+// elements anywhere in doc, in document order and without duplicate URLs,
+// resolved against baseURL, the documentBaseURL of doc.
+func (p *Processor) extractFeeds(doc *stdxhtml.Node, baseURL string) []FeedLink {
+	var feeds []FeedLink
+	seen := make(map[string]struct{})
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode || n.Data != "link" {
+			return true
+		}
+		rel, href := linkRelHref(n)
+		if href == "" || !relHasToken(rel, "alternate") || !internal.IsValidURL(href) {
+			return true
+		}
+		var feedType string
+		switch strings.ToLower(strings.TrimSpace(internal.GetAttr(n, "type"))) {
+		case "application/rss+xml":
+			feedType = "rss"
+		case "application/atom+xml":
+			feedType = "atom"
+		default:
+			return true
+		}
+		url := p.resolveDocumentURL(baseURL, href)
+		if _, dup := seen[url]; dup {
+			return true
+		}
+		seen[url] = struct{}{}
+		feeds = append(feeds, FeedLink{
+			URL:   url,
+			Title: strings.TrimSpace(internal.GetAttr(n, "title")),
+			Type:  feedType,
+		})
+		return true
+	})
+	return feeds
+}
+
+// resolveDocumentURL resolves a document-relative URL found in metadata
+// against baseURL, the documentBaseURL of the document: BaseURL when configured
+// and otherwise the base detected from the document itself, so metadata URLs
+// resolve even without an explicit BaseURL. Callers detect it once per
+// document. URLs with a scheme, such as data: URLs, are returned unchanged.
+func (p *Processor) resolveDocumentURL(baseURL, raw string) string {
+	if !p.config.ResolveRelativeURLs || internal.IsExternalURL(raw) || hasURLScheme(raw) {
+		return raw
+	}
+	return p.resolveURLIfEnabled(baseURL, raw)
+}
+
+// linkRelHref returns the lower-cased rel and the trimmed href of a <link> element.
+func linkRelHref(n *stdxhtml.Node) (rel, href string) {
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "rel":
+			rel = strings.ToLower(attr.Val)
+		case "href":
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+	return rel, href
+}
+
+// relHasToken reports whether the space-separated rel value contains token.
+func relHasToken(rel, token string) bool {
+	for _, t := range strings.Fields(rel) {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// metaKeyContent returns the lower-cased property (or name) and the trimmed
+// content of a <meta> element. Open Graph uses property, while most other
+// vocabularies use name; property wins when both are present.
+func metaKeyContent(n *stdxhtml.Node) (key, content string) {
+	var property, name string
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "property":
+			property = attr.Val
+		case "name":
+			name = attr.Val
+		case "content":
+			content = strings.TrimSpace(attr.Val)
+		}
+	}
+	if property == "" {
+		property = name
+	}
+	return strings.ToLower(strings.TrimSpace(property)), content
+}
+
+// declaredCharset returns the lower-cased charset declared by a <meta
+// charset> element or by the charset parameter of a <meta
+// http-equiv="Content-Type"> element, whether or not it is supported, and ""
+// for any other <meta>.
+func declaredCharset(n *stdxhtml.Node) string {
+	if charset := strings.TrimSpace(internal.GetAttr(n, "charset")); charset != "" {
+		return strings.ToLower(charset)
+	}
+	if !strings.EqualFold(strings.TrimSpace(internal.GetAttr(n, "http-equiv")), "content-type") {
+		return ""
+	}
+	for _, param := range strings.Split(internal.GetAttr(n, "content"), ";") {
+		key, value, found := strings.Cut(param, "=")
+		if found && strings.EqualFold(strings.TrimSpace(key), "charset") {
+			return strings.ToLower(strings.Trim(strings.TrimSpace(value), `"'`))
+		}
+	}
+	return ""
+}
+
+// parseMetaRefresh returns the trimmed target URL of a <meta http-equiv="refresh">
+// content value such as "0; url=/new-page", following the HTML parsing rules:
+// a delay, then an optional "url=" prefix and a possibly quoted URL. A refresh
+// without a URL reloads the page itself and yields "", and so does a target
+// with a scheme other than http or https, such as javascript:.
+func parseMetaRefresh(content string) string {
+	s := strings.TrimLeft(content, " \t\n\f\r0123456789.")
+	if s == content || s == "" {
+		return ""
+	}
+	if s[0] != ';' && s[0] != ',' && s[0] != ' ' && s[0] != '\t' {
+		return ""
+	}
+	s = strings.TrimLeft(s, " \t\n\f\r;,")
+	if len(s) >= 3 && strings.EqualFold(s[:3], "url") {
+		if rest := strings.TrimLeft(s[3:], " \t\n\f\r"); strings.HasPrefix(rest, "=") {
+			s = strings.TrimLeft(rest[1:], " \t\n\f\r")
+		}
+	}
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		if end := strings.IndexByte(s[1:], s[0]); end >= 0 {
+			s = s[1 : end+1]
+		} else {
+			s = s[1:]
+		}
+	}
+	s = strings.TrimSpace(s)
+	if s == "" || !internal.IsValidURL(s) {
+		return ""
+	}
+	if hasURLScheme(s) {
+		if lower := strings.ToLower(s); !strings.HasPrefix(lower, "http:") && !strings.HasPrefix(lower, "https:") {
+			return ""
+		}
+	}
+	return s
+}
+
+// parseRobotsDirectives adds the directives listed in a robots meta content
+// value to d. Directives are matched case-insensitively and may be separated
+// by commas or spaces. A user-agent prefix such as "googlebot:" is stripped,
+// with or without a space after the colon, so scoped directives apply to all
+// crawlers.
+func parseRobotsDirectives(content string, d *RobotsDirectives) {
+	for _, directive := range strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		if _, rest, ok := strings.Cut(directive, ":"); ok {
+			directive = rest
+		}
+		switch directive {
+		case "noindex":
+			d.NoIndex = true
+		case "nofollow":
+			d.NoFollow = true
+		case "noarchive":
+			d.NoArchive = true
+		case "none":
+			d.NoIndex = true
+			d.NoFollow = true
+		}
+	}
+}
+
+// parseMetaDimension parses a pixel dimension from a meta content value,
+// returning 0 for missing, malformed, or non-positive values.
+func parseMetaDimension(s string) int {
+	v, err := strconv.Atoi(strings.TrimSuffix(s, "px"))
+	if err != nil || v <= 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,385 @@
+package html
+
+// metadata_only.go implements the metadata-only fast path, which skips article
+// scoring and text, image, link and media extraction.
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// metadataBodyPrefix is how much of the body ExtractMetadataOnly parses, enough
+// for the bylines, <time> elements and headings that usually open an article.
+const metadataBodyPrefix = 16 * 1024
+
+// Metadata holds document-level metadata returned by ExtractMetadataOnly.
+type Metadata struct {
+	// Title is the document title, as in Result.Title.
+	Title string `json:"title"`
+	// Description is the meta description, falling back to og:description.
+	Description string `json:"description,omitempty"`
+	// CanonicalURL is the canonical address, as in Result.CanonicalURL.
+	CanonicalURL string `json:"canonical_url,omitempty"`
+	// SocialImage is the og:image URL, falling back to twitter:image.
+	SocialImage string `json:"social_image,omitempty"`
+	// SocialImageWidth is the og:image:width value in pixels, as in Result.SocialImageWidth.
+	SocialImageWidth int `json:"social_image_width,omitempty"`
+	// SocialImageHeight is the og:image:height value in pixels, as in Result.SocialImageHeight.
+	SocialImageHeight int `json:"social_image_height,omitempty"`
+	// SitemapURL is the sitemap linked via <link rel="sitemap">.
+	SitemapURL string `json:"sitemap_url,omitempty"`
+	// MetaRefresh is the client-side redirect target, as in Result.MetaRefresh.
+	MetaRefresh string `json:"meta_refresh,omitempty"`
+	// DeclaredCharset is the charset declared by a <meta> tag, as in Result.DeclaredCharset.
+	DeclaredCharset string `json:"declared_charset,omitempty"`
+	// BaseTarget is the default link target from <base target>, as in Result.BaseTarget.
+	BaseTarget string `json:"base_target,omitempty"`
+	// Author is the author, as in Result.Author.
+	Author string `json:"author,omitempty"`
+	// Robots holds the crawler directives, as in Result.Robots.
+	Robots RobotsDirectives `json:"robots"`
+	// Breadcrumbs is the category path of the page, as in Result.Breadcrumbs.
+	Breadcrumbs []string `json:"breadcrumbs,omitempty"`
+	// PublishedAt is the publication time, as in Result.PublishedAt.
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// ModifiedAt is the last-modified time, as in Result.ModifiedAt.
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+	// ThemeColor is the browser UI color from <meta name="theme-color">, preferring a tag
+	// without a media query, e.g. "#4285f4".
+	ThemeColor string `json:"theme_color,omitempty"`
+	// AppleMobileWebAppTitle is the home screen title from <meta name="apple-mobile-web-app-title">.
+	AppleMobileWebAppTitle string `json:"apple_mobile_web_app_title,omitempty"`
+	// Viewport is the raw content of <meta name="viewport">, e.g. "width=device-width, initial-scale=1".
+	Viewport string `json:"viewport,omitempty"`
+	// IsResponsive reports whether Viewport adapts the layout to the device: it sets
+	// width=device-width, or sets initial-scale without a fixed width.
+	IsResponsive bool `json:"is_responsive"`
+	// ManifestURL is the web app manifest linked via <link rel="manifest">, resolved against
+	// the document base.
+	ManifestURL string `json:"manifest_url,omitempty"`
+	// MaskIconColor is the color attribute of the Safari pinned-tab icon, <link rel="mask-icon">.
+	MaskIconColor string `json:"mask_icon_color,omitempty"`
+	// AMPURL is the AMP version of the page linked via <link rel="amphtml">, resolved against
+	// the document base.
+	AMPURL string `json:"amp_url,omitempty"`
+	// OEmbedURL is the oEmbed endpoint discovered via <link rel="alternate"> of type
+	// application/json+oembed, falling back to text/xml+oembed, resolved against the document base.
+	OEmbedURL string `json:"oembed_url,omitempty"`
+	// Alternates maps the hreflang of each <link rel="alternate" hreflang="..."> translation,
+	// such as "de" or "x-default", to its URL resolved against the document base.
+	Alternates map[string]string `json:"alternates,omitempty"`
+	// MetaKeywords lists the comma-separated terms of <meta name="keywords">, trimmed.
+	MetaKeywords []string `json:"meta_keywords,omitempty"`
+	// OpenGraph maps each og:* property (e.g. "og:type", "og:site_name") to its first content value.
+	OpenGraph map[string]string `json:"open_graph,omitempty"`
+	// Feeds lists the advertised RSS and Atom feeds; empty unless ExtractFeeds is set.
+	Feeds []FeedLink `json:"feeds,omitempty"`
+}
+
+// ExtractMetadataOnly returns the document metadata of htmlContent without
+// extracting its content. Only the <head> and the first 16KB of the body are
+// parsed, and article scoring, text cleaning and the image, link and media
+// walks are skipped, making it much cheaper than Extract when only metadata is
+// needed, for example to pre-filter pages during a crawl. Metadata gathered
+// from the body, such as bylines and <time> elements, is therefore only found
+// near its start. ExtractMetadata does not apply; metadata is always extracted.
+func (p *Processor) ExtractMetadataOnly(htmlContent string) (Metadata, error) {
+	return recoverPanic(func() (Metadata, error) {
+		meta, _, err := p.extractMetadataOnly(htmlContent)
+		return meta, err
+	})
+}
+
+// extractMetadataOnly implements ExtractMetadataOnly, also returning the
+// parsed, sanitized document for callers that derive more from it; the
+// document is nil for blank content.
+func (p *Processor) extractMetadataOnly(htmlContent string) (Metadata, *stdxhtml.Node, error) {
+	if err := p.validateInput([]byte(htmlContent)); err != nil {
+		return Metadata{}, nil, err
+	}
+	if p.isBlankContent(htmlContent) {
+		return Metadata{}, nil, nil
+	}
+	startTime := time.Now()
+
+	doc, err := stdxhtml.Parse(strings.NewReader(metadataPrefix(htmlContent)))
+	if err != nil {
+		p.stats.errorCount.Add(1)
+		return Metadata{}, nil, newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+	}
+	if err := p.validateDepthTraversal(doc, 0); err != nil {
+		p.stats.errorCount.Add(1)
+		return Metadata{}, nil, err
+	}
+
+	signals := collectSignals(doc)
+	if p.config.EnableSanitization {
+		internal.SanitizeDOM(doc, internal.NoOpAuditRecorder{})
+	}
+
+	baseURL := p.documentBaseURL(doc)
+	result := &Result{Title: p.extractTitle(doc)}
+	description := p.extractMetadata(doc, baseURL, result)
+	signals.apply(result)
+	meta := Metadata{
+		Title:             result.Title,
+		Description:       strings.TrimSpace(description),
+		CanonicalURL:      result.CanonicalURL,
+		SocialImage:       result.SocialImage,
+		SocialImageWidth:  result.SocialImageWidth,
+		SocialImageHeight: result.SocialImageHeight,
+		SitemapURL:        result.SitemapURL,
+		MetaRefresh:       result.MetaRefresh,
+		DeclaredCharset:   result.DeclaredCharset,
+		BaseTarget:        result.BaseTarget,
+		Author:            result.Author,
+		Robots:            result.Robots,
+		Breadcrumbs:       result.Breadcrumbs,
+		PublishedAt:       result.PublishedAt,
+		ModifiedAt:        result.ModifiedAt,
+		MetaKeywords:      metaKeywords(doc),
+		OpenGraph:         openGraphProperties(doc),
+	}
+	p.extractAppMetadata(doc, baseURL, &meta)
+	p.extractAlternates(doc, baseURL, &meta)
+	if p.config.ExtractFeeds {
+		meta.Feeds = p.extractFeeds(doc, baseURL)
+	}
+
+	p.stats.totalProcessTime.Add(int64(time.Since(startTime)))
+	p.stats.totalProcessed.Add(1)
+	return meta, doc, nil
+}
+
+// ExtractMetadataOnly returns the document metadata of htmlContent without
+// extracting its content.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractMetadataOnly for what is parsed.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractMetadataOnly(htmlContent string, cfg ...Config) (Metadata, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (Metadata, error) {
+		return p.ExtractMetadataOnly(htmlContent)
+	})
+}
+
+// metadataPrefix returns htmlContent cut metadataBodyPrefix bytes after the
+// opening <body> tag. Documents without one are cut after 8 times that, which
+// still covers unusually large heads.
+func metadataPrefix(htmlContent string) string {
+	limit := 8 * metadataBodyPrefix
+	for i := strings.IndexByte(htmlContent, '<'); i >= 0 && i < len(htmlContent); {
+		if len(htmlContent)-i >= 5 && strings.EqualFold(htmlContent[i:i+5], "<body") {
+			limit = i + metadataBodyPrefix
+			break
+		}
+		next := strings.IndexByte(htmlContent[i+1:], '<')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	if len(htmlContent) <= limit {
+		return htmlContent
+	}
+	return htmlContent[:limit]
+}
+
+// openGraphProperties collects the first content of each og:* <meta> in the head.
+func openGraphProperties(doc *stdxhtml.Node) map[string]string {
+	var props map[string]string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		if n.Data == "body" {
+			return false
+		}
+		if n.Data == "meta" {
+			key, content := metaKeyContent(n)
+			if strings.HasPrefix(key, "og:") && content != "" {
+				if props == nil {
+					props = make(map[string]string)
+				}
+				if _, ok := props[key]; !ok {
+					props[key] = content
+				}
+			}
+		}
+		return true
+	})
+	return props
+}
+
+// metaKeywords returns the trimmed, non-empty terms of the first
+// <meta name="keywords"> in the head.
+func metaKeywords(doc *stdxhtml.Node) []string {
+	var keywords []string
+	found := false
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if found || n.Type != stdxhtml.ElementNode {
+			return !found
+		}
+		if n.Data == "body" {
+			return false
+		}
+		if n.Data == "meta" {
+			if key, content := metaKeyContent(n); key == "keywords" && content != "" {
+				found = true
+				for _, term := range strings.Split(content, ",") {
+					if term = strings.TrimSpace(term); term != "" {
+						keywords = append(keywords, term)
+					}
+				}
+			}
+		}
+		return true
+	})
+	return keywords
+}
+
+// extractAppMetadata fills the web app fields of meta, the theme color, home
+// screen title, viewport, manifest and mask icon color, from the head of doc.
+func (p *Processor) extractAppMetadata(doc *stdxhtml.Node, baseURL string, meta *Metadata) {
+	var mediaThemeColor, manifestHref string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		switch n.Data {
+		case "body":
+			return false
+		case "meta":
+			key, content := metaKeyContent(n)
+			if content == "" {
+				return true
+			}
+			switch key {
+			case "theme-color":
+				// Sites pair light and dark colors with media queries; the
+				// plain tag is the default.
+				if internal.GetAttr(n, "media") == "" {
+					if meta.ThemeColor == "" {
+						meta.ThemeColor = content
+					}
+				} else if mediaThemeColor == "" {
+					mediaThemeColor = content
+				}
+			case "apple-mobile-web-app-title":
+				if meta.AppleMobileWebAppTitle == "" {
+					meta.AppleMobileWebAppTitle = content
+				}
+			case "viewport":
+				if meta.Viewport == "" {
+					meta.Viewport = content
+				}
+			}
+		case "link":
+			rel, href := linkRelHref(n)
+			if manifestHref == "" && relHasToken(rel, "manifest") && href != "" && internal.IsValidURL(href) {
+				manifestHref = href
+			}
+			if meta.MaskIconColor == "" && relHasToken(rel, "mask-icon") {
+				meta.MaskIconColor = strings.TrimSpace(internal.GetAttr(n, "color"))
+			}
+		}
+		return true
+	})
+	if meta.ThemeColor == "" {
+		meta.ThemeColor = mediaThemeColor
+	}
+	if manifestHref != "" {
+		meta.ManifestURL = p.resolveDocumentURL(baseURL, manifestHref)
+	}
+	meta.IsResponsive = isResponsiveViewport(meta.Viewport)
+}
+
+// isResponsiveViewport reports whether the viewport content, a list of
+// key=value pairs separated by commas or semicolons, sets width=device-width,
+// or sets initial-scale without a fixed pixel width.
+func isResponsiveViewport(content string) bool {
+	var width string
+	hasInitialScale := false
+	for _, pair := range strings.FieldsFunc(content, func(r rune) bool { return r == ',' || r == ';' }) {
+		key, value, _ := strings.Cut(pair, "=")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "width":
+			width = strings.ToLower(strings.TrimSpace(value))
+		case "initial-scale":
+			hasInitialScale = strings.TrimSpace(value) != ""
+		}
+	}
+	return width == "device-width" || (width == "" && hasInitialScale)
+}
+
+// extractAlternates fills the AMP URL, the oEmbed URL and the hreflang
+// alternates of meta from the <link> elements in the head of doc. The first
+// link for each hreflang wins, and a JSON oEmbed link wins over an XML one.
+func (p *Processor) extractAlternates(doc *stdxhtml.Node, baseURL string, meta *Metadata) {
+	var xmlOEmbedHref string
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type != stdxhtml.ElementNode {
+			return true
+		}
+		if n.Data == "body" {
+			return false
+		}
+		if n.Data != "link" {
+			return true
+		}
+		rel, href := linkRelHref(n)
+		if href == "" || !internal.IsValidURL(href) {
+			return true
+		}
+		if meta.AMPURL == "" && relHasToken(rel, "amphtml") {
+			meta.AMPURL = p.resolveDocumentURL(baseURL, href)
+		}
+		if lang := strings.TrimSpace(internal.GetAttr(n, "hreflang")); lang != "" && relHasToken(rel, "alternate") {
+			if meta.Alternates == nil {
+				meta.Alternates = make(map[string]string)
+			}
+			if _, ok := meta.Alternates[lang]; !ok {
+				meta.Alternates[lang] = p.resolveDocumentURL(baseURL, href)
+			}
+		}
+		if relHasToken(rel, "alternate") {
+			switch oEmbedFormat(internal.GetAttr(n, "type")) {
+			case "json":
+				if meta.OEmbedURL == "" {
+					meta.OEmbedURL = p.resolveDocumentURL(baseURL, href)
+				}
+			case "xml":
+				if xmlOEmbedHref == "" {
+					xmlOEmbedHref = href
+				}
+			}
+		}
+		return true
+	})
+	if meta.OEmbedURL == "" && xmlOEmbedHref != "" {
+		meta.OEmbedURL = p.resolveDocumentURL(baseURL, xmlOEmbedHref)
+	}
+}
+
+// oEmbedFormat returns "json" or "xml" for the type of an oEmbed discovery
+// link, such as "application/json+oembed" or "text/xml+oembed", and "" for
+// any other type. Parameters such as "; charset=utf-8" are ignored.
+func oEmbedFormat(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	switch strings.ToLower(strings.TrimSpace(mimeType)) {
+	case "application/json+oembed":
+		return "json"
+	case "text/xml+oembed", "application/xml+oembed":
+		return "xml"
+	}
+	return ""
+}
@@ -0,0 +1,236 @@
+package html_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractMetadataOnly(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<title>Launch Day</title>
+		<meta name="description" content="  We shipped the new release.  ">
+		<meta property="og:type" content="article">
+		<meta property="og:site_name" content="Example Blog">
+		<meta property="og:image" content="https://example.com/cover.png">
+		<meta property="og:image:width" content="1200">
+		<meta property="article:published_time" content="2024-05-01T09:00:00Z">
+		<meta name="author" content="Dana Scully">
+		<link rel="canonical" href="https://example.com/posts/launch">
+		<link rel="alternate" type="application/rss+xml" href="https://example.com/feed.xml">
+	</head><body><article><h1>Launch Day</h1><p>` + strings.Repeat("Body text. ", 10000) + `</p>
+		<time datetime="1999-01-01">far below the parsed prefix</time></article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractFeeds = true
+	meta, err := html.ExtractMetadataOnly(doc, cfg)
+	if err != nil {
+		t.Fatalf("ExtractMetadataOnly() error = %v", err)
+	}
+
+	published := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	switch {
+	case meta.Title != "Launch Day":
+		t.Errorf("Title = %q, want Launch Day", meta.Title)
+	case meta.Description != "We shipped the new release.":
+		t.Errorf("Description = %q", meta.Description)
+	case meta.CanonicalURL != "https://example.com/posts/launch":
+		t.Errorf("CanonicalURL = %q", meta.CanonicalURL)
+	case meta.SocialImage != "https://example.com/cover.png" || meta.SocialImageWidth != 1200:
+		t.Errorf("SocialImage = %q (%d wide)", meta.SocialImage, meta.SocialImageWidth)
+	case meta.Author != "Dana Scully":
+		t.Errorf("Author = %q", meta.Author)
+	case meta.PublishedAt == nil || !meta.PublishedAt.Equal(published):
+		t.Errorf("PublishedAt = %v, want %v", meta.PublishedAt, published)
+	case meta.OpenGraph["og:type"] != "article" || meta.OpenGraph["og:site_name"] != "Example Blog":
+		t.Errorf("OpenGraph = %v", meta.OpenGraph)
+	case len(meta.Feeds) != 1 || meta.Feeds[0].URL != "https://example.com/feed.xml":
+		t.Errorf("Feeds = %+v", meta.Feeds)
+	}
+
+	full, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if full.Title != meta.Title || full.CanonicalURL != meta.CanonicalURL || full.Author != meta.Author {
+		t.Errorf("metadata differs from Extract: %+v vs title %q canonical %q author %q", meta, full.Title, full.CanonicalURL, full.Author)
+	}
+
+	t.Run("empty input", func(t *testing.T) {
+		meta, err := html.ExtractMetadataOnly("   ")
+		if err != nil || meta.Title != "" {
+			t.Errorf("ExtractMetadataOnly(blank) = %+v, %v", meta, err)
+		}
+	})
+
+	t.Run("closed processor", func(t *testing.T) {
+		p, err := html.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = p.Close()
+		if _, err := p.ExtractMetadataOnly(doc); err == nil {
+			t.Error("ExtractMetadataOnly() on closed processor should fail")
+		}
+	})
+}
+
+func TestExtractMetadataOnlyAppMetadata(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<base href="https://example.com/">
+		<meta name="theme-color" media="(prefers-color-scheme: dark)" content="#202124">
+		<meta name="theme-color" content="#4285f4">
+		<meta name="apple-mobile-web-app-title" content="Example">
+		<meta name="viewport" content="width=device-width, initial-scale=1">
+		<link rel="manifest" href="/site.webmanifest">
+		<link rel="mask-icon" href="/safari-pinned-tab.svg" color="#5bbad5">
+	</head><body><p>App shell.</p></body></html>`
+
+	meta, err := html.ExtractMetadataOnly(doc)
+	if err != nil {
+		t.Fatalf("ExtractMetadataOnly() error = %v", err)
+	}
+	if meta.ThemeColor != "#4285f4" {
+		t.Errorf("ThemeColor = %q, want the tag without a media query", meta.ThemeColor)
+	}
+	if meta.AppleMobileWebAppTitle != "Example" {
+		t.Errorf("AppleMobileWebAppTitle = %q", meta.AppleMobileWebAppTitle)
+	}
+	if meta.Viewport != "width=device-width, initial-scale=1" || !meta.IsResponsive {
+		t.Errorf("Viewport = %q, IsResponsive = %v", meta.Viewport, meta.IsResponsive)
+	}
+	if meta.ManifestURL != "https://example.com/site.webmanifest" {
+		t.Errorf("ManifestURL = %q, want it resolved against <base>", meta.ManifestURL)
+	}
+	if meta.MaskIconColor != "#5bbad5" {
+		t.Errorf("MaskIconColor = %q", meta.MaskIconColor)
+	}
+
+	t.Run("viewport", func(t *testing.T) {
+		for content, want := range map[string]bool{
+			"":                            false,
+			"width=980":                   false,
+			"width=1024, initial-scale=1": false,
+			"initial-scale=1.0":           true,
+			"WIDTH = Device-Width":        true,
+		} {
+			meta, err := html.ExtractMetadataOnly(`<html><head><meta name="viewport" content="` + content + `"></head><body></body></html>`)
+			if err != nil {
+				t.Fatalf("ExtractMetadataOnly() error = %v", err)
+			}
+			if meta.IsResponsive != want {
+				t.Errorf("IsResponsive for %q = %v, want %v", content, meta.IsResponsive, want)
+			}
+		}
+	})
+
+	t.Run("media-only theme color", func(t *testing.T) {
+		meta, err := html.ExtractMetadataOnly(`<html><head><meta name="theme-color" media="(prefers-color-scheme: light)" content="white"></head><body></body></html>`)
+		if err != nil {
+			t.Fatalf("ExtractMetadataOnly() error = %v", err)
+		}
+		if meta.ThemeColor != "white" {
+			t.Errorf("ThemeColor = %q, want white", meta.ThemeColor)
+		}
+	})
+}
+
+func TestExtractMetadataOnlyAlternates(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<base href="https://example.com/">
+		<link rel="amphtml" href="/amp/story.html">
+		<link rel="alternate" hreflang="de" href="/de/story.html">
+		<link rel="alternate" hreflang="en-US" href="https://example.com/en/story.html">
+		<link rel="alternate" hreflang="x-default" href="/story.html">
+		<link rel="alternate" hreflang="de" href="/de/duplicate.html">
+		<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+	</head><body><p>Story.</p></body></html>`
+
+	meta, err := html.ExtractMetadataOnly(doc)
+	if err != nil {
+		t.Fatalf("ExtractMetadataOnly() error = %v", err)
+	}
+	if meta.AMPURL != "https://example.com/amp/story.html" {
+		t.Errorf("AMPURL = %q", meta.AMPURL)
+	}
+	want := map[string]string{
+		"de":        "https://example.com/de/story.html",
+		"en-US":     "https://example.com/en/story.html",
+		"x-default": "https://example.com/story.html",
+	}
+	if !reflect.DeepEqual(meta.Alternates, want) {
+		t.Errorf("Alternates = %v, want %v", meta.Alternates, want)
+	}
+}
+
+func TestExtractMetadataOnlyOEmbed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		links string
+		want  string
+	}{
+		{
+			name:  "json",
+			links: `<link rel="alternate" type="application/json+oembed" href="/oembed?format=json">`,
+			want:  "https://example.com/oembed?format=json",
+		},
+		{
+			name:  "xml",
+			links: `<link rel="alternate" type="text/xml+oembed" href="/oembed?format=xml">`,
+			want:  "https://example.com/oembed?format=xml",
+		},
+		{
+			name: "json preferred over earlier xml",
+			links: `<link rel="alternate" type="text/xml+oembed" href="/oembed?format=xml">
+				<link rel="alternate" type="Application/JSON+oEmbed; charset=utf-8" href="/oembed?format=json">`,
+			want: "https://example.com/oembed?format=json",
+		},
+		{
+			name:  "not alternate",
+			links: `<link rel="stylesheet" type="application/json+oembed" href="/oembed">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			doc := `<html><head><base href="https://example.com/">` + tt.links +
+				`</head><body><p>Story.</p></body></html>`
+			meta, err := html.ExtractMetadataOnly(doc)
+			if err != nil {
+				t.Fatalf("ExtractMetadataOnly() error = %v", err)
+			}
+			if meta.OEmbedURL != tt.want {
+				t.Errorf("OEmbedURL = %q, want %q", meta.OEmbedURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractMetadataOnlyMetaKeywords(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<meta name="Keywords" content="golang, html parsing , ,extraction">
+		<meta name="keywords" content="ignored">
+	</head><body><p>Story.</p></body></html>`
+
+	meta, err := html.ExtractMetadataOnly(doc)
+	if err != nil {
+		t.Fatalf("ExtractMetadataOnly() error = %v", err)
+	}
+	want := []string{"golang", "html parsing", "extraction"}
+	if !reflect.DeepEqual(meta.MetaKeywords, want) {
+		t.Errorf("MetaKeywords = %q, want %q", meta.MetaKeywords, want)
+	}
+}
@@ -0,0 +1,552 @@
+package html_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/html"
+)
+
+func TestSocialImageDimensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		head       string
+		wantURL    string
+		wantWidth  int
+		wantHeight int
+	}{
+		{
+			name: "og image with dimensions",
+			head: `<meta property="og:image" content="https://example.com/card.png">
+				<meta property="og:image:width" content="1200">
+				<meta property="og:image:height" content="630">`,
+			wantURL:    "https://example.com/card.png",
+			wantWidth:  1200,
+			wantHeight: 630,
+		},
+		{
+			name: "first image dimensions win",
+			head: `<meta property="og:image" content="https://example.com/a.png">
+				<meta property="og:image:width" content="800">
+				<meta property="og:image:height" content="600">
+				<meta property="og:image" content="https://example.com/b.png">
+				<meta property="og:image:width" content="400">
+				<meta property="og:image:height" content="300">`,
+			wantURL:    "https://example.com/a.png",
+			wantWidth:  800,
+			wantHeight: 600,
+		},
+		{
+			name: "invalid dimensions ignored",
+			head: `<meta property="og:image" content="https://example.com/card.png">
+				<meta property="og:image:width" content="wide">
+				<meta property="og:image:height" content="-5">`,
+			wantURL: "https://example.com/card.png",
+		},
+		{
+			name:    "twitter image fallback",
+			head:    `<meta name="twitter:image" content="https://example.com/tw.png">`,
+			wantURL: "https://example.com/tw.png",
+		},
+		{
+			name: "og dimensions not applied to twitter fallback",
+			head: `<meta property="og:image:width" content="1200">
+				<meta property="og:image:height" content="630">
+				<meta name="twitter:image" content="https://example.com/tw.png">`,
+			wantURL: "https://example.com/tw.png",
+		},
+		{
+			name: "no social image",
+			head: `<meta name="description" content="A page">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := `<html><head>` + tt.head + `</head><body><p>Body text.</p></body></html>`
+			result, err := html.Extract([]byte(doc))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.SocialImage != tt.wantURL {
+				t.Errorf("SocialImage = %q, want %q", result.SocialImage, tt.wantURL)
+			}
+			if result.SocialImageWidth != tt.wantWidth || result.SocialImageHeight != tt.wantHeight {
+				t.Errorf("SocialImage dimensions = %dx%d, want %dx%d",
+					result.SocialImageWidth, result.SocialImageHeight, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestSocialImageJSON(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head><meta property="og:image" content="https://example.com/card.png">
+		<meta property="og:image:width" content="1200"><meta property="og:image:height" content="630">
+		</head><body><p>Body</p></body></html>`
+	jsonBytes, err := html.ExtractToJSON([]byte(doc))
+	if err != nil {
+		t.Fatalf("ExtractToJSON() failed: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(jsonBytes, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out["social_image_width"] != float64(1200) || out["social_image_height"] != float64(630) {
+		t.Errorf("unexpected JSON dimensions: %s", jsonBytes)
+	}
+	if !strings.Contains(string(jsonBytes), `"social_image":"https://example.com/card.png"`) {
+		t.Errorf("social_image missing from JSON: %s", jsonBytes)
+	}
+}
+
+func TestSitemapURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		head    string
+		baseURL string
+		want    string
+	}{
+		{
+			name: "absolute sitemap link",
+			head: `<link rel="sitemap" type="application/xml" href="https://example.com/sitemap.xml">`,
+			want: "https://example.com/sitemap.xml",
+		},
+		{
+			name:    "relative sitemap resolved against BaseURL",
+			head:    `<link rel="sitemap" href="/sitemap.xml">`,
+			baseURL: "https://example.com/blog/",
+			want:    "https://example.com/sitemap.xml",
+		},
+		{
+			name: "relative sitemap resolved against canonical",
+			head: `<link rel="canonical" href="https://site.example/post"><link rel="Sitemap" href="/sitemap_index.xml">`,
+			want: "https://site.example/sitemap_index.xml",
+		},
+		{
+			name: "no sitemap link",
+			head: `<link rel="stylesheet" href="/style.css">`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := html.DefaultConfig()
+			cfg.BaseURL = tt.baseURL
+			doc := `<html><head>` + tt.head + `</head><body><p>Body text.</p></body></html>`
+			result, err := html.Extract([]byte(doc), cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.SitemapURL != tt.want {
+				t.Errorf("SitemapURL = %q, want %q", result.SitemapURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetaRefresh(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		head    string
+		baseURL string
+		want    string
+	}{
+		{
+			name: "absolute target",
+			head: `<meta http-equiv="refresh" content="0;url=https://example.com/new-page">`,
+			want: "https://example.com/new-page",
+		},
+		{
+			name:    "quoted relative target resolved against BaseURL",
+			head:    `<meta http-equiv="Refresh" content="5; URL='/moved?id=1'">`,
+			baseURL: "https://example.com/old/",
+			want:    "https://example.com/moved?id=1",
+		},
+		{
+			name: "target without url prefix",
+			head: `<meta http-equiv="refresh" content="0, https://example.com/next">`,
+			want: "https://example.com/next",
+		},
+		{
+			name: "reload without target",
+			head: `<meta http-equiv="refresh" content="30">`,
+			want: "",
+		},
+		{
+			name: "javascript target ignored",
+			head: `<meta http-equiv="refresh" content="0;url=javascript:alert(1)">`,
+			want: "",
+		},
+		{
+			name: "no refresh tag",
+			head: `<meta name="refresh" content="0;url=https://example.com/">`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := html.DefaultConfig()
+			cfg.BaseURL = tt.baseURL
+			doc := `<html><head>` + tt.head + `</head><body><p>Redirecting.</p></body></html>`
+			result, err := html.Extract([]byte(doc), cfg)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.MetaRefresh != tt.want {
+				t.Errorf("MetaRefresh = %q, want %q", result.MetaRefresh, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeclaredCharset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		head string
+		want string
+	}{
+		{name: "meta charset", head: `<meta charset=" UTF-8 ">`, want: "utf-8"},
+		{name: "http-equiv content type", head: `<meta http-equiv="Content-Type" content="text/html; Charset='ISO-8859-1'">`, want: "iso-8859-1"},
+		{name: "unsupported charset kept", head: `<meta charset="x-unknown">`, want: "x-unknown"},
+		{name: "first declaration wins", head: `<meta charset="windows-1252"><meta charset="utf-8">`, want: "windows-1252"},
+		{name: "content type without charset", head: `<meta http-equiv="content-type" content="text/html">`, want: ""},
+		{name: "no declaration", head: `<meta name="charset" content="utf-8">`, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := `<html><head>` + tt.head + `</head><body><p>Text.</p></body></html>`
+			result, err := html.Extract([]byte(doc))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.DeclaredCharset != tt.want {
+				t.Errorf("DeclaredCharset = %q, want %q", result.DeclaredCharset, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		head string
+		want string
+	}{
+		{
+			name: "link canonical",
+			head: `<link rel="canonical" href="https://example.com/articles/42?ref=x">`,
+			want: "https://example.com/articles/42?ref=x",
+		},
+		{
+			name: "link canonical preferred over og:url",
+			head: `<meta property="og:url" content="https://example.com/og"><link rel="canonical" href="https://example.com/canonical">`,
+			want: "https://example.com/canonical",
+		},
+		{
+			name: "og:url fallback",
+			head: `<meta property="og:url" content="https://example.com/posts/hello">`,
+			want: "https://example.com/posts/hello",
+		},
+		{
+			name: "relative canonical resolved against base",
+			head: `<base href="https://example.com/blog/"><link rel="canonical" href="/posts/hello">`,
+			want: "https://example.com/posts/hello",
+		},
+		{
+			name: "absent",
+			head: `<title>No canonical</title>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := `<html><head>` + tt.head + `</head><body><p>Body</p></body></html>`
+			result, err := html.Extract([]byte(doc))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.CanonicalURL != tt.want {
+				t.Errorf("CanonicalURL = %q, want %q", result.CanonicalURL, tt.want)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ExtractMetadata = false
+		doc := `<html><head><link rel="canonical" href="https://example.com/c"><meta property="og:image" content="https://example.com/i.png"></head><body><p>Body</p></body></html>`
+		result, err := html.Extract([]byte(doc), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.CanonicalURL != "" || result.SocialImage != "" {
+			t.Errorf("metadata extracted with ExtractMetadata=false: %q, %q", result.CanonicalURL, result.SocialImage)
+		}
+	})
+}
+
+func TestPublishedModifiedDates(t *testing.T) {
+	t.Parallel()
+
+	date := func(s string) *time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &tm
+	}
+
+	tests := []struct {
+		name          string
+		doc           string
+		wantPublished *time.Time
+		wantModified  *time.Time
+	}{
+		{
+			name: "open graph meta",
+			doc: `<head><meta property="article:published_time" content="2024-03-01T10:30:00+02:00">
+				<meta property="og:updated_time" content="2024-03-02T08:00:00Z"></head>`,
+			wantPublished: date("2024-03-01T10:30:00+02:00"),
+			wantModified:  date("2024-03-02T08:00:00Z"),
+		},
+		{
+			name: "json-ld graph",
+			doc: `<head><script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+				{"@type":"WebSite"},{"@type":"NewsArticle","datePublished":"2023-12-24","dateModified":"2023-12-25T12:00:00Z"}]}</script></head>`,
+			wantPublished: date("2023-12-24T00:00:00Z"),
+			wantModified:  date("2023-12-25T12:00:00Z"),
+		},
+		{
+			name:          "time element with itemprop",
+			doc:           `<body><p>Posted <time itemprop="datePublished" datetime="2022-06-15 09:00:00">June 15</time></p></body>`,
+			wantPublished: date("2022-06-15T09:00:00Z"),
+		},
+		{
+			name:          "first time element fallback",
+			doc:           `<body><article><time datetime="Mon, 02 Jan 2006 15:04:05 GMT">then</time></article></body>`,
+			wantPublished: date("2006-01-02T15:04:05Z"),
+		},
+		{
+			name:          "meta wins over json-ld",
+			doc:           `<head><meta property="article:published_time" content="2021-01-01T00:00:00Z"><script type="application/ld+json">{"datePublished":"2020-01-01"}</script></head>`,
+			wantPublished: date("2021-01-01T00:00:00Z"),
+		},
+		{
+			name:          "unparseable skipped",
+			doc:           `<head><meta property="article:published_time" content="last Tuesday"><meta name="date" content="March 5, 2020"></head>`,
+			wantPublished: date("2020-03-05T00:00:00Z"),
+		},
+		{
+			name: "absent",
+			doc:  `<head><title>No dates</title></head>`,
+		},
+	}
+
+	equal := func(a, b *time.Time) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Equal(*b)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := html.Extract([]byte(`<html>` + tt.doc + `<body><p>Body</p></body></html>`))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if !equal(result.PublishedAt, tt.wantPublished) {
+				t.Errorf("PublishedAt = %v, want %v", result.PublishedAt, tt.wantPublished)
+			}
+			if !equal(result.ModifiedAt, tt.wantModified) {
+				t.Errorf("ModifiedAt = %v, want %v", result.ModifiedAt, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestAuthor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "json-ld person",
+			doc: `<head><meta name="author" content="Meta Name"><script type="application/ld+json">
+				{"@type":"NewsArticle","author":[{"@type":"Person","name":"Ada Lovelace"}]}</script></head><body><p>Body</p></body>`,
+			want: "Ada Lovelace",
+		},
+		{
+			name: "json-ld string author",
+			doc:  `<head><script type="application/ld+json">{"@graph":[{"@type":"Article","author":"Grace Hopper"}]}</script></head><body><p>Body</p></body>`,
+			want: "Grace Hopper",
+		},
+		{
+			name: "meta author",
+			doc:  `<head><meta name="author" content="Alan Turing"><meta property="article:author" content="Other"></head><body><p>Body</p></body>`,
+			want: "Alan Turing",
+		},
+		{
+			name: "article author skips profile url",
+			doc:  `<head><meta property="article:author" content="https://facebook.com/someone"></head><body><p>By <a rel="author" href="/u/k">Katherine Johnson</a></p></body>`,
+			want: "Katherine Johnson",
+		},
+		{
+			name: "byline class",
+			doc:  `<body><div class="post-byline">By  Linus   Torvalds</div><p>Body</p></body>`,
+			want: "Linus Torvalds",
+		},
+		{
+			name: "long author box skipped",
+			doc:  `<body><div class="author-bio">` + strings.Repeat("Biography text. ", 20) + `</div><span class="author">Barbara Liskov</span></body>`,
+			want: "Barbara Liskov",
+		},
+		{
+			name: "absent",
+			doc:  `<body><p>No attribution here.</p></body>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := html.Extract([]byte(`<html>` + tt.doc + `</html>`))
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.Author != tt.want {
+				t.Errorf("Author = %q, want %q", result.Author, tt.want)
+			}
+		})
+	}
+
+	t.Run("disabled with metadata", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ExtractMetadata = false
+		result, err := html.Extract([]byte(`<html><head><meta name="author" content="Alan Turing"></head><body><p>Body</p></body></html>`), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.Author != "" {
+			t.Errorf("Author = %q, want empty when ExtractMetadata is false", result.Author)
+		}
+	})
+}
+
+func TestFeeds(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head>
+		<base href="https://example.com/">
+		<link rel="alternate" type="application/rss+xml" title="Posts" href="/feed.xml">
+		<link rel="alternate" type="application/atom+xml" title="Comments" href="comments/atom">
+		<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+		<link rel="alternate" hreflang="de" href="/de/">
+		<link rel="stylesheet" type="application/rss+xml" href="/not-a-feed">
+		<link rel="alternate" type="application/atom+xml" href="https://feeds.example.org/all">
+	</head><body><p>Body</p></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractFeeds = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []html.FeedLink{
+		{URL: "https://example.com/feed.xml", Title: "Posts", Type: "rss"},
+		{URL: "https://example.com/comments/atom", Title: "Comments", Type: "atom"},
+		{URL: "https://feeds.example.org/all", Type: "atom"},
+	}
+	if len(result.Feeds) != len(want) {
+		t.Fatalf("Feeds = %+v, want %+v", result.Feeds, want)
+	}
+	for i := range want {
+		if result.Feeds[i] != want[i] {
+			t.Errorf("Feeds[%d] = %+v, want %+v", i, result.Feeds[i], want[i])
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"feeds":[{"url":"https://example.com/feed.xml"`) {
+		t.Errorf("JSON missing feeds: %s", data)
+	}
+
+	plain, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if plain.Feeds != nil {
+		t.Errorf("Feeds = %+v, want nil without ExtractFeeds", plain.Feeds)
+	}
+}
+
+func TestRobots(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		head string
+		want html.RobotsDirectives
+	}{
+		{"absent", ``, html.RobotsDirectives{}},
+		{"comma separated", `<meta name="robots" content="noindex, nofollow">`, html.RobotsDirectives{NoIndex: true, NoFollow: true}},
+		{"case insensitive", `<meta name="ROBOTS" content="NoArchive,NOINDEX">`, html.RobotsDirectives{NoIndex: true, NoArchive: true}},
+		{"none", `<meta name="robots" content="none">`, html.RobotsDirectives{NoIndex: true, NoFollow: true}},
+		{"combined tags", `<meta name="robots" content="noindex"><meta name="robots" content="noarchive">`, html.RobotsDirectives{NoIndex: true, NoArchive: true}},
+		{"x-robots-tag", `<meta http-equiv="X-Robots-Tag" content="googlebot: nofollow">`, html.RobotsDirectives{NoFollow: true}},
+		{"agent prefix with space", `<meta name="robots" content="googlebot: noindex">`, html.RobotsDirectives{NoIndex: true}},
+		{"agent prefix without space", `<meta name="robots" content="googlebot:noindex, bingbot:noarchive">`, html.RobotsDirectives{NoIndex: true, NoArchive: true}},
+		{"value directives", `<meta name="robots" content="max-snippet:20, unavailable_after: 25 Jun 2030">`, html.RobotsDirectives{}},
+		{"index follow", `<meta name="robots" content="index, follow">`, html.RobotsDirectives{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			doc := []byte(`<html><head>` + tt.head + `</head><body><p>Body</p></body></html>`)
+			result, err := html.Extract(doc)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if result.Robots != tt.want {
+				t.Errorf("Robots = %+v, want %+v", result.Robots, tt.want)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		cfg := html.DefaultConfig()
+		cfg.ExtractMetadata = false
+		result, err := html.Extract([]byte(`<html><head><meta name="robots" content="noindex"></head><body><p>Body</p></body></html>`), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.Robots != (html.RobotsDirectives{}) {
+			t.Errorf("Robots = %+v, want zero with ExtractMetadata disabled", result.Robots)
+		}
+	})
+}
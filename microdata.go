@@ -0,0 +1,98 @@
+package html
+
+// microdata.go extracts HTML microdata (itemscope/itemtype/itemprop) items.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// maxMicrodataItems bounds the number of top-level items collected per document.
+const maxMicrodataItems = 100
+
+// collectMicrodata returns the top-level microdata items of doc in document
+// order. It must run before sanitization, which may strip the structural
+// elements and attributes microdata is attached to. An itemscope element that
+// is itself an itemprop contributes its properties to the enclosing item under
+// dotted names; one without itemprop starts an independent item. Items with
+// neither a type nor properties are dropped.
+func collectMicrodata(doc *stdxhtml.Node) []MicrodataItem {
+	if doc == nil {
+		return nil
+	}
+	var items []MicrodataItem
+	var walk func(n *stdxhtml.Node, props map[string][]string, prefix string)
+	walk = func(n *stdxhtml.Node, props map[string][]string, prefix string) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != stdxhtml.ElementNode || c.Data == "script" || c.Data == "style" {
+				continue
+			}
+			names := strings.Fields(internal.GetAttr(c, "itemprop"))
+			scope := hasAttr(c, "itemscope")
+			switch {
+			case scope && props != nil && len(names) > 0:
+				for _, name := range names {
+					walk(c, props, prefix+name+".")
+				}
+				continue
+			case scope:
+				if len(items) < maxMicrodataItems {
+					item := MicrodataItem{
+						Type:       firstField(internal.GetAttr(c, "itemtype")),
+						Properties: make(map[string][]string),
+					}
+					walk(c, item.Properties, "")
+					if item.Type != "" || len(item.Properties) > 0 {
+						items = append(items, item)
+					}
+				}
+				continue
+			case props != nil && len(names) > 0:
+				value := microdataValue(c)
+				for _, name := range names {
+					props[prefix+name] = append(props[prefix+name], value)
+				}
+			}
+			walk(c, props, prefix)
+		}
+	}
+	walk(doc, nil, "")
+	return items
+}
+
+// microdataValue returns the value of an itemprop element as the microdata
+// specification defines it: an attribute for URL-bearing and machine-readable
+// elements, and the whitespace-collapsed text content otherwise.
+func microdataValue(n *stdxhtml.Node) string {
+	var attr string
+	switch n.Data {
+	case "meta":
+		attr = "content"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		attr = "src"
+	case "a", "area", "link":
+		attr = "href"
+	case "object":
+		attr = "data"
+	case "data", "meter":
+		attr = "value"
+	case "time":
+		if hasAttr(n, "datetime") {
+			attr = "datetime"
+		}
+	}
+	if attr != "" {
+		return strings.TrimSpace(internal.GetAttr(n, attr))
+	}
+	return strings.Join(strings.Fields(internal.GetTextContent(n)), " ")
+}
+
+// firstField returns the first whitespace-separated token of s.
+func firstField(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestMicrodata(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head><title>Pancakes</title></head><body>
+		<div itemscope itemtype="https://schema.org/Recipe">
+			<h1 itemprop="name">Fluffy  Pancakes</h1>
+			<img itemprop="image" src="/img/pancakes.jpg" alt="">
+			<meta itemprop="prepTime" content="PT10M">
+			<time itemprop="datePublished" datetime="2024-02-01">Feb 1</time>
+			<ul>
+				<li itemprop="recipeIngredient">2 eggs</li>
+				<li itemprop="recipeIngredient">1 cup flour</li>
+			</ul>
+			<div itemprop="author" itemscope itemtype="https://schema.org/Person">
+				<span itemprop="name">Jamie</span>
+			</div>
+			<div itemprop="aggregateRating" itemscope itemtype="https://schema.org/AggregateRating">
+				<data itemprop="ratingValue" value="4.8">4.8 stars</data>
+			</div>
+		</div>
+		<div itemscope itemtype="https://schema.org/Event https://example.com/Extra">
+			<a itemprop="url" href="https://example.com/e/1"><span itemprop="name">Launch</span></a>
+		</div>
+		<div itemscope></div>
+		<p>Text outside any item <span itemprop="name">ignored</span>.</p>
+	</body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []html.MicrodataItem{
+		{
+			Type: "https://schema.org/Recipe",
+			Properties: map[string][]string{
+				"name":                        {"Fluffy Pancakes"},
+				"image":                       {"/img/pancakes.jpg"},
+				"prepTime":                    {"PT10M"},
+				"datePublished":               {"2024-02-01"},
+				"recipeIngredient":            {"2 eggs", "1 cup flour"},
+				"author.name":                 {"Jamie"},
+				"aggregateRating.ratingValue": {"4.8"},
+			},
+		},
+		{
+			Type: "https://schema.org/Event",
+			Properties: map[string][]string{
+				"url":  {"https://example.com/e/1"},
+				"name": {"Launch"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result.Microdata, want) {
+		t.Errorf("Microdata =\n%+v\nwant\n%+v", result.Microdata, want)
+	}
+
+	t.Run("disabled with metadata", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ExtractMetadata = false
+		result, err := html.Extract(doc, cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.Microdata != nil {
+			t.Errorf("Microdata = %+v, want nil when ExtractMetadata is false", result.Microdata)
+		}
+	})
+}
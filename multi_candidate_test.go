@@ -0,0 +1,99 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestMergeTopCandidates(t *testing.T) {
+	t.Parallel()
+
+	post := func(name string) string {
+		return `<article class="post-content"><p>` + strings.Repeat(name+" writes a thoughtful reply, with details, examples, and reasons. ", 12) + `</p><p>` +
+			strings.Repeat(name+" adds a second paragraph, with more context. ", 8) + `</p></article>`
+	}
+	var widget strings.Builder
+	for i := 0; i < 80; i++ {
+		widget.WriteString(`<li><a href="/topic">Another popular topic title in the forum sidebar</a></li>`)
+	}
+	doc := `<html><body>
+		<div class="thread">` + post("Alice") + `</div>
+		<div class="widget"><ul>` + widget.String() + `</ul></div>
+		<div class="thread">` + post("Bob") + `</div>
+	</body></html>`
+
+	single, err := html.Extract([]byte(doc))
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(single.Text, "Alice") && strings.Contains(single.Text, "Bob") {
+		t.Fatalf("default extraction already kept both posts; test page does not exercise merging")
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.MergeTopCandidates = true
+	merged, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	alice := strings.Index(merged.Text, "Alice")
+	bob := strings.Index(merged.Text, "Bob")
+	if alice < 0 || bob < 0 || alice > bob {
+		t.Errorf("merged Text should contain both posts in document order, got %q", merged.Text)
+	}
+	if strings.Count(merged.Text, "Alice writes") != 12 {
+		t.Errorf("merged Text duplicated or dropped content: %d occurrences", strings.Count(merged.Text, "Alice writes"))
+	}
+
+	t.Run("threshold above all scores falls back to body", func(t *testing.T) {
+		cfg := html.DefaultConfig()
+		cfg.ArticleScoreThreshold = 1 << 30
+		result, err := html.Extract([]byte(doc), cfg)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if !strings.Contains(result.Text, "Alice") || !strings.Contains(result.Text, "Bob") {
+			t.Errorf("body fallback should keep both posts, got %q", result.Text)
+		}
+	})
+
+	bad := html.DefaultConfig()
+	bad.ArticleScoreThreshold = -1
+	if err := bad.Validate(); err == nil {
+		t.Error("Validate() should reject negative ArticleScoreThreshold")
+	}
+}
+
+func TestContentScorer(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<div class="article-body"><p>` + strings.Repeat("The long article text that the default scorer prefers, with commas, clauses, and detail. ", 10) + `</p></div>
+		<section data-role="summary"><p>Short curated summary.</p></section>
+	</body></html>`)
+
+	def, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(def.Text, "curated summary") {
+		t.Fatalf("default extraction already selected the summary; test page does not exercise ContentScorer")
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.ContentScorer = func(n html.ContentNode) int {
+		if n.Type() == "element" && n.Data() == "section" && n.AttrValue("data-role") == "summary" {
+			return 1000
+		}
+		return 0
+	}
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.Text != "Short curated summary." {
+		t.Errorf("Text = %q, want only the section chosen by ContentScorer", result.Text)
+	}
+}
@@ -0,0 +1,113 @@
+package html
+
+// noscript.go recovers lazy-loaded images whose real <img> sits in a <noscript> fallback.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxNoscriptImages bounds how many images one <noscript> element can contribute.
+const maxNoscriptImages = 16
+
+// liftNoscriptImages inserts a copy of every <img> found inside a <noscript>
+// element just before that element, so image extraction sees it after the
+// <noscript> itself is dropped. The parser treats <noscript> content as raw
+// text, so the content is parsed as a fragment first. A lazy-loading
+// placeholder <img> immediately before the <noscript>, one without a src or
+// with a data: src, is removed in favor of the fallback.
+func liftNoscriptImages(doc *stdxhtml.Node) {
+	var wrappers []*stdxhtml.Node
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type == stdxhtml.ElementNode && n.Data == "noscript" {
+			wrappers = append(wrappers, n)
+			return false
+		}
+		return true
+	})
+
+	for _, ns := range wrappers {
+		if ns.Parent == nil {
+			continue
+		}
+		images := noscriptImages(ns)
+		if len(images) == 0 {
+			continue
+		}
+		if prev := previousElement(ns); prev != nil && prev.Data == "img" && isLazyPlaceholder(prev) {
+			ns.Parent.RemoveChild(prev)
+		}
+		for _, img := range images {
+			ns.Parent.InsertBefore(&stdxhtml.Node{
+				Type:     stdxhtml.ElementNode,
+				DataAtom: atom.Img,
+				Data:     "img",
+				Attr:     append([]stdxhtml.Attribute(nil), img.Attr...),
+			}, ns)
+		}
+	}
+}
+
+// noscriptImages returns the <img> elements inside ns, parsing its raw text
+// content when the parser did not build child elements.
+func noscriptImages(ns *stdxhtml.Node) []*stdxhtml.Node {
+	roots := []*stdxhtml.Node{ns}
+	if ns.FirstChild != nil && ns.FirstChild.Type == stdxhtml.TextNode {
+		var sb strings.Builder
+		for c := ns.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == stdxhtml.TextNode {
+				sb.WriteString(c.Data)
+			}
+		}
+		raw := sb.String()
+		if !strings.Contains(strings.ToLower(raw), "<img") {
+			return nil
+		}
+		parent := &stdxhtml.Node{Type: stdxhtml.ElementNode, DataAtom: atom.Div, Data: "div"}
+		nodes, err := stdxhtml.ParseFragment(strings.NewReader(raw), parent)
+		if err != nil {
+			return nil
+		}
+		roots = nodes
+	}
+
+	var images []*stdxhtml.Node
+	for _, root := range roots {
+		internal.WalkNodes(root, func(n *stdxhtml.Node) bool {
+			if len(images) >= maxNoscriptImages {
+				return false
+			}
+			if n.Type == stdxhtml.ElementNode && n.Data == "img" {
+				images = append(images, n)
+			}
+			return true
+		})
+	}
+	return images
+}
+
+// previousElement returns the closest preceding sibling element of n,
+// skipping whitespace-only text, or nil.
+func previousElement(n *stdxhtml.Node) *stdxhtml.Node {
+	for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+		switch s.Type {
+		case stdxhtml.ElementNode:
+			return s
+		case stdxhtml.TextNode:
+			if strings.TrimSpace(s.Data) != "" {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// isLazyPlaceholder reports whether img has no real source: its src is
+// missing, empty or a data: URL.
+func isLazyPlaceholder(img *stdxhtml.Node) bool {
+	src := strings.TrimSpace(internal.GetAttr(img, "src"))
+	return src == "" || strings.HasPrefix(strings.ToLower(src), "data:")
+}
@@ -3,6 +3,7 @@ package html
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/cybergodev/html/internal"
 )
@@ -269,66 +270,153 @@ func ExtractToJSONFromFileWithContext(ctx context.Context, filePath string, cfg
 
 // jsonResult wraps Result for custom JSON marshaling with duration formatting.
 type jsonResult struct {
-	Text             string      `json:"text"`
-	Title            string      `json:"title"`
-	Images           []ImageInfo `json:"images,omitempty"`
-	Links            []LinkInfo  `json:"links,omitempty"`
-	Videos           []VideoInfo `json:"videos,omitempty"`
-	Audios           []AudioInfo `json:"audios,omitempty"`
-	ProcessingTimeMS int64       `json:"processing_time_ms"`
-	WordCount        int         `json:"word_count"`
-	ReadingTimeMS    int64       `json:"reading_time_ms"`
+	Text                 string              `json:"text"`
+	TextSpans            []TextSpan          `json:"text_spans,omitempty"`
+	LanguageSpans        []LanguageSpan      `json:"language_spans,omitempty"`
+	Title                string              `json:"title"`
+	Images               []ImageInfo         `json:"images,omitempty"`
+	Links                []LinkInfo          `json:"links,omitempty"`
+	Videos               []VideoInfo         `json:"videos,omitempty"`
+	Audios               []AudioInfo         `json:"audios,omitempty"`
+	ProcessingTimeMS     int64               `json:"processing_time_ms"`
+	WordCount            int                 `json:"word_count"`
+	ReadingTimeMS        int64               `json:"reading_time_ms"`
+	ArticleConfidence    float64             `json:"article_confidence"`
+	LinkDensity          float64             `json:"link_density"`
+	SocialImage          string              `json:"social_image,omitempty"`
+	SocialImageWidth     int                 `json:"social_image_width,omitempty"`
+	SocialImageHeight    int                 `json:"social_image_height,omitempty"`
+	MainImage            string              `json:"main_image,omitempty"`
+	CanonicalURL         string              `json:"canonical_url,omitempty"`
+	PublishedAt          *time.Time          `json:"published_at,omitempty"`
+	ModifiedAt           *time.Time          `json:"modified_at,omitempty"`
+	Author               string              `json:"author,omitempty"`
+	Robots               RobotsDirectives    `json:"robots"`
+	SitemapURL           string              `json:"sitemap_url,omitempty"`
+	MetaRefresh          string              `json:"meta_refresh,omitempty"`
+	DeclaredCharset      string              `json:"declared_charset,omitempty"`
+	BaseTarget           string              `json:"base_target,omitempty"`
+	Microdata            []MicrodataItem     `json:"microdata,omitempty"`
+	Breadcrumbs          []string            `json:"breadcrumbs,omitempty"`
+	Feeds                []FeedLink          `json:"feeds,omitempty"`
+	Comments             []string            `json:"comments,omitempty"`
+	CommentThread        string              `json:"comment_thread,omitempty"`
+	DataAttributes       map[string][]string `json:"data_attributes,omitempty"`
+	Footnotes            []Footnote          `json:"footnotes,omitempty"`
+	Sections             []Section           `json:"sections,omitempty"`
+	Abbreviations        map[string]string   `json:"abbreviations,omitempty"`
+	Lists                []ListInfo          `json:"lists,omitempty"`
+	Keywords             []KeywordCount      `json:"keywords,omitempty"`
+	SignificantWordCount int                 `json:"significant_word_count,omitempty"`
+	Excerpt              string              `json:"excerpt,omitempty"`
+	DebugInfo            *DebugInfo          `json:"debug_info,omitempty"`
 }
 
 // MarshalJSON implements custom JSON marshaling for Result.
-// It converts time.Duration fields to milliseconds for better JSON interoperability.
+// It converts time.Duration fields to milliseconds for better JSON interoperability,
+// omits empty slices and optional strings, and always emits keys in the order of the
+// Result fields. It has a value receiver, so Result values and []Result slices are
+// encoded the same way as *Result.
 // Note: Result does not implement UnmarshalJSON. Deserializing JSON output back into
 // Result will lose duration fields (ProcessingTime, ReadingTime) because the JSON keys
 // differ from the struct field names. This is intentional — the JSON format is designed
 // for external consumption, not round-tripping.
-func (r *Result) MarshalJSON() ([]byte, error) {
+func (r Result) MarshalJSON() ([]byte, error) {
 	jr := jsonResult{
-		Text:             r.Text,
-		Title:            r.Title,
-		Images:           r.Images,
-		Links:            r.Links,
-		Videos:           r.Videos,
-		Audios:           r.Audios,
-		ProcessingTimeMS: r.ProcessingTime.Milliseconds(),
-		WordCount:        r.WordCount,
-		ReadingTimeMS:    r.ReadingTime.Milliseconds(),
+		Text:                 r.Text,
+		TextSpans:            r.TextSpans,
+		LanguageSpans:        r.LanguageSpans,
+		Title:                r.Title,
+		Images:               r.Images,
+		Links:                r.Links,
+		Videos:               r.Videos,
+		Audios:               r.Audios,
+		ProcessingTimeMS:     r.ProcessingTime.Milliseconds(),
+		WordCount:            r.WordCount,
+		ReadingTimeMS:        r.ReadingTime.Milliseconds(),
+		ArticleConfidence:    r.ArticleConfidence,
+		LinkDensity:          r.LinkDensity,
+		SocialImage:          r.SocialImage,
+		SocialImageWidth:     r.SocialImageWidth,
+		SocialImageHeight:    r.SocialImageHeight,
+		MainImage:            r.MainImage,
+		CanonicalURL:         r.CanonicalURL,
+		PublishedAt:          r.PublishedAt,
+		ModifiedAt:           r.ModifiedAt,
+		Author:               r.Author,
+		Robots:               r.Robots,
+		SitemapURL:           r.SitemapURL,
+		MetaRefresh:          r.MetaRefresh,
+		DeclaredCharset:      r.DeclaredCharset,
+		BaseTarget:           r.BaseTarget,
+		Microdata:            r.Microdata,
+		Breadcrumbs:          r.Breadcrumbs,
+		Feeds:                r.Feeds,
+		Comments:             r.Comments,
+		CommentThread:        r.CommentThread,
+		DataAttributes:       r.DataAttributes,
+		Footnotes:            r.Footnotes,
+		Sections:             r.Sections,
+		Abbreviations:        r.Abbreviations,
+		Lists:                r.Lists,
+		Keywords:             r.Keywords,
+		SignificantWordCount: r.SignificantWordCount,
+		Excerpt:              r.Excerpt,
+		DebugInfo:            r.DebugInfo,
 	}
 	return json.Marshal(jr)
 }
 
-// buildFormatProcessor returns a transient processor that reuses p's scorer but
-// applies the given inline image/link formats. It snapshots p's config under the
-// config lock so the format overrides never mutate the shared config, uses a
-// disabled cache to avoid polluting p's cache with format-specific results, and
-// an independent disabled audit collector so a parent Close() cannot race with
-// an in-flight extraction on the temporary processor.
+// JSON returns the JSON encoding of r produced by MarshalJSON, with
+// processing_time_ms and reading_time_ms in place of the Duration fields.
+func (r *Result) JSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+	return r.MarshalJSON()
+}
+
+// buildFormatProcessor returns a transient processor that applies the given
+// inline image/link formats.
 func (p *Processor) buildFormatProcessor(imageFormat, linkFormat string) *Processor {
+	return p.transientProcessor(func(cfg *Config) {
+		cfg.InlineImageFormat = imageFormat
+		cfg.InlineLinkFormat = linkFormat
+	})
+}
+
+// transientProcessor returns a processor that reuses p's scorer but applies
+// the config overrides of modify. It snapshots p's config under the config
+// lock so the overrides never mutate the shared config, uses a disabled cache
+// to avoid polluting p's cache with call-specific results, and an independent
+// disabled audit collector so a parent Close() cannot race with an in-flight
+// extraction on the temporary processor.
+func (p *Processor) transientProcessor(modify func(*Config)) *Processor {
 	p.configMu.Lock()
 	cfg := *p.config // Value copy to avoid race conditions
 	p.configMu.Unlock()
 
-	cfg.InlineImageFormat = imageFormat
-	cfg.InlineLinkFormat = linkFormat
+	modify(&cfg)
 	// The transient processor uses a disabled cache (NewCache[[16]byte](0, 0) below), so
 	// zero the entry budget too: this short-circuits the cache-key generation
 	// and Get/Set calls in Extract, which would otherwise run as no-ops while
-	// still paying the cost of hashing the input on every format conversion.
+	// still paying the cost of hashing the input on every call.
 	cfg.MaxCacheEntries = 0
 
 	return &Processor{
-		config:       &cfg,
-		cache:        internal.NewCache[[16]byte](0, 0),
-		scorer:       p.scorer,
-		audit:        newAuditCollector(AuditConfig{Enabled: false}),
-		auditAdapter: &auditRecorderAdapter{collector: nil},
-		stats:        &processorStats{},
-		imageFormat:  normalizeInlineFormat(imageFormat),
-		linkFormat:   normalizeInlineFormat(linkFormat),
+		config:          &cfg,
+		cache:           internal.NewCache[[16]byte](0, 0),
+		scorer:          p.scorer,
+		audit:           newAuditCollector(AuditConfig{Enabled: false}),
+		auditAdapter:    &auditRecorderAdapter{collector: nil},
+		stats:           &processorStats{},
+		imageFormat:     normalizeInlineFormat(cfg.InlineImageFormat),
+		linkFormat:      normalizeInlineFormat(cfg.InlineLinkFormat),
+		lineBreak:       p.lineBreak,
+		contentFilter:   p.contentFilter,
+		dataAttributes:  p.dataAttributes,
+		mediaExtensions: p.mediaExtensions,
+		stopWords:       p.stopWords,
 	}
 }
 
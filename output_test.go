@@ -198,6 +198,40 @@ func TestResultSerialization(t *testing.T) {
 	}
 }
 
+// TestResultJSON checks Result.JSON and that Result values marshal like pointers.
+func TestResultJSON(t *testing.T) {
+	t.Parallel()
+
+	result := html.Result{
+		Title:          "Test Title",
+		Text:           "Body",
+		ReadingTime:    90 * time.Second,
+		ProcessingTime: 250 * time.Millisecond,
+	}
+	data, err := result.JSON()
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+	want := `{"text":"Body","title":"Test Title","processing_time_ms":250,"word_count":0,"reading_time_ms":90000,` +
+		`"article_confidence":0,"link_density":0,"robots":{"noindex":false,"nofollow":false,"noarchive":false}}`
+	if string(data) != want {
+		t.Errorf("JSON() = %s\nwant %s", data, want)
+	}
+
+	byValue, err := json.Marshal([]html.Result{result})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	if string(byValue) != "["+want+"]" {
+		t.Errorf("json.Marshal([]Result) = %s, want the MarshalJSON encoding", byValue)
+	}
+
+	var nilResult *html.Result
+	if data, err := nilResult.JSON(); err != nil || string(data) != "null" {
+		t.Errorf("nil JSON() = %s, %v; want null", data, err)
+	}
+}
+
 // TestMediaInfoJSONSerialization consolidates JSON serialization tests for all media info types.
 func TestMediaInfoJSONSerialization(t *testing.T) {
 	t.Parallel()
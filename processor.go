@@ -157,6 +157,19 @@ type Processor struct {
 	// Pre-computed format strings to avoid repeated strings.ToLower in hot path
 	imageFormat string
 	linkFormat  string
+	lineBreak   string
+	// Compiled Config.ContentFilter; nil when it is empty
+	contentFilter *contentFilter
+	// Attribute names of Config.CaptureDataAttributes; nil when it is empty
+	dataAttributes map[string]bool
+	// Compiled Config.AdditionalVideoExtensions and AdditionalAudioExtensions; nil when both are empty
+	mediaExtensions *mediaExtensions
+	// Lower-cased Config.StopWords; nil when it is nil, so the built-in lists apply
+	stopWords map[string]bool
+	// Content scope of ExtractSelector, replacing article detection, and of ExtractLinksFromSelector; nil otherwise
+	selector selector
+	// Resolves the URLs of the extracted content against BaseURL, which Extract leaves as written; set by ExtractFromURL only
+	resolveContentURLs bool
 	// Cached audit adapter to avoid per-call allocation
 	auditAdapter *auditRecorderAdapter
 }
@@ -220,6 +233,11 @@ func New(cfg ...Config) (*Processor, error) {
 	// Pre-compute normalized format strings to avoid repeated strings.ToLower in hot path
 	p.imageFormat = normalizeInlineFormat(c.InlineImageFormat)
 	p.linkFormat = normalizeInlineFormat(c.InlineLinkFormat)
+	p.lineBreak = strings.ToLower(strings.TrimSpace(c.LineBreakMode))
+	p.contentFilter = newContentFilter(c.ContentFilter)
+	p.dataAttributes = dataAttributeSet(c.CaptureDataAttributes)
+	p.mediaExtensions = newMediaExtensions(c.AdditionalVideoExtensions, c.AdditionalAudioExtensions)
+	p.stopWords = stopWordSet(c.StopWords)
 
 	// Cache audit adapter to avoid per-call allocation
 	p.auditAdapter = &auditRecorderAdapter{collector: p.audit}
@@ -228,6 +246,12 @@ func New(cfg ...Config) (*Processor, error) {
 	// Note: Scorer interface uses ContentNode abstraction; adapter converts to internal.Scorer
 	if c.Scorer != nil {
 		p.scorer = &scorerAdapter{external: c.Scorer}
+	} else if c.FoldCase || c.FoldAccents || c.MinContentTextLength > 0 {
+		sc := internal.DefaultScoringConfig()
+		sc.FoldCase = c.FoldCase
+		sc.FoldAccents = c.FoldAccents
+		sc.MinTextLength = c.MinContentTextLength
+		p.scorer = internal.NewDefaultScorerWithConfig(sc)
 	} else {
 		p.scorer = internal.SharedDefaultScorer()
 	}
@@ -296,6 +320,34 @@ func (p *Processor) ClearCache() {
 	p.cache.Clear()
 }
 
+// SetCacheTTL changes how long cached results are kept, for long-running
+// services adapting to memory pressure without recreating the processor. It
+// also applies to the results already cached, counting from when each was
+// stored, while keeping the other entries and the statistics. 0 or a negative
+// d means entries never expire; a positive d starts the background cleanup
+// when CacheCleanup is set and it is not already running. Safe to call
+// concurrently with extractions.
+func (p *Processor) SetCacheTTL(d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.cache.SetTTL(d)
+	if d > 0 && p.config.CacheCleanup > 0 && !p.closed.Load() {
+		p.cache.StartCleanup(p.config.CacheCleanup)
+	}
+}
+
+// SetMaxCacheEntries changes the maximum number of cached results, evicting
+// expired and then least recently used entries when the cache is over the new
+// limit. n is clamped to the range MaxCacheEntries accepts, and 0 disables the
+// cache. Safe to call concurrently with extractions.
+func (p *Processor) SetMaxCacheEntries(n int) {
+	if p == nil {
+		return
+	}
+	p.cache.SetMaxEntries(min(n, maxConfigCacheEntries))
+}
+
 // ResetStatistics resets all statistics counters to zero.
 // This preserves cache entries while clearing the accumulated metrics.
 func (p *Processor) ResetStatistics() {
@@ -310,7 +362,7 @@ func (p *Processor) ResetStatistics() {
 }
 
 // Close releases resources used by the processor.
-// After calling Close, the processor should not be used.
+// After calling Close, the processor should not be used until Reset is called.
 func (p *Processor) Close() error {
 	if p == nil {
 		return nil
@@ -327,6 +379,35 @@ func (p *Processor) Close() error {
 	return nil
 }
 
+// Reset returns the processor to its freshly created state so it can be
+// reused instead of reallocated: it clears the cache and the audit log, zeroes
+// the statistics counters and, if Close was called, reopens the processor and
+// restarts the background cache cleanup. A custom audit sink closed by Close
+// is not reopened. Reset is not safe to call concurrently with any other
+// method; callers must ensure no extraction is in flight.
+func (p *Processor) Reset() {
+	if p == nil {
+		return
+	}
+	// Capture whether Close() stopped the cleanup goroutine. Open processors
+	// keep theirs running, so cleanup is only restarted when it was actually
+	// stopped rather than needlessly stopped and respawned on every reset.
+	wasClosed := p.closed.Swap(false)
+	p.ResetStatistics()
+	// Sink writes are synchronous, so by the time the previous Extract
+	// returned, every audit entry was already handed to its sink. Wait() is a
+	// no-op safety hook marking the "audit work is done" point before the
+	// entries are cleared.
+	if p.audit != nil {
+		p.audit.Wait()
+	}
+	p.ClearAuditLog()
+	p.ClearCache()
+	if wasClosed && p.cache.TTL() > 0 && p.config.CacheCleanup > 0 {
+		p.cache.RestartCleanup(p.config.CacheCleanup)
+	}
+}
+
 // validateInput performs common validation for HTML input.
 // It checks for nil/closed processor and input size limits.
 // Returns an error if validation fails, nil otherwise.
@@ -66,25 +66,7 @@ func putPooledProcessor(p *Processor) {
 	if p == nil {
 		return
 	}
-	// Capture whether Close() stopped the cleanup goroutine during this use.
-	// Pooled processors are never closed on the normal path, so we only restart
-	// cleanup when it was actually stopped — otherwise every pooled call would
-	// needlessly stop and respawn the background cleanup goroutine.
-	wasClosed := p.closed.Swap(false)
-	p.ResetStatistics()
-	// Sink writes are synchronous, so by the time the previous user's Extract
-	// returned, every audit entry was already handed to its sink. Wait() is a
-	// no-op safety hook kept here to mark the "audit work for this use is done"
-	// point before clearing entries and returning the processor to the pool.
-	if p.audit != nil {
-		p.audit.Wait()
-	}
-	p.ClearAuditLog()
-	p.ClearCache()
-	// Restart background cleanup only if Close() stopped it during this use.
-	if wasClosed && p.config.CacheTTL > 0 && p.config.CacheCleanup > 0 {
-		p.cache.RestartCleanup(p.config.CacheCleanup)
-	}
+	p.Reset()
 	processorPool.Put(p)
 }
 
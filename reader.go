@@ -0,0 +1,116 @@
+package html
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// ExtractReader reads an HTML document from r and extracts its content like Extract.
+// At most MaxInputSize bytes are read; longer input is rejected with an error
+// matching ErrInputTooLarge.
+//
+// When AutoDecompress is enabled, gzip and zlib ("deflate" Content-Encoding)
+// streams are detected by their magic bytes and decompressed before encoding
+// detection. MaxInputSize is enforced on the decompressed size, so a small
+// compressed body cannot expand past the limit.
+func (p *Processor) ExtractReader(r io.Reader) (*Result, error) {
+	return recoverResult(func() (*Result, error) {
+		data, err := p.readInput(r)
+		if err != nil {
+			return nil, err
+		}
+		return p.Extract(data)
+	})
+}
+
+// ExtractReader reads an HTML document from r and extracts its content.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractReader for size limits and decompression.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractReader(r io.Reader, cfg ...Config) (*Result, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (*Result, error) {
+		return p.ExtractReader(r)
+	})
+}
+
+// readInput reads all of r, decompressing it first when AutoDecompress is set,
+// and fails once more than MaxInputSize bytes have been produced.
+func (p *Processor) readInput(r io.Reader) ([]byte, error) {
+	if p == nil || p.closed.Load() {
+		return nil, ErrProcessorClosed
+	}
+	if r == nil {
+		return nil, nil
+	}
+
+	if p.config.AutoDecompress {
+		dr, err := decompressReader(r)
+		if err != nil {
+			p.stats.errorCount.Add(1)
+			return nil, newInputError("ExtractReader", 0, p.config.MaxInputSize, err)
+		}
+		if c, ok := dr.(io.Closer); ok {
+			defer c.Close()
+		}
+		r = dr
+	}
+
+	limit := p.config.MaxInputSize
+	data, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		p.stats.errorCount.Add(1)
+		return nil, newInputError("ExtractReader", len(data), limit, err)
+	}
+	if len(data) > limit {
+		if p.audit != nil {
+			p.audit.RecordInputViolation(len(data), limit, "input_too_large")
+		}
+		p.stats.errorCount.Add(1)
+		return nil, newInputError("ExtractReader", len(data), limit, nil)
+	}
+	return data, nil
+}
+
+// decompressReader wraps r in a gzip or zlib reader when its first bytes carry
+// the matching magic header, and returns a reader over the unmodified stream
+// otherwise.
+func decompressReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(2)
+	if len(magic) < 2 {
+		return br, nil
+	}
+
+	switch {
+	case magic[0] == 0x1f && magic[1] == 0x8b:
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip stream: %w", err)
+		}
+		return zr, nil
+	case isZlibHeader(magic[0], magic[1]):
+		zr, err := zlib.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deflate stream: %w", err)
+		}
+		return zr, nil
+	}
+	return br, nil
+}
+
+// isZlibHeader reports whether cmf and flg form a zlib header (RFC 1950) with
+// deflate and a 32K window, which is what every common encoder emits. Checking
+// only this CMF value keeps plain text such as "HT" or "h1" from being taken
+// for a zlib stream; the only printable match, "x^", cannot start real HTML.
+func isZlibHeader(cmf, flg byte) bool {
+	return cmf == 0x78 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
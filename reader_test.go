@@ -0,0 +1,114 @@
+package html_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+const readerDoc = `<html><head><title>Compressed</title></head><body><p>Hello from a stream.</p></body></html>`
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zlibBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractReader(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.AutoDecompress = true
+
+	inputs := map[string][]byte{
+		"plain":   []byte(readerDoc),
+		"gzip":    gzipBytes(t, []byte(readerDoc)),
+		"deflate": zlibBytes(t, []byte(readerDoc)),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			result, err := html.ExtractReader(bytes.NewReader(input), cfg)
+			if err != nil {
+				t.Fatalf("ExtractReader() failed: %v", err)
+			}
+			if result.Title != "Compressed" || !strings.Contains(result.Text, "Hello from a stream.") {
+				t.Errorf("ExtractReader() = %q / %q", result.Title, result.Text)
+			}
+		})
+	}
+
+	t.Run("compressed without AutoDecompress", func(t *testing.T) {
+		// The gzip magic byte survives into Text when the stream is parsed as-is.
+		result, err := html.ExtractReader(bytes.NewReader(inputs["gzip"]))
+		if err != nil {
+			t.Fatalf("ExtractReader() failed: %v", err)
+		}
+		if !strings.ContainsRune(result.Text, 0x1f) {
+			t.Error("gzip input was decompressed with AutoDecompress disabled")
+		}
+	})
+}
+
+func TestExtractReaderSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.AutoDecompress = true
+	cfg.MaxInputSize = 1024
+
+	// Highly compressible input: small on the wire, far above the limit once expanded.
+	bomb := gzipBytes(t, []byte(`<p>`+strings.Repeat("a", 100*1024)+`</p>`))
+	if len(bomb) >= cfg.MaxInputSize {
+		t.Fatalf("compressed size %d should be below the limit", len(bomb))
+	}
+	_, err := html.ExtractReader(bytes.NewReader(bomb), cfg)
+	if !errors.Is(err, html.ErrInputTooLarge) {
+		t.Errorf("ExtractReader() error = %v, want ErrInputTooLarge", err)
+	}
+
+	_, err = html.ExtractReader(strings.NewReader(strings.Repeat("x", 2048)), cfg)
+	if !errors.Is(err, html.ErrInputTooLarge) {
+		t.Errorf("ExtractReader() plain error = %v, want ErrInputTooLarge", err)
+	}
+}
+
+func TestExtractReaderCorruptStream(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.AutoDecompress = true
+	corrupt := gzipBytes(t, []byte(readerDoc))
+	corrupt = corrupt[:len(corrupt)/2]
+	_, err := html.ExtractReader(bytes.NewReader(corrupt), cfg)
+	if err == nil {
+		t.Fatal("ExtractReader() should fail on a truncated gzip stream")
+	}
+	if errors.Is(err, html.ErrInputTooLarge) {
+		t.Errorf("truncated stream reported as too large: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package html
+
+// sanitize.go exposes the extraction path's HTML sanitizer as a standalone function.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+)
+
+// SanitizationPolicy adjusts the rules applied by Sanitize. The zero value, like
+// a nil policy, applies exactly the rules used when EnableSanitization is set.
+type SanitizationPolicy struct {
+	// RemoveTags lists additional elements to remove together with their content,
+	// for example "img" or "form".
+	RemoveTags []string
+	// KeepTags lists elements removed by default that should be kept, for example
+	// "svg" or "iframe". Their attributes and descendants are still sanitized:
+	// srcdoc is always stripped, and the SVG animation elements set, animate,
+	// animateMotion, animateTransform and animateColor are always removed, as
+	// are script, style and noscript.
+	KeepTags []string
+	// RemoveAttributes lists additional attributes to strip from every element,
+	// for example "class" or "style".
+	RemoveAttributes []string
+	// PreserveAttributeOrder keeps attributes in their source order, for output
+	// compared against the original. The parser otherwise sorts the attributes of
+	// formatting elements such as <a>, <b> and <em>. This is best effort: it costs
+	// a second tokenizing pass, and tag and attribute names are still lower-cased.
+	PreserveAttributeOrder bool
+}
+
+// Sanitize returns htmlContent with unsafe markup removed, for displaying
+// untrusted HTML without running a full extraction. It applies the same rules
+// as the extraction path: script, style and embedding elements such as iframe
+// and object are removed with their content, on* event handler attributes are
+// stripped, and href, src and other URL attributes using javascript:, vbscript:
+// or unsafe data: URLs are dropped. policy may be nil to use these rules as is.
+//
+// A full document is returned as the rendered contents of its <body>; a
+// fragment is returned without the html, head and body wrappers the parser adds.
+// The HTML parser lower-cases tag and attribute names, normalizes quoting and
+// whitespace, and sorts the attributes of formatting elements unless
+// PreserveAttributeOrder is set, so the output is not byte-for-byte comparable
+// with the input. Input that cannot be parsed yields "".
+func Sanitize(htmlContent string, policy *SanitizationPolicy) string {
+	return internal.SanitizeHTMLWithPolicy(htmlContent, internal.NoOpAuditRecorder{}, policy.toInternal())
+}
+
+// toInternal converts the policy to its internal form, lower-casing all names.
+func (sp *SanitizationPolicy) toInternal() *internal.Policy {
+	if sp == nil {
+		return nil
+	}
+	return &internal.Policy{
+		RemoveTags:  nameSet(sp.RemoveTags),
+		KeepTags:    nameSet(sp.KeepTags),
+		RemoveAttrs: nameSet(sp.RemoveAttributes),
+
+		PreserveAttributeOrder: sp.PreserveAttributeOrder,
+	}
+}
+
+// nameSet returns the trimmed, lower-cased names as a set, or nil when empty.
+func nameSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
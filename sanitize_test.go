@@ -0,0 +1,136 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	input := `<div class="post" onclick="steal()"><p onmouseover="x()">Hello <b>world</b></p>` +
+		`<script>alert(1)</script><style>body{}</style>` +
+		`<a href="javascript:alert(1)">bad</a><a href="https://example.com/">good</a>` +
+		`<img src="a.png" onerror="alert(1)"><iframe src="https://evil.example/"></iframe>` +
+		`<svg><circle r="1"></circle></svg></div>`
+
+	got := html.Sanitize(input, nil)
+	for _, banned := range []string{"onclick", "onmouseover", "onerror", "<script", "alert(1)", "<style", "javascript:", "<iframe", "<svg"} {
+		if strings.Contains(got, banned) {
+			t.Errorf("Sanitize() kept %q: %s", banned, got)
+		}
+	}
+	for _, kept := range []string{`<div class="post">`, "<p>Hello <b>world</b></p>", `<a href="https://example.com/">good</a>`, `<img src="a.png"/>`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("Sanitize() lost %q: %s", kept, got)
+		}
+	}
+	if strings.Contains(got, "<body>") || strings.Contains(got, "<html>") {
+		t.Errorf("Sanitize() added document wrappers: %s", got)
+	}
+
+	t.Run("policy", func(t *testing.T) {
+		t.Parallel()
+		got := html.Sanitize(input, &html.SanitizationPolicy{
+			RemoveTags:       []string{"IMG"},
+			KeepTags:         []string{"svg", "script"},
+			RemoveAttributes: []string{"class"},
+		})
+		if strings.Contains(got, "<img") || strings.Contains(got, "class=") {
+			t.Errorf("policy removals not applied: %s", got)
+		}
+		if !strings.Contains(got, `<svg><circle r="1"></circle></svg>`) {
+			t.Errorf("KeepTags did not keep svg: %s", got)
+		}
+		if strings.Contains(got, "<script") || strings.Contains(got, "onclick") {
+			t.Errorf("policy weakened the mandatory rules: %s", got)
+		}
+	})
+
+	t.Run("full document", func(t *testing.T) {
+		t.Parallel()
+		got := html.Sanitize(`<html><head><title>T</title></head><body><p>Body</p></body></html>`, nil)
+		if got != "<p>Body</p>" {
+			t.Errorf("Sanitize() = %q, want body contents", got)
+		}
+	})
+
+	if got := html.Sanitize("", nil); got != "" {
+		t.Errorf("Sanitize(\"\") = %q, want empty", got)
+	}
+}
+
+func TestSanitizePreserveAttributeOrder(t *testing.T) {
+	t.Parallel()
+
+	input := `<p id="p1" class="lead"><a title="t" HREF="/x" data-z="1" class="c" onclick="x()">link</a>` +
+		`<b title="x" class="y">one<i>two</b>three</i></p>`
+
+	got := html.Sanitize(input, nil)
+	if !strings.Contains(got, `<a class="c" data-z="1" href="/x" title="t">`) {
+		t.Errorf("Sanitize() = %q, want the parser's sorted attributes by default", got)
+	}
+
+	got = html.Sanitize(input, &html.SanitizationPolicy{PreserveAttributeOrder: true})
+	for _, want := range []string{`<p id="p1" class="lead">`, `<a title="t" href="/x" data-z="1" class="c">`, `<b title="x" class="y">`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Sanitize() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestSanitizeBypasses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		policy *html.SanitizationPolicy
+		banned []string
+		kept   string
+	}{
+		{
+			name:   "tab in scheme",
+			input:  `<a href="java&#x09;script:alert(1)">x</a>`,
+			banned: []string{"script:"},
+			kept:   "<a>x</a>",
+		},
+		{
+			name:   "newline and carriage return in scheme",
+			input:  `<a href=" &#x0A;jav&#x0D;ascript&#x0A;:alert(1)">x</a><img src="vb&#x0A;script:x">`,
+			banned: []string{"script", "ascript"},
+			kept:   "<a>x</a>",
+		},
+		{
+			name:   "kept iframe srcdoc",
+			input:  `<iframe src="https://example.com/" srcdoc="<script>alert(1)</script>"></iframe>`,
+			policy: &html.SanitizationPolicy{KeepTags: []string{"iframe"}},
+			banned: []string{"srcdoc", "alert"},
+			kept:   `<iframe src="https://example.com/">`,
+		},
+		{
+			name: "kept svg animation",
+			input: `<svg><a><animate attributeName="href" values="javascript:alert(1)"></animate>` +
+				`<set attributeName="href" to="javascript:alert(2)"></set><animateMotion></animateMotion><circle r="1"></circle></a></svg>`,
+			policy: &html.SanitizationPolicy{KeepTags: []string{"svg"}},
+			banned: []string{"javascript", "animate", "<set"},
+			kept:   `<circle r="1">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := html.Sanitize(tt.input, tt.policy)
+			for _, banned := range tt.banned {
+				if strings.Contains(strings.ToLower(got), banned) {
+					t.Errorf("Sanitize() kept %q: %s", banned, got)
+				}
+			}
+			if !strings.Contains(got, tt.kept) {
+				t.Errorf("Sanitize() = %q, want it to contain %q", got, tt.kept)
+			}
+		})
+	}
+}
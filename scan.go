@@ -0,0 +1,222 @@
+package html
+
+// scan.go implements ScanLinks, a tokenizer-based link scanner that extracts
+// resource links without building a DOM tree.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// errScanInputTooLarge signals from limitedScanReader that MaxInputSize was exceeded.
+var errScanInputTooLarge = errors.New("scan input too large")
+
+// ScanLinks extracts links from r using the HTML tokenizer instead of parsing a
+// full DOM tree, which keeps memory flat for multi-megabyte documents. Links are
+// classified, filtered and deduplicated with the same rules as ExtractAllLinks
+// and returned sorted by URL.
+//
+// Because no tree is built, ScanLinks skips logic that depends on the DOM:
+//   - MaxDepth is not enforced.
+//   - Relative URLs are resolved against BaseURL, or against a <base href> that
+//     appears before them; canonical and first-absolute-URL detection is skipped.
+//   - The input is read as UTF-8 unless Encoding is set; charset auto-detection
+//     is not performed. Use ConvertToUTF8 first for other encodings.
+//
+// Input longer than MaxInputSize is rejected with an error matching ErrInputTooLarge.
+// As with ExtractAllLinks, reaching MaxLinks stops the scan and returns the
+// partial set together with ErrMaxLinksExceeded.
+func (p *Processor) ScanLinks(r io.Reader) ([]LinkResource, error) {
+	return recoverLinks(func() ([]LinkResource, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		if r == nil {
+			return []LinkResource{}, nil
+		}
+
+		startTime := time.Now()
+
+		limited := &limitedScanReader{r: r, remaining: int64(p.config.MaxInputSize)}
+		var src io.Reader = limited
+		if p.config.Encoding != "" {
+			src = internal.NewDecodingReader(src, p.config.Encoding)
+		}
+
+		links, err := p.scanLinks(src)
+		// ErrMaxLinksExceeded carries a valid partial result.
+		if err != nil && !errors.Is(err, ErrMaxLinksExceeded) {
+			if errors.Is(err, errScanInputTooLarge) {
+				if p.audit != nil {
+					p.audit.RecordInputViolation(int(limited.read), p.config.MaxInputSize, "input_too_large")
+				}
+				err = newInputError("ScanLinks", int(limited.read), p.config.MaxInputSize, nil)
+			}
+			p.stats.errorCount.Add(1)
+			return nil, err
+		}
+
+		p.stats.totalProcessTime.Add(int64(time.Since(startTime)))
+		p.stats.totalProcessed.Add(1)
+		return links, err
+	})
+}
+
+// ScanLinks extracts links from r with the HTML tokenizer, without building a DOM tree.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ScanLinks for the trade-offs versus ExtractAllLinks.
+//
+// An optional Config can be provided to customize link extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ScanLinks(r io.Reader, cfg ...Config) ([]LinkResource, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) ([]LinkResource, error) {
+		return p.ScanLinks(r)
+	})
+}
+
+// scanLinks tokenizes r and feeds each start tag to the same per-element
+// extractors used by extractLinksFromDocument, wrapped in a detached node.
+// Anchors get their text collected into a single child so the title fallback
+// to link text still works, and so do <style> elements for IncludeCSSResources.
+func (p *Processor) scanLinks(r io.Reader) ([]LinkResource, error) {
+	baseURL := p.config.BaseURL
+	linkMap := make(map[string]LinkResource, linkMapCap)
+
+	truncated := false
+	extract := func(n *stdxhtml.Node) {
+		if p.extractLinksFromDocument(n, baseURL, baseURL, linkMap) {
+			truncated = true
+		}
+	}
+
+	var anchor, style *stdxhtml.Node
+	var anchorText strings.Builder
+	flushAnchor := func() {
+		if anchor == nil {
+			return
+		}
+		if anchorText.Len() > 0 {
+			anchor.AppendChild(&stdxhtml.Node{Type: stdxhtml.TextNode, Data: anchorText.String()})
+		}
+		extract(anchor)
+		anchor = nil
+		anchorText.Reset()
+	}
+
+	// offset counts the bytes tokenized so far, to report where a read error hit.
+	offset := 0
+	z := stdxhtml.NewTokenizer(r)
+	for {
+		if truncated {
+			return sortedLinks(linkMap), ErrMaxLinksExceeded
+		}
+		tt := z.Next()
+		offset += len(z.Raw())
+		switch tt {
+		case stdxhtml.ErrorToken:
+			flushAnchor()
+			if style != nil {
+				extract(style)
+			}
+			if err := z.Err(); err != io.EOF {
+				if errors.Is(err, errScanInputTooLarge) {
+					return nil, err
+				}
+				return nil, newExtractError("parse", offset, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+			}
+			if truncated {
+				return sortedLinks(linkMap), ErrMaxLinksExceeded
+			}
+			return sortedLinks(linkMap), nil
+
+		case stdxhtml.TextToken:
+			if anchor != nil {
+				anchorText.Write(z.Text())
+			}
+			if style != nil {
+				style.AppendChild(&stdxhtml.Node{Type: stdxhtml.TextNode, Data: string(z.Text())})
+			}
+
+		case stdxhtml.EndTagToken:
+			switch name, _ := z.TagName(); string(name) {
+			case "a":
+				flushAnchor()
+			case "style":
+				if style != nil {
+					extract(style)
+					style = nil
+				}
+			}
+
+		case stdxhtml.StartTagToken, stdxhtml.SelfClosingTagToken:
+			tok := z.Token()
+			n := &stdxhtml.Node{Type: stdxhtml.ElementNode, Data: tok.Data, DataAtom: tok.DataAtom, Attr: tok.Attr}
+			switch tok.Data {
+			case "a":
+				// An unclosed <a> is implicitly closed by the next one.
+				flushAnchor()
+				if tt == stdxhtml.SelfClosingTagToken {
+					extract(n)
+				} else {
+					anchor = n
+				}
+				continue
+			case "base":
+				if baseURL == "" && p.config.ResolveRelativeURLs {
+					if href := internal.GetAttr(n, "href"); href != "" {
+						baseURL = internal.NormalizeBaseURL(href)
+					}
+				}
+				continue
+			case "style":
+				// The stylesheet is raw text, delivered before the end tag.
+				if p.config.IncludeCSSResources && tt == stdxhtml.StartTagToken {
+					style = n
+					continue
+				}
+			}
+			extract(n)
+		}
+	}
+}
+
+// sortedLinks drains linkMap into a slice sorted by URL for deterministic output.
+func sortedLinks(linkMap map[string]LinkResource) []LinkResource {
+	links := make([]LinkResource, 0, len(linkMap))
+	for _, link := range linkMap {
+		links = append(links, link)
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].URL < links[j].URL
+	})
+	return links
+}
+
+// limitedScanReader fails with errScanInputTooLarge once more than remaining
+// bytes have been read, unlike io.LimitReader which silently truncates.
+type limitedScanReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (l *limitedScanReader) Read(b []byte) (int, error) {
+	n, err := l.r.Read(b)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return 0, errScanInputTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,174 @@
+package html_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+// TestScanLinks verifies that the tokenizer-based scanner matches ExtractAllLinks
+// on a document whose base URL is explicit.
+func TestScanLinks(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><head>
+		<base href="https://example.com/">
+		<link rel="stylesheet" href="/style.css">
+		<link rel="icon" href="/favicon.ico">
+		<script src="/app.js"></script>
+		<script>var s = "<a href='/not-a-link'>x</a>";</script>
+	</head><body>
+		<a href="/about">About &amp; Team</a>
+		<a href="/about">About again</a>
+		<a href="https://other.org/page" title="Other">ignored text</a>
+		<a href="/empty"></a>
+		<img src="/logo.png" alt="Logo">
+		<video src="/clip.mp4"><source src="/clip.webm" type="video/webm"></video>
+		<audio src="/song.mp3"></audio>
+		<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ"></iframe>
+	</body></html>`
+
+	scanned, err := html.ScanLinks(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ScanLinks() failed: %v", err)
+	}
+	extracted, err := html.ExtractAllLinks([]byte(doc))
+	if err != nil {
+		t.Fatalf("ExtractAllLinks() failed: %v", err)
+	}
+
+	if len(scanned) != len(extracted) {
+		t.Fatalf("ScanLinks() returned %d links, ExtractAllLinks() %d:\n%v\n%v", len(scanned), len(extracted), scanned, extracted)
+	}
+	for i := range scanned {
+		if scanned[i] != extracted[i] {
+			t.Errorf("link %d: ScanLinks() = %+v, ExtractAllLinks() = %+v", i, scanned[i], extracted[i])
+		}
+	}
+
+	for _, link := range scanned {
+		if strings.Contains(link.URL, "not-a-link") {
+			t.Errorf("ScanLinks() picked up a link from script text: %+v", link)
+		}
+	}
+}
+
+func TestScanLinksFilters(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.BaseURL = "https://example.com"
+	cfg.IncludeImages = false
+	cfg.IncludeExternalLinks = false
+
+	doc := `<a href="/in">In</a><a href="https://elsewhere.net/">Out</a><img src="/a.png">`
+	links, err := html.ScanLinks(strings.NewReader(doc), cfg)
+	if err != nil {
+		t.Fatalf("ScanLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].URL != "https://example.com/in" || links[0].Title != "In" {
+		t.Errorf("ScanLinks() = %+v, want only https://example.com/in", links)
+	}
+}
+
+func TestScanLinksInputTooLarge(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.MaxInputSize = 64
+	doc := `<a href="/a">A</a>` + strings.Repeat(" ", 100)
+	_, err := html.ScanLinks(strings.NewReader(doc), cfg)
+	if !errors.Is(err, html.ErrInputTooLarge) {
+		t.Errorf("ScanLinks() error = %v, want ErrInputTooLarge", err)
+	}
+}
+
+func TestScanLinksForcedEncoding(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.Encoding = "windows-1252"
+	// 0xE9 is "é" in Windows-1252.
+	doc := []byte("<a href=\"https://example.com/\">Caf\xe9</a>")
+	links, err := html.ScanLinks(strings.NewReader(string(doc)), cfg)
+	if err != nil {
+		t.Fatalf("ScanLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].Title != "Café" {
+		t.Errorf("ScanLinks() = %+v, want title Café", links)
+	}
+}
+
+// TestMaxLinks verifies that both link extractors stop at MaxLinks and return
+// the partial set with ErrMaxLinksExceeded.
+func TestMaxLinks(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+	for i := 0; i < 50; i++ {
+		sb.WriteString(`<a href="https://example.com/page` + strings.Repeat("x", i) + `">p</a>`)
+	}
+	farm := sb.String()
+
+	cfg := html.DefaultConfig()
+	cfg.MaxLinks = 10
+
+	extractors := map[string]func(string) ([]html.LinkResource, error){
+		"ExtractAllLinks": func(s string) ([]html.LinkResource, error) { return html.ExtractAllLinks([]byte(s), cfg) },
+		"ScanLinks":       func(s string) ([]html.LinkResource, error) { return html.ScanLinks(strings.NewReader(s), cfg) },
+	}
+	for name, extract := range extractors {
+		t.Run(name, func(t *testing.T) {
+			links, err := extract(farm)
+			if !errors.Is(err, html.ErrMaxLinksExceeded) {
+				t.Errorf("error = %v, want ErrMaxLinksExceeded", err)
+			}
+			if len(links) != 10 {
+				t.Errorf("got %d links, want 10", len(links))
+			}
+
+			// Exactly MaxLinks unique links, with duplicates, is not truncation.
+			exact := strings.Repeat(`<a href="https://example.com/same">same</a>`, 20)
+			for i := 0; i < 9; i++ {
+				exact += `<a href="https://example.com/u` + strings.Repeat("y", i) + `">u</a>`
+			}
+			links, err = extract(exact + `<a href="https://example.com/same">again</a>`)
+			if err != nil {
+				t.Errorf("error = %v, want nil at exactly MaxLinks", err)
+			}
+			if len(links) != 10 {
+				t.Errorf("got %d links, want 10", len(links))
+			}
+		})
+	}
+
+	// A single element adding several URLs must not exceed the cap either.
+	css := `<html><head><style>
+		.a { background: url(/a.png) } .b { background: url(/b.png) }
+		.c { background: url(/c.png) } .d { background: url(/d.png) }
+	</style></head><body><img srcset="/e.png 1x, /f.png 2x"></body></html>`
+	cssCfg := html.DefaultConfig()
+	cssCfg.BaseURL = "https://example.com/"
+	cssCfg.IncludeCSSResources = true
+	cssCfg.MaxLinks = 2
+	for name, extract := range map[string]func(string) ([]html.LinkResource, error){
+		"ExtractAllLinks": func(s string) ([]html.LinkResource, error) { return html.ExtractAllLinks([]byte(s), cssCfg) },
+		"ScanLinks":       func(s string) ([]html.LinkResource, error) { return html.ScanLinks(strings.NewReader(s), cssCfg) },
+	} {
+		links, err := extract(css)
+		if !errors.Is(err, html.ErrMaxLinksExceeded) {
+			t.Errorf("%s CSS error = %v, want ErrMaxLinksExceeded", name, err)
+		}
+		if len(links) != 2 || links[0].URL != "https://example.com/a.png" || links[1].URL != "https://example.com/b.png" {
+			t.Errorf("%s CSS links = %+v, want a.png and b.png", name, links)
+		}
+	}
+
+	bad := html.DefaultConfig()
+	bad.MaxLinks = -1
+	if err := bad.Validate(); err == nil {
+		t.Error("Validate() should reject negative MaxLinks")
+	}
+}
@@ -0,0 +1,91 @@
+package html
+
+// sections.go splits the cleaned content node into heading-delimited sections.
+
+import (
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// extractSections splits contentNode at every <h1>-<h6>, wherever it is nested.
+// Subtrees without headings are extracted whole, and consecutive ones share a
+// buffer so inline runs such as "a <b>b</b> c" keep their spacing. Content
+// before the first heading becomes a section with Level 0, and headings with
+// no following text still produce a section with empty Text.
+func (p *Processor) extractSections(contentNode *stdxhtml.Node) []Section {
+	if contentNode == nil {
+		return nil
+	}
+
+	var sections []Section
+	current := Section{}
+	buf := getTextBuffer()
+	defer putTextBuffer(buf)
+
+	flush := func() {
+		current.Text = p.cleanText(buf.String())
+		buf.Reset()
+		if current.Level > 0 || current.Text != "" {
+			sections = append(sections, current)
+		}
+	}
+
+	var visit func(n *stdxhtml.Node)
+	visit = func(n *stdxhtml.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if level := headingLevel(c); level > 0 {
+				flush()
+				current = Section{
+					Heading: strings.Join(strings.Fields(internal.GetTextContent(c)), " "),
+					Level:   level,
+				}
+				continue
+			}
+			if c.Type == stdxhtml.ElementNode && containsHeading(c) {
+				visit(c)
+				continue
+			}
+			p.writeStructuredText(c, buf, nil, nil)
+		}
+	}
+
+	if level := headingLevel(contentNode); level > 0 {
+		current = Section{
+			Heading: strings.Join(strings.Fields(internal.GetTextContent(contentNode)), " "),
+			Level:   level,
+		}
+	} else {
+		visit(contentNode)
+	}
+	flush()
+	return sections
+}
+
+// headingLevel returns 1-6 for <h1>-<h6> elements and 0 for anything else.
+func headingLevel(n *stdxhtml.Node) int {
+	if n.Type != stdxhtml.ElementNode || len(n.Data) != 2 || n.Data[0] != 'h' {
+		return 0
+	}
+	if level := int(n.Data[1] - '0'); level >= 1 && level <= 6 {
+		return level
+	}
+	return 0
+}
+
+// containsHeading reports whether any descendant of n is a heading element.
+func containsHeading(n *stdxhtml.Node) bool {
+	found := false
+	internal.WalkNodes(n, func(node *stdxhtml.Node) bool {
+		if found {
+			return false
+		}
+		if node != n && headingLevel(node) > 0 {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
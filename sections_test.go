@@ -0,0 +1,55 @@
+package html_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestSplitSections(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body><article>
+		<p>Intro with <b>bold</b> text.</p>
+		<h1>Title</h1>
+		<section>
+			<h2>First  part</h2>
+			<p>Alpha paragraph.</p>
+			<p>Beta paragraph.</p>
+		</section>
+		<section>
+			<h2>Second part</h2>
+			<div><p>Nested gamma.</p><h3>Deeper</h3><p>Delta.</p></div>
+		</section>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.SplitSections = true
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []html.Section{
+		{Heading: "", Level: 0, Text: "Intro with bold text."},
+		{Heading: "Title", Level: 1, Text: ""},
+		{Heading: "First part", Level: 2, Text: "Alpha paragraph.\n\nBeta paragraph."},
+		{Heading: "Second part", Level: 2, Text: "Nested gamma."},
+		{Heading: "Deeper", Level: 3, Text: "Delta."},
+	}
+	if !reflect.DeepEqual(result.Sections, want) {
+		t.Errorf("Sections =\n%#v\nwant\n%#v", result.Sections, want)
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result, err := html.Extract([]byte(doc))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if result.Sections != nil {
+			t.Errorf("Sections = %v, want nil", result.Sections)
+		}
+	})
+}
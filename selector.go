@@ -0,0 +1,257 @@
+package html
+
+// selector.go implements ExtractSelector, ExtractLinksFromSelector and the
+// minimal CSS selector subset they accept.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ExtractSelector extracts content like Extract, but from the elements of the
+// document matching selector instead of the automatically detected article,
+// for sites whose content is known to live in, say, "#main .post-body".
+// ExtractArticle is ignored. Matches nested in another match are skipped, and
+// several matches are extracted together in document order.
+//
+// The selector is a minimal CSS subset: type selectors such as "article", "*",
+// "#id" and ".class", combined into compounds like "div.post.featured" and
+// separated by whitespace as descendant combinators. Tag and class names are
+// matched case-insensitively and ids exactly. Attribute selectors,
+// pseudo-classes, the >, + and ~ combinators and comma-separated lists are not
+// supported.
+//
+// In addition to the errors returned by [Processor.Extract], this method
+// returns ErrInvalidSelector for a selector outside the subset and
+// ErrSelectorNotMatched when no element matches. Results are not cached.
+func (p *Processor) ExtractSelector(htmlBytes []byte, selector string) (*Result, error) {
+	return recoverResult(func() (*Result, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		sel, err := parseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		scoped := p.transientProcessor(func(*Config) {})
+		scoped.selector = sel
+		return scoped.Extract(htmlBytes)
+	})
+}
+
+// ExtractSelector extracts content from the elements of htmlBytes matching selector.
+// See Processor.ExtractSelector for the supported selector syntax.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractSelector(htmlBytes []byte, selector string, cfg ...Config) (*Result, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) (*Result, error) {
+		return p.ExtractSelector(htmlBytes, selector)
+	})
+}
+
+// ExtractLinksFromSelector extracts links like ExtractAllLinks, but only from
+// the elements of the document matching selector, such as "nav" when building
+// a sitemap or ".article-body" to leave out sidebar links. URLs are still
+// resolved against the base of the whole document. The selector syntax is the
+// one of ExtractSelector, and links in nested matches are only collected once.
+//
+// In addition to the errors returned by [Processor.ExtractAllLinks], this
+// method returns ErrInvalidSelector for a selector outside the subset and
+// ErrSelectorNotMatched when no element matches.
+func (p *Processor) ExtractLinksFromSelector(htmlBytes []byte, selector string) ([]LinkResource, error) {
+	return recoverLinks(func() ([]LinkResource, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		sel, err := parseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		scoped := p.transientProcessor(func(*Config) {})
+		scoped.selector = sel
+		return scoped.ExtractAllLinks(htmlBytes)
+	})
+}
+
+// ExtractLinksFromSelector extracts the links inside the elements of htmlBytes matching selector.
+// See Processor.ExtractSelector for the supported selector syntax.
+//
+// An optional Config can be provided to customize link extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractLinksFromSelector(htmlBytes []byte, selector string, cfg ...Config) ([]LinkResource, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) ([]LinkResource, error) {
+		return p.ExtractLinksFromSelector(htmlBytes, selector)
+	})
+}
+
+// compoundSelector matches a single element by tag, id and classes, each
+// optional, as in "div#main.post".
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+// selector is a chain of compound selectors joined by descendant combinators,
+// outermost first.
+type selector []compoundSelector
+
+// parseSelector parses a selector made of tag, #id and .class parts, combined
+// into compounds such as "article.post" and separated by whitespace as
+// descendant combinators. Tags and classes are matched case-insensitively.
+// Other CSS syntax, such as attribute selectors, pseudo-classes, child and
+// sibling combinators or selector lists, fails with ErrInvalidSelector.
+func parseSelector(s string) (selector, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty selector", ErrInvalidSelector)
+	}
+	sel := make(selector, 0, len(fields))
+	for _, field := range fields {
+		compound, err := parseCompoundSelector(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidSelector, s, err.Error())
+		}
+		sel = append(sel, compound)
+	}
+	return sel, nil
+}
+
+// parseCompoundSelector parses one whitespace-free part of a selector.
+func parseCompoundSelector(s string) (compoundSelector, error) {
+	var c compoundSelector
+	for i := 0; i < len(s); {
+		kind := byte(0)
+		if s[i] == '#' || s[i] == '.' {
+			kind = s[i]
+			i++
+		} else if i > 0 {
+			return c, fmt.Errorf("unexpected %q", s[i])
+		}
+		start := i
+		if kind == 0 && s[i] == '*' {
+			// The universal selector stands alone as the type selector.
+			i++
+		} else {
+			for i < len(s) && isSelectorNameByte(s[i]) {
+				i++
+			}
+		}
+		name := s[start:i]
+		if name == "" {
+			if i < len(s) {
+				return c, fmt.Errorf("unsupported %q", s[i])
+			}
+			return c, fmt.Errorf("missing name after %q", kind)
+		}
+		switch kind {
+		case '#':
+			if c.id != "" {
+				return c, fmt.Errorf("more than one id")
+			}
+			c.id = name
+		case '.':
+			c.classes = append(c.classes, strings.ToLower(name))
+		default:
+			if name != "*" {
+				c.tag = strings.ToLower(name)
+			}
+		}
+	}
+	return c, nil
+}
+
+// isSelectorNameByte reports whether b may appear in a tag, id or class name.
+// Non-ASCII bytes are accepted so that names in other scripts work.
+func isSelectorNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' ||
+		b == '-' || b == '_' || b >= 0x80
+}
+
+// matches reports whether element n satisfies c.
+func (c compoundSelector) matches(n *stdxhtml.Node) bool {
+	if n.Type != stdxhtml.ElementNode || (c.tag != "" && n.Data != c.tag) {
+		return false
+	}
+	if c.id != "" && internal.GetAttr(n, "id") != c.id {
+		return false
+	}
+	if len(c.classes) == 0 {
+		return true
+	}
+	classes := strings.Fields(strings.ToLower(internal.GetAttr(n, "class")))
+	for _, want := range c.classes {
+		found := false
+		for _, class := range classes {
+			if class == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether n satisfies the last compound of s and each earlier
+// compound is satisfied by an ancestor, in order.
+func (s selector) matches(n *stdxhtml.Node) bool {
+	if len(s) == 0 || !s[len(s)-1].matches(n) {
+		return false
+	}
+	i := len(s) - 2
+	for a := n.Parent; a != nil && i >= 0; a = a.Parent {
+		if s[i].matches(a) {
+			i--
+		}
+	}
+	return i < 0
+}
+
+// selectNodes returns the elements of doc matching s in document order,
+// leaving out matches nested inside an earlier match.
+func (s selector) selectNodes(doc *stdxhtml.Node) []*stdxhtml.Node {
+	var nodes []*stdxhtml.Node
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if s.matches(n) {
+			nodes = append(nodes, n)
+			return false
+		}
+		return true
+	})
+	return nodes
+}
+
+// scope returns the content node for s in doc: the single match, or a
+// detached <div> holding copies of all matches so that doc stays untouched for
+// the whole-document passes. It fails with ErrSelectorNotMatched when nothing
+// matches.
+func (s selector) scope(doc *stdxhtml.Node) (*stdxhtml.Node, error) {
+	nodes := s.selectNodes(doc)
+	switch len(nodes) {
+	case 0:
+		return nil, ErrSelectorNotMatched
+	case 1:
+		return nodes[0], nil
+	}
+	merged := &stdxhtml.Node{Type: stdxhtml.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, n := range nodes {
+		merged.AppendChild(internal.CloneNode(n))
+	}
+	return merged, nil
+}
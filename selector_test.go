@@ -0,0 +1,116 @@
+package html_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractSelector(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body>
+		<div id="main">
+			<div class="post">
+				<h2>First post</h2>
+				<div class="Post-Body">First body text.</div>
+				<div class="comments"><div class="post-body">Nested comment body.</div></div>
+			</div>
+			<div class="post featured"><div class="post-body">Second body text.</div></div>
+		</div>
+		<div class="post-body">Outside main.</div>
+		<article><p>A much longer article that automatic detection would normally pick up as the main content.</p></article>
+	</body></html>`)
+
+	tests := []struct {
+		name      string
+		selector  string
+		want      []string
+		unwanted  []string
+		wantError error
+	}{
+		{name: "id and descendant class", selector: "#main .post-body", want: []string{"First body text.", "Nested comment body.", "Second body text."}, unwanted: []string{"Outside main", "longer article"}},
+		{name: "compound", selector: "div.post.featured", want: []string{"Second body text."}, unwanted: []string{"First"}},
+		{name: "tag", selector: "h2", want: []string{"First post"}, unwanted: []string{"body text"}},
+		{name: "outermost match only", selector: ".post", want: []string{"First post", "Second body text."}},
+		{name: "no match", selector: "#missing", wantError: html.ErrSelectorNotMatched},
+		{name: "child combinator", selector: "#main > .post", wantError: html.ErrInvalidSelector},
+		{name: "attribute selector", selector: "div[id=main]", wantError: html.ErrInvalidSelector},
+		{name: "empty", selector: "  ", wantError: html.ErrInvalidSelector},
+		{name: "universal compound", selector: "#main *.featured", want: []string{"Second body text."}, unwanted: []string{"First"}},
+		{name: "star after tag", selector: "div*", wantError: html.ErrInvalidSelector},
+		{name: "star inside tag", selector: "a*b", wantError: html.ErrInvalidSelector},
+		{name: "star in class", selector: ".po*st", wantError: html.ErrInvalidSelector},
+		{name: "double star", selector: "**", wantError: html.ErrInvalidSelector},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := html.ExtractSelector(doc, tt.selector)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Fatalf("ExtractSelector(%q) error = %v, want %v", tt.selector, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtractSelector(%q) failed: %v", tt.selector, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result.Text, want) {
+					t.Errorf("Text = %q, want it to contain %q", result.Text, want)
+				}
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(result.Text, unwanted) {
+					t.Errorf("Text = %q, want no %q", result.Text, unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractLinksFromSelector(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><head><base href="https://example.com/"></head><body>
+		<nav><a href="/">Home</a><a href="/about">About</a></nav>
+		<div class="Article-Body">
+			<p>Read the <a href="/docs">docs</a>.</p>
+			<nav><a href="/about">About again</a></nav>
+		</div>
+		<aside><a href="https://ads.example.net/">Sponsored</a></aside>
+	</body></html>`)
+
+	urls := func(links []html.LinkResource) []string {
+		var out []string
+		for _, link := range links {
+			out = append(out, link.URL)
+		}
+		return out
+	}
+
+	links, err := html.ExtractLinksFromSelector(doc, "nav")
+	if err != nil {
+		t.Fatalf("ExtractLinksFromSelector(nav) failed: %v", err)
+	}
+	if got, want := strings.Join(urls(links), " "), "https://example.com/ https://example.com/about"; got != want {
+		t.Errorf("nav links = %q, want %q", got, want)
+	}
+
+	links, err = html.ExtractLinksFromSelector(doc, ".article-body")
+	if err != nil {
+		t.Fatalf("ExtractLinksFromSelector(.article-body) failed: %v", err)
+	}
+	if got, want := strings.Join(urls(links), " "), "https://example.com/about https://example.com/docs"; got != want {
+		t.Errorf("article links = %q, want %q", got, want)
+	}
+
+	if _, err := html.ExtractLinksFromSelector(doc, "footer"); !errors.Is(err, html.ErrSelectorNotMatched) {
+		t.Errorf("ExtractLinksFromSelector(footer) error = %v, want ErrSelectorNotMatched", err)
+	}
+	if _, err := html.ExtractLinksFromSelector(doc, "nav > a"); !errors.Is(err, html.ErrInvalidSelector) {
+		t.Errorf("ExtractLinksFromSelector(nav > a) error = %v, want ErrInvalidSelector", err)
+	}
+}
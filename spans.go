@@ -0,0 +1,138 @@
+package html
+
+// spans.go records where each segment of the extracted text came from, for
+// TrackPositions.
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// trackedText is like structuredText but also returns a TextSpan for every
+// text segment written, in output order, and with TrackSegmentLanguage the
+// language of each. Paths are only filled in with TrackPositions, and offsets
+// later by locateSpans, once the final text is known.
+func (p *Processor) trackedText(node *stdxhtml.Node, imageCounter, linkCounter *int) (string, []TextSpan, []string) {
+	buf := getTextBuffer()
+	defer putTextBuffer(buf)
+
+	var spans []TextSpan
+	var langs []string
+	paths := make(map[*stdxhtml.Node]string)
+	nodeLangs := make(map[*stdxhtml.Node]string)
+	opts := p.textOptions(p.config.TableFormat)
+	opts.MaxParagraphs = p.config.MaxParagraphs
+	opts.OnText = func(n *stdxhtml.Node, text string) {
+		if n.Type == stdxhtml.TextNode {
+			n = n.Parent
+		}
+		span := TextSpan{Text: strings.Join(strings.Fields(text), " "), Offset: -1}
+		if p.config.TrackPositions {
+			span.Path = nodePath(n, paths)
+		}
+		spans = append(spans, span)
+		if p.config.TrackSegmentLanguage {
+			langs = append(langs, nodeLang(n, nodeLangs))
+		}
+	}
+	internal.ExtractStructuredText(node, buf, imageCounter, linkCounter, opts)
+	return p.cleanText(buf.String()), spans, langs
+}
+
+// locateSpans sets the Offset of each span to the position of its text in
+// text, searching forward from the end of the previous span found, since
+// spans are in output order.
+func locateSpans(text string, spans []TextSpan) {
+	pos := 0
+	for i := range spans {
+		if idx := strings.Index(text[pos:], spans[i].Text); idx >= 0 {
+			spans[i].Offset = pos + idx
+			pos += idx + len(spans[i].Text)
+		}
+	}
+}
+
+// nodePath returns the XPath-like location of element n, such as
+// "/html/body/div[2]/p". A position is only added among siblings sharing the
+// tag. Paths are memoized in paths, as consecutive spans share ancestors.
+func nodePath(n *stdxhtml.Node, paths map[*stdxhtml.Node]string) string {
+	if n == nil || n.Type != stdxhtml.ElementNode {
+		return ""
+	}
+	if path, ok := paths[n]; ok {
+		return path
+	}
+
+	position, count := 1, 1
+	if n.Parent != nil {
+		count = 0
+		for c := n.Parent.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == stdxhtml.ElementNode && c.Data == n.Data {
+				count++
+				if c == n {
+					position = count
+				}
+			}
+		}
+	}
+	path := nodePath(n.Parent, paths) + "/" + n.Data
+	if count > 1 {
+		path += "[" + strconv.Itoa(position) + "]"
+	}
+	paths[n] = path
+	return path
+}
+
+// nodeLang returns the lang attribute, or else the xml:lang attribute, of n
+// or of its nearest ancestor declaring one, trimmed; "" when none does. An
+// empty lang="" marks the language as unknown. Results are memoized in cache.
+func nodeLang(n *stdxhtml.Node, cache map[*stdxhtml.Node]string) string {
+	if n == nil {
+		return ""
+	}
+	if lang, ok := cache[n]; ok {
+		return lang
+	}
+	lang, found := declaredLang(n)
+	if !found {
+		lang = nodeLang(n.Parent, cache)
+	}
+	cache[n] = lang
+	return lang
+}
+
+// declaredLang returns the trimmed lang attribute of n, or else its xml:lang
+// attribute, and whether n declares either.
+func declaredLang(n *stdxhtml.Node) (string, bool) {
+	if n.Type != stdxhtml.ElementNode {
+		return "", false
+	}
+	for _, key := range [...]string{"lang", "xml:lang"} {
+		for _, attr := range n.Attr {
+			if attr.Key == key {
+				return strings.TrimSpace(attr.Val), true
+			}
+		}
+	}
+	return "", false
+}
+
+// languageSpans groups the located spans into runs of consecutive segments
+// sharing a language, langs[i] being the language of spans[i]. A run's Text is
+// the slice of text from the start of its first segment to the end of its
+// last; a segment that was not located forms a run of its own.
+func languageSpans(text string, spans []TextSpan, langs []string) []LanguageSpan {
+	var runs []LanguageSpan
+	for i, span := range spans {
+		last := len(runs) - 1
+		if span.Offset >= 0 && last >= 0 && runs[last].Offset >= 0 && runs[last].Lang == langs[i] {
+			runs[last].Text = text[runs[last].Offset : span.Offset+len(span.Text)]
+			continue
+		}
+		runs = append(runs, LanguageSpan{Lang: langs[i], Text: span.Text, Offset: span.Offset})
+	}
+	return runs
+}
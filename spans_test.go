@@ -0,0 +1,108 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestTrackPositions(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body><article>
+		<h1>Release notes</h1>
+		<p>First   paragraph with <b>bold</b> words.</p>
+		<p>Second paragraph.</p>
+		<table><tr><th>Version</th><th>Date</th></tr><tr><td>1.2</td><td>May</td></tr></table>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.TrackPositions = true
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	want := []html.TextSpan{
+		{Text: "Release notes", Path: "/html/body/article/h1"},
+		{Text: "First paragraph with", Path: "/html/body/article/p[1]"},
+		{Text: "bold", Path: "/html/body/article/p[1]/b"},
+		{Text: "words.", Path: "/html/body/article/p[1]"},
+		{Text: "Second paragraph.", Path: "/html/body/article/p[2]"},
+		{Text: "Version Date 1.2 May", Path: "/html/body/article/table", Offset: -1},
+	}
+	if len(result.TextSpans) != len(want) {
+		t.Fatalf("TextSpans = %+v, want %d spans", result.TextSpans, len(want))
+	}
+	for i, w := range want {
+		got := result.TextSpans[i]
+		if got.Text != w.Text || got.Path != w.Path {
+			t.Errorf("span %d = %+v, want text %q at %q", i, got, w.Text, w.Path)
+			continue
+		}
+		if w.Offset == -1 {
+			if got.Offset != -1 {
+				t.Errorf("span %d Offset = %d, want -1", i, got.Offset)
+			}
+			continue
+		}
+		if got.Offset < 0 || !strings.HasPrefix(result.Text[got.Offset:], got.Text) {
+			t.Errorf("span %d Offset = %d does not point at %q in %q", i, got.Offset, got.Text, result.Text)
+		}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result, err := html.Extract([]byte(doc))
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		if len(result.TextSpans) != 0 {
+			t.Errorf("TextSpans = %+v, want none", result.TextSpans)
+		}
+	})
+}
+
+func TestTrackSegmentLanguage(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html lang="en"><body><article>
+		<p>The motto of the city is <q lang="la">Fluctuat nec mergitur</q>, often quoted.</p>
+		<p>It also appears in French.</p>
+		<blockquote lang="fr"><p>Il est battu par les flots.</p><p xml:lang="de">Er wird von den Wellen geschlagen.</p></blockquote>
+		<p lang="">Unknown language.</p>
+	</article></body></html>`
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.TrackSegmentLanguage = true
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if result.TextSpans != nil {
+		t.Errorf("TextSpans = %+v, want nil without TrackPositions", result.TextSpans)
+	}
+
+	want := []struct{ lang, text string }{
+		{"en", "The motto of the city is"},
+		{"la", "Fluctuat nec mergitur"},
+		{"en", ", often quoted.\n\nIt also appears in French."},
+		{"fr", "Il est battu par les flots."},
+		{"de", "Er wird von den Wellen geschlagen."},
+		{"", "Unknown language."},
+	}
+	if len(result.LanguageSpans) != len(want) {
+		t.Fatalf("LanguageSpans = %+v, want %d runs", result.LanguageSpans, len(want))
+	}
+	for i, w := range want {
+		got := result.LanguageSpans[i]
+		if got.Lang != w.lang || got.Text != w.text {
+			t.Errorf("LanguageSpans[%d] = {%q %q}, want {%q %q}", i, got.Lang, got.Text, w.lang, w.text)
+		}
+		if got.Offset < 0 || !strings.HasPrefix(result.Text[got.Offset:], got.Text) {
+			t.Errorf("LanguageSpans[%d].Offset = %d does not locate %q in %q", i, got.Offset, got.Text, result.Text)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package html
+
+import (
+	"context"
+	"sync"
+)
+
+// StreamResult is a single extraction result emitted by ExtractStream.
+type StreamResult struct {
+	// Index is the zero-based position of the input in the order it was received.
+	Index int
+	// Result is the extraction result, or nil when Err is set.
+	Result *Result
+	// Err is the extraction error for this input, if any.
+	Err error
+}
+
+// streamJob pairs an input document with its arrival index.
+type streamJob struct {
+	index   int
+	content []byte
+}
+
+// ExtractStream extracts content from documents received on htmlContents and
+// emits a StreamResult for each one as soon as it completes. Unlike ExtractBatch,
+// neither the inputs nor the results are held in memory together: at most
+// WorkerPoolSize documents are processed at once, and the output channel is
+// buffered to WorkerPoolSize so workers block when the consumer falls behind.
+//
+// Results arrive in completion order; use StreamResult.Index to restore input
+// order. The returned channel is closed once htmlContents is closed and every
+// received document has been emitted, or once ctx is cancelled. After
+// cancellation, inputs still on htmlContents are left unread and in-flight
+// results may be dropped.
+func (p *Processor) ExtractStream(ctx context.Context, htmlContents <-chan []byte) <-chan StreamResult {
+	return p.extractStream(ctx, htmlContents, nil)
+}
+
+// ExtractStream extracts content from documents received on htmlContents and
+// emits results as they complete. This is a convenience function that uses a
+// pooled Processor, which is held until the returned channel is closed.
+// See Processor.ExtractStream for ordering and cancellation semantics.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used. A config error is
+// reported as a single StreamResult with Index -1.
+func ExtractStream(ctx context.Context, htmlContents <-chan []byte, cfg ...Config) <-chan StreamResult {
+	c, pooled, err := resolveConfig(cfg...)
+	var p *Processor
+	if err == nil {
+		if pooled {
+			p = getPooledProcessor()
+		} else {
+			p, err = New(c)
+		}
+	}
+	if err != nil {
+		out := make(chan StreamResult, 1)
+		out <- StreamResult{Index: -1, Err: err}
+		close(out)
+		return out
+	}
+
+	return p.extractStream(ctx, htmlContents, func() {
+		if pooled {
+			putPooledProcessor(p)
+		} else {
+			_ = p.Close()
+		}
+	})
+}
+
+// extractStream runs a dispatcher that indexes inputs and WorkerPoolSize workers
+// that extract them. release, when non-nil, runs after the last worker exits and
+// before the output channel is closed.
+func (p *Processor) extractStream(ctx context.Context, htmlContents <-chan []byte, release func()) <-chan StreamResult {
+	workers := DefaultWorkerPoolSize
+	if p != nil && p.config.WorkerPoolSize > 0 {
+		workers = p.config.WorkerPoolSize
+	}
+
+	out := make(chan StreamResult, workers)
+	jobs := make(chan streamJob)
+
+	go func() {
+		defer close(jobs)
+		for index := 0; ; index++ {
+			select {
+			case <-ctx.Done():
+				return
+			case content, ok := <-htmlContents:
+				if !ok {
+					return
+				}
+				select {
+				case jobs <- streamJob{index: index, content: content}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				// ExtractWithContext recovers internal panics and reports them as ErrInternalPanic.
+				result, err := p.ExtractWithContext(ctx, job.content)
+				select {
+				case out <- StreamResult{Index: job.index, Result: result, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		if release != nil {
+			release()
+		}
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,101 @@
+package html_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractStream(t *testing.T) {
+	t.Parallel()
+
+	const count = 50
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for i := 0; i < count; i++ {
+			in <- []byte(fmt.Sprintf(`<html><head><title>Doc %d</title></head><body><p>Body %d</p></body></html>`, i, i))
+		}
+	}()
+
+	cfg := html.DefaultConfig()
+	cfg.WorkerPoolSize = 3
+	seen := make(map[int]bool, count)
+	for r := range html.ExtractStream(context.Background(), in, cfg) {
+		if r.Err != nil {
+			t.Fatalf("item %d failed: %v", r.Index, r.Err)
+		}
+		if want := fmt.Sprintf("Doc %d", r.Index); r.Result.Title != want {
+			t.Errorf("item %d: Title = %q, want %q", r.Index, r.Result.Title, want)
+		}
+		if seen[r.Index] {
+			t.Errorf("item %d emitted twice", r.Index)
+		}
+		seen[r.Index] = true
+	}
+	if len(seen) != count {
+		t.Errorf("received %d results, want %d", len(seen), count)
+	}
+}
+
+func TestExtractStreamErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := html.DefaultConfig()
+	cfg.MaxInputSize = 100
+	in := make(chan []byte, 2)
+	in <- []byte(`<p>ok</p>`)
+	in <- []byte(strings.Repeat("x", 200))
+	close(in)
+
+	var failed int
+	for r := range html.ExtractStream(context.Background(), in, cfg) {
+		if r.Err != nil {
+			if r.Index != 1 || !errors.Is(r.Err, html.ErrInputTooLarge) {
+				t.Errorf("item %d: unexpected error %v", r.Index, r.Err)
+			}
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("failed = %d, want 1", failed)
+	}
+
+	bad := html.DefaultConfig()
+	bad.WorkerPoolSize = 0
+	results := html.ExtractStream(context.Background(), in, bad)
+	r, ok := <-results
+	if !ok || r.Index != -1 || !errors.Is(r.Err, html.ErrInvalidConfig) {
+		t.Errorf("invalid config: got %+v, want Index -1 with ErrInvalidConfig", r)
+	}
+	if _, ok := <-results; ok {
+		t.Error("channel not closed after config error")
+	}
+}
+
+func TestExtractStreamCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan []byte) // never closed
+	results := html.ExtractStream(ctx, in)
+	in <- []byte(`<p>one</p>`)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ExtractStream did not close its channel after cancellation")
+	}
+}
@@ -0,0 +1,210 @@
+package html
+
+// svg.go turns inline <svg> graphics into images for IncludeInlineSVG.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// svgDataURLPrefix starts the data URL of an inline SVG image, whose MimeType
+// in Result.Images is therefore "image/svg+xml".
+const svgDataURLPrefix = "data:image/svg+xml;base64,"
+
+// svgPlaceholder is an <img> standing in for an inline <svg>, and the data
+// URL it receives once sanitization has run.
+type svgPlaceholder struct {
+	img *stdxhtml.Node
+	src string
+}
+
+// replaceInlineSVGs replaces every outermost <svg> element of doc, which
+// sanitization would remove, with an <img> placeholder carrying its size and
+// its <title>, <desc> or aria-label as alt text. The graphic is reduced to an
+// allowlist of elements and attributes by scrubSVG before it is serialized,
+// and the data URL is returned for setSVGSources to attach after
+// sanitization, which rejects SVG data URLs from the document itself.
+func replaceInlineSVGs(doc *stdxhtml.Node) []svgPlaceholder {
+	var svgs []*stdxhtml.Node
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type == stdxhtml.ElementNode && n.Data == "svg" {
+			svgs = append(svgs, n)
+			return false
+		}
+		return true
+	})
+
+	placeholders := make([]svgPlaceholder, 0, len(svgs))
+	for _, svg := range svgs {
+		if svg.Parent == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := stdxhtml.Render(&buf, scrubSVG(internal.CloneNode(svg))); err != nil {
+			continue
+		}
+		img := &stdxhtml.Node{Type: stdxhtml.ElementNode, DataAtom: atom.Img, Data: "img"}
+		if alt := svgAltText(svg); alt != "" {
+			img.Attr = append(img.Attr, stdxhtml.Attribute{Key: "alt", Val: alt})
+		}
+		for _, key := range [...]string{"width", "height"} {
+			if val := strings.TrimSpace(internal.GetAttr(svg, key)); val != "" {
+				img.Attr = append(img.Attr, stdxhtml.Attribute{Key: key, Val: val})
+			}
+		}
+		svg.Parent.InsertBefore(img, svg)
+		svg.Parent.RemoveChild(svg)
+		placeholders = append(placeholders, svgPlaceholder{
+			img: img,
+			src: svgDataURLPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		})
+	}
+	return placeholders
+}
+
+// setSVGSources sets the src of each placeholder to its data URL.
+func setSVGSources(placeholders []svgPlaceholder) {
+	for _, ph := range placeholders {
+		ph.img.Attr = append(ph.img.Attr, stdxhtml.Attribute{Key: "src", Val: ph.src})
+	}
+}
+
+// svgElements are the SVG elements kept by scrubSVG, lower-cased: shapes,
+// text, structure, paint servers and filter primitives. Everything else,
+// including <script>, <style>, <foreignObject>, <image> and the animation
+// elements <set> and <animate*>, is removed with its content.
+var svgElements = map[string]bool{
+	"svg": true, "g": true, "defs": true, "symbol": true, "use": true, "title": true, "desc": true,
+	"path": true, "rect": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true,
+	"text": true, "tspan": true, "textpath": true,
+	"lineargradient": true, "radialgradient": true, "stop": true, "pattern": true,
+	"clippath": true, "mask": true, "marker": true,
+	"filter": true, "feblend": true, "fecolormatrix": true, "fecomposite": true, "fedropshadow": true,
+	"feflood": true, "fegaussianblur": true, "femerge": true, "femergenode": true, "femorphology": true,
+	"feoffset": true,
+}
+
+// svgUnwrappedElements are replaced by their children rather than removed, so
+// that the shapes inside a link or a <switch> are still drawn.
+var svgUnwrappedElements = map[string]bool{"a": true, "switch": true}
+
+// svgAttributes are the attributes kept by scrubSVG, lower-cased: geometry,
+// presentation and filter parameters. Event handlers, style attributes and
+// animation targets such as attributeName are not among them. href and
+// xlink:href are kept separately, when they reference a fragment.
+var svgAttributes = map[string]bool{
+	"id": true, "class": true, "width": true, "height": true, "viewbox": true, "preserveaspectratio": true,
+	"x": true, "y": true, "x1": true, "y1": true, "x2": true, "y2": true, "cx": true, "cy": true,
+	"r": true, "rx": true, "ry": true, "d": true, "points": true, "pathlength": true, "transform": true,
+	"fill": true, "fill-opacity": true, "fill-rule": true, "stroke": true, "stroke-width": true,
+	"stroke-linecap": true, "stroke-linejoin": true, "stroke-miterlimit": true, "stroke-dasharray": true,
+	"stroke-dashoffset": true, "stroke-opacity": true, "opacity": true, "color": true, "visibility": true,
+	"display": true, "vector-effect": true, "shape-rendering": true, "paint-order": true,
+	"offset": true, "stop-color": true, "stop-opacity": true, "gradientunits": true, "gradienttransform": true,
+	"spreadmethod": true, "fx": true, "fy": true, "fr": true, "patternunits": true,
+	"patterncontentunits": true, "patterntransform": true, "clip-path": true, "clip-rule": true,
+	"clippathunits": true, "mask": true, "maskunits": true, "maskcontentunits": true,
+	"marker-start": true, "marker-mid": true, "marker-end": true, "markerwidth": true, "markerheight": true,
+	"markerunits": true, "refx": true, "refy": true, "orient": true,
+	"filter": true, "filterunits": true, "primitiveunits": true, "in": true, "in2": true, "result": true,
+	"stddeviation": true, "dx": true, "dy": true, "mode": true, "operator": true, "k1": true, "k2": true,
+	"k3": true, "k4": true, "values": true, "type": true, "radius": true, "flood-color": true, "flood-opacity": true,
+	"font-family": true, "font-size": true, "font-weight": true, "font-style": true, "text-anchor": true,
+	"dominant-baseline": true, "letter-spacing": true, "textlength": true, "lengthadjust": true,
+	"startoffset": true, "version": true, "role": true, "aria-label": true, "aria-hidden": true, "focusable": true,
+}
+
+// scrubSVG reduces the detached svg to the elements of svgElements and the
+// attributes of svgAttributes. href and xlink:href are only kept as fragment
+// references, and attribute values with a url() that is not a fragment
+// reference are dropped, so the graphic loads nothing from outside. The SVG
+// and XLink namespaces a standalone document needs are declared.
+func scrubSVG(svg *stdxhtml.Node) *stdxhtml.Node {
+	usesXLink := scrubSVGElement(svg)
+	svg.Attr = append(svg.Attr, stdxhtml.Attribute{Key: "xmlns", Val: "http://www.w3.org/2000/svg"})
+	if usesXLink {
+		svg.Attr = append(svg.Attr, stdxhtml.Attribute{Key: "xmlns:xlink", Val: "http://www.w3.org/1999/xlink"})
+	}
+	return svg
+}
+
+// scrubSVGElement scrubs the attributes of element n and its descendants as
+// described for scrubSVG, removing or unwrapping the children outside
+// svgElements, and reports whether an xlink:href was kept.
+func scrubSVGElement(n *stdxhtml.Node) (usesXLink bool) {
+	attrs := n.Attr[:0]
+	for _, attr := range n.Attr {
+		key := strings.ToLower(attr.Key)
+		switch {
+		case key == "href" && (attr.Namespace == "" || attr.Namespace == "xlink"):
+			if !strings.HasPrefix(strings.TrimSpace(attr.Val), "#") {
+				continue
+			}
+			usesXLink = usesXLink || attr.Namespace == "xlink"
+		case attr.Namespace != "" || !svgAttributes[key] || !svgFragmentURLsOnly(attr.Val):
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	n.Attr = attrs
+
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch {
+		case c.Type == stdxhtml.TextNode:
+		case c.Type != stdxhtml.ElementNode:
+			n.RemoveChild(c)
+		case svgUnwrappedElements[strings.ToLower(c.Data)]:
+			// Scrub the children in place, after which they replace c and are
+			// not visited again.
+			usesXLink = scrubSVGElement(c) || usesXLink
+			for gc := c.FirstChild; gc != nil; gc = c.FirstChild {
+				c.RemoveChild(gc)
+				n.InsertBefore(gc, c)
+			}
+			n.RemoveChild(c)
+		case svgElements[strings.ToLower(c.Data)]:
+			usesXLink = scrubSVGElement(c) || usesXLink
+		default:
+			n.RemoveChild(c)
+		}
+		c = next
+	}
+	return usesXLink
+}
+
+// svgFragmentURLsOnly reports whether every url() in the attribute value val
+// references a fragment of the document, as in "url(#gradient)".
+func svgFragmentURLsOnly(val string) bool {
+	rest := strings.ToLower(val)
+	for {
+		i := strings.Index(rest, "url(")
+		if i < 0 {
+			return true
+		}
+		rest = rest[i+len("url("):]
+		if !strings.HasPrefix(strings.TrimLeft(rest, " \t\n\r\f'\""), "#") {
+			return false
+		}
+	}
+}
+
+// svgAltText returns the text of the first <title> child of svg, else of its
+// first <desc> child, else its aria-label attribute.
+func svgAltText(svg *stdxhtml.Node) string {
+	for _, tag := range [...]string{"title", "desc"} {
+		for c := svg.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == stdxhtml.ElementNode && c.Data == tag {
+				if text := strings.Join(strings.Fields(internal.GetTextContent(c)), " "); text != "" {
+					return text
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(internal.GetAttr(svg, "aria-label"))
+}
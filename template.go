@@ -0,0 +1,32 @@
+package html
+
+// template.go exposes <template> content to extraction for client-rendered pages.
+
+import (
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// unwrapTemplates replaces every <template> element in doc with its content,
+// so that the content is scored and extracted like the rest of the page.
+// Nested templates are unwrapped too.
+func unwrapTemplates(doc *stdxhtml.Node) {
+	var templates []*stdxhtml.Node
+	internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+		if n.Type == stdxhtml.ElementNode && n.Data == "template" {
+			templates = append(templates, n)
+		}
+		return true
+	})
+	for _, t := range templates {
+		parent := t.Parent
+		if parent == nil {
+			continue
+		}
+		for c := t.FirstChild; c != nil; c = t.FirstChild {
+			t.RemoveChild(c)
+			parent.InsertBefore(c, t)
+		}
+		parent.RemoveChild(t)
+	}
+}
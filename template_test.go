@@ -0,0 +1,86 @@
+package html_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestIncludeTemplates(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><div id="app"></div>
+		<template id="page"><article><h1>Rendered Title</h1>
+		<p>This paragraph only exists inside a template until client code renders it, with <a href="https://example.com/more">a link</a>.</p>
+		<template><p>Nested template paragraph.</p></template>
+		<img src="https://example.com/hero.jpg" alt="Hero"></article></template>
+	</body></html>`)
+
+	def, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(def.Text, "only exists inside a template") || len(def.Images) != 0 {
+		t.Errorf("template content extracted by default: Text=%q Images=%+v", def.Text, def.Images)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeTemplates = true
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	for _, want := range []string{"Rendered Title", "only exists inside a template", "Nested template paragraph."} {
+		if !strings.Contains(result.Text, want) {
+			t.Errorf("Text = %q, missing %q", result.Text, want)
+		}
+	}
+	if len(result.Images) != 1 || result.Images[0].URL != "https://example.com/hero.jpg" {
+		t.Errorf("Images = %+v, want the template image", result.Images)
+	}
+	if len(result.Links) != 1 || result.Links[0].URL != "https://example.com/more" {
+		t.Errorf("Links = %+v, want the template link", result.Links)
+	}
+}
+
+// TestTemplatesSkippedByDefault pins the default since <template> joined the
+// non-content tags: template text next to visible content is no longer part of
+// Text, ExtractText or the scored article.
+func TestTemplatesSkippedByDefault(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<p>Visible article paragraph that readers see, with enough words to be the content.</p>
+		<template><p>Row template text for client rendering.</p></template>
+		<p>Another visible paragraph follows the template in the article body.</p>
+	</article></body></html>`)
+
+	result, err := html.Extract(doc)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if strings.Contains(result.Text, "Row template text") {
+		t.Errorf("Text = %q, want template text dropped by default", result.Text)
+	}
+	if !strings.Contains(result.Text, "Visible article paragraph") || !strings.Contains(result.Text, "Another visible paragraph") {
+		t.Errorf("Text = %q, want the visible paragraphs", result.Text)
+	}
+	text, err := html.ExtractText(doc)
+	if err != nil {
+		t.Fatalf("ExtractText() failed: %v", err)
+	}
+	if strings.Contains(text, "Row template text") {
+		t.Errorf("ExtractText() = %q, want template text dropped by default", text)
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.IncludeTemplates = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if !strings.Contains(result.Text, "Row template text") {
+		t.Errorf("IncludeTemplates Text = %q, want the template text", result.Text)
+	}
+}
@@ -0,0 +1,83 @@
+package html
+
+// textbytag.go implements ExtractTextByTag, a lightweight scraping helper that
+// skips article detection.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergodev/html/internal"
+	stdxhtml "golang.org/x/net/html"
+)
+
+// ExtractTextByTag returns the whitespace-collapsed text of every element of
+// htmlContent named tag, such as "h2" or "blockquote", in document order, for
+// quick scraping that needs no article detection. The tag is matched
+// case-insensitively; elements without text are skipped, and a matching
+// element nested in another yields its own entry too. The document is
+// sanitized first when EnableSanitization is set, so tags such as <script>
+// never match then.
+//
+// In addition to the input errors of [Processor.Extract], this method returns
+// ErrInvalidSelector when tag is not a plain tag name.
+func (p *Processor) ExtractTextByTag(htmlContent, tag string) ([]string, error) {
+	return recoverPanic(func() ([]string, error) {
+		if p == nil || p.closed.Load() {
+			return nil, ErrProcessorClosed
+		}
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || strings.ContainsAny(tag, " \t\n\r\f#.*[]:>+~,()") {
+			return nil, fmt.Errorf("%w: invalid tag name %q", ErrInvalidSelector, tag)
+		}
+		if err := p.validateInput([]byte(htmlContent)); err != nil {
+			return nil, err
+		}
+		if p.isBlankContent(htmlContent) {
+			return []string{}, nil
+		}
+
+		doc, err := stdxhtml.Parse(strings.NewReader(htmlContent))
+		if err != nil {
+			return nil, newExtractError("parse", -1, fmt.Errorf("%w: %w", ErrInvalidHTML, err))
+		}
+		if err := p.validateDepthTraversal(doc, 0); err != nil {
+			return nil, err
+		}
+		if p.config.EnableSanitization {
+			internal.SanitizeDOM(doc, internal.NoOpAuditRecorder{})
+		}
+
+		buf := getTextBuffer()
+		defer putTextBuffer(buf)
+
+		texts := []string{}
+		internal.WalkNodes(doc, func(n *stdxhtml.Node) bool {
+			if n.Type == stdxhtml.ElementNode && n.Data == tag {
+				buf.Reset()
+				p.writeStructuredText(n, buf, nil, nil)
+				if text := strings.Join(strings.Fields(buf.String()), " "); text != "" {
+					texts = append(texts, text)
+				}
+			}
+			return true
+		})
+		return texts, nil
+	})
+}
+
+// ExtractTextByTag returns the text of every element of htmlContent named tag.
+// This is a convenience function that uses a pooled Processor for efficiency.
+// See Processor.ExtractTextByTag for how elements are matched.
+//
+// An optional Config can be provided to customize extraction behavior.
+// If no config is provided, DefaultConfig() is used.
+func ExtractTextByTag(htmlContent, tag string, cfg ...Config) ([]string, error) {
+	c, pooled, err := resolveConfig(cfg...)
+	if err != nil {
+		return nil, err
+	}
+	return withProcessor(pooled, c, func(p *Processor) ([]string, error) {
+		return p.ExtractTextByTag(htmlContent, tag)
+	})
+}
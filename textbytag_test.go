@@ -0,0 +1,47 @@
+package html_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestExtractTextByTag(t *testing.T) {
+	t.Parallel()
+
+	doc := `<html><body>
+		<h2>First   section</h2>
+		<p>Body text.</p>
+		<H2>Second <em>section</em></H2>
+		<h2> </h2>
+		<blockquote><p>One quote.</p><p>Two sentences.</p></blockquote>
+		<script>var h2 = "<h2>not text</h2>";</script>
+	</body></html>`
+
+	got, err := html.ExtractTextByTag(doc, "H2")
+	if err != nil {
+		t.Fatalf("ExtractTextByTag() failed: %v", err)
+	}
+	if want := []string{"First section", "Second section"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractTextByTag(h2) = %q, want %q", got, want)
+	}
+
+	got, err = html.ExtractTextByTag(doc, "blockquote")
+	if err != nil {
+		t.Fatalf("ExtractTextByTag() failed: %v", err)
+	}
+	if want := []string{"One quote. Two sentences."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractTextByTag(blockquote) = %q, want %q", got, want)
+	}
+
+	if got, err := html.ExtractTextByTag(doc, "table"); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("ExtractTextByTag(table) = %q, %v, want an empty slice", got, err)
+	}
+	for _, tag := range []string{"", "div.post", "h2 p"} {
+		if _, err := html.ExtractTextByTag(doc, tag); !errors.Is(err, html.ErrInvalidSelector) {
+			t.Errorf("ExtractTextByTag(%q) error = %v, want ErrInvalidSelector", tag, err)
+		}
+	}
+}
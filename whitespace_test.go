@@ -0,0 +1,191 @@
+package html_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/html"
+)
+
+func TestPreservePreformatted(t *testing.T) {
+	t.Parallel()
+
+	doc := "<html><body><p>Example   program:</p><pre><code>func main() {\n" +
+		"\tif ok {\n" +
+		"        fmt.Println(\"a  b\")\n" +
+		"    }\n" +
+		"\n" +
+		"\n" +
+		"}</code></pre><p>After   the code.</p></body></html>"
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+
+	collapsed, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if strings.Contains(collapsed.Text, "        fmt.Println") {
+		t.Errorf("default Text should not keep <pre> indentation: %q", collapsed.Text)
+	}
+
+	cfg.PreservePreformatted = true
+	result, err := html.Extract([]byte(doc), cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	want := "Example program:\n\n" +
+		"func main() {\n" +
+		"\tif ok {\n" +
+		"        fmt.Println(\"a  b\")\n" +
+		"    }\n" +
+		"\n" +
+		"\n" +
+		"}\n\n" +
+		"After the code."
+	if result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+	if strings.ContainsRune(result.Text, 0) {
+		t.Errorf("Text contains marker byte: %q", result.Text)
+	}
+}
+
+func TestPreserveParagraphs(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><h1>Title</h1><p>First   paragraph
+		wraps here.</p><p>Second<br>line.</p><ul><li>one</li><li>two</li></ul></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	want := "Title\n\nFirst paragraph wraps here.\n\nSecond\nline.\n\n- one\n- two"
+	if result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+
+	cfg.PreserveParagraphs = false
+	flat, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if want := "Title First paragraph wraps here. Second line. - one - two"; flat.Text != want {
+		t.Errorf("flattened Text = %q, want %q", flat.Text, want)
+	}
+
+	t.Run("preformatted blocks survive flattening", func(t *testing.T) {
+		cfg.PreservePreformatted = true
+		result, err := html.Extract([]byte("<html><body><p>Run:</p><pre>make\n  test</pre><p>Done.</p></body></html>"), cfg)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if want := "Run:\n\nmake\n  test\n\nDone."; result.Text != want {
+			t.Errorf("Text = %q, want %q", result.Text, want)
+		}
+	})
+}
+
+func TestLineBreakMode(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><p>Jane Doe<br>12 Main Street<br/>Springfield</p>` +
+		`<pre>a<br>b</pre><p>End.</p></body></html>`)
+
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"", "Jane Doe\n12 Main Street\nSpringfield\n\na\nb\n\nEnd."},
+		{"newline", "Jane Doe\n12 Main Street\nSpringfield\n\na\nb\n\nEnd."},
+		{"space", "Jane Doe 12 Main Street Springfield\n\na\nb\n\nEnd."},
+		{"Paragraph", "Jane Doe\n\n12 Main Street\n\nSpringfield\n\na\nb\n\nEnd."},
+	}
+	for _, tt := range tests {
+		cfg := html.DefaultConfig()
+		cfg.ExtractArticle = false
+		cfg.PreservePreformatted = true
+		cfg.LineBreakMode = tt.mode
+		result, err := html.Extract(doc, cfg)
+		if err != nil {
+			t.Fatalf("LineBreakMode %q: Extract() error = %v", tt.mode, err)
+		}
+		if result.Text != tt.want {
+			t.Errorf("LineBreakMode %q: Text = %q, want %q", tt.mode, result.Text, tt.want)
+		}
+	}
+
+	cfg := html.DefaultConfig()
+	cfg.LineBreakMode = "tab"
+	if _, err := html.Extract(doc, cfg); !errors.Is(err, html.ErrInvalidConfig) {
+		t.Errorf("LineBreakMode %q: error = %v, want ErrInvalidConfig", cfg.LineBreakMode, err)
+	}
+}
+
+func TestStripControlCharsAndEmoji(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte("<html><body><p>Launch \U0001F680 day\u0085 is here \U0001F44D\U0001F3FD!</p><p>☐ Ship ✅</p></body></html>")
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if !strings.Contains(result.Text, "\U0001F680") || !strings.Contains(result.Text, "\u0085") {
+		t.Errorf("default Text = %q, want emoji and control characters kept", result.Text)
+	}
+
+	cfg.StripControlChars = true
+	cfg.StripEmoji = true
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if want := "Launch day is here !\n\n[ ] Ship"; result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+}
+
+func TestMaxParagraphs(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`<html><body><article>
+		<h1>Heading</h1>
+		<div><p>First paragraph.</p><p>Second paragraph.</p></div>
+		<ul><li>One</li><li>Two</li></ul>
+		<p>Last paragraph.</p>
+	</article></body></html>`)
+
+	cfg := html.DefaultConfig()
+	cfg.ExtractArticle = false
+	cfg.MaxParagraphs = 3
+	result, err := html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if want := "Heading\n\nFirst paragraph.\n\nSecond paragraph."; result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+
+	cfg.MaxParagraphs = 4
+	result, err = html.Extract(doc, cfg)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if !strings.HasSuffix(result.Text, "Two") || strings.Contains(result.Text, "Last") {
+		t.Errorf("Text = %q, want it to end with the list", result.Text)
+	}
+
+	cfg.MaxParagraphs = -1
+	if _, err := html.New(cfg); !errors.Is(err, html.ErrInvalidConfig) {
+		t.Errorf("New(MaxParagraphs=-1) error = %v, want ErrInvalidConfig", err)
+	}
+}